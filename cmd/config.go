@@ -0,0 +1,92 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// configFileName is the config file that validate reads from the current
+// directory and init writes.
+const configFileName = ".helm-values-checker.yaml"
+
+// config holds defaults for validate flags. A flag given on the command
+// line takes precedence over its setting here.
+type config struct {
+	Chart      string   `yaml:"chart"`
+	IgnoreKeys []string `yaml:"ignoreKeys"`
+	Strict     bool     `yaml:"strict"`
+	Output     string   `yaml:"output"`
+}
+
+// configTemplate is the commented config file written by init.
+const configTemplate = `# helm-values-checker configuration. "helm-values-checker validate" reads
+# this file from the current directory; flags given on the command line
+# take precedence over the settings here.
+
+# Chart to validate against: repo/name, OCI URL, or local path.
+chart: ""
+
+# Key paths skipped by all checks (glob patterns).
+ignoreKeys: []
+#  - "global.**"
+
+# Treat warnings as errors.
+strict: false
+
+# Output format: text, table, json, ndjson, yaml, tap, gitlab, or template.
+output: text
+`
+
+// loadConfig reads the config file at path. A missing file is no error and
+// yields nil; unknown settings are.
+func loadConfig(path string) (*config, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading config %s: %w", path, err)
+	}
+
+	cfg := &config{}
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(cfg); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("parsing config %s: %w", path, err)
+	}
+	return cfg, nil
+}
+
+// applyConfig sets the validate flags that cfg configures and the command
+// line leaves unset.
+func applyConfig(cmd *cobra.Command, cfg *config) error {
+	if cfg == nil {
+		return nil
+	}
+	settings := []struct {
+		flag, value string
+		set         bool
+	}{
+		{"chart", cfg.Chart, cfg.Chart != ""},
+		{"ignore-keys", strings.Join(cfg.IgnoreKeys, ","), len(cfg.IgnoreKeys) > 0},
+		{"strict", strconv.FormatBool(cfg.Strict), cfg.Strict},
+		{"output", cfg.Output, cfg.Output != ""},
+	}
+	for _, s := range settings {
+		if !s.set || cmd.Flags().Changed(s.flag) {
+			continue
+		}
+		if err := cmd.Flags().Set(s.flag, s.value); err != nil {
+			return fmt.Errorf("config %s: invalid %s: %w", configFileName, s.flag, err)
+		}
+	}
+	return nil
+}
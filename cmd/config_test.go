@@ -0,0 +1,50 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func TestLoadConfig(t *testing.T) {
+	dir := t.TempDir()
+	if cfg, err := loadConfig(filepath.Join(dir, configFileName)); cfg != nil || err != nil {
+		t.Errorf("expected no config and no error for a missing file, got %+v, %v", cfg, err)
+	}
+
+	path := filepath.Join(dir, configFileName)
+	if err := os.WriteFile(path, []byte("chart: ./my-chart\nstrict: true\nignoreKey: [a]\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := loadConfig(path); err == nil || !strings.Contains(err.Error(), "ignoreKey") {
+		t.Errorf("expected an error naming the unknown setting, got %v", err)
+	}
+}
+
+func TestApplyConfig_FlagsTakePrecedence(t *testing.T) {
+	var charts, ignore []string
+	var strictFlag bool
+	var output string
+	cmd := &cobra.Command{Use: "validate"}
+	cmd.Flags().StringArrayVar(&charts, "chart", nil, "")
+	cmd.Flags().StringSliceVar(&ignore, "ignore-keys", nil, "")
+	cmd.Flags().BoolVar(&strictFlag, "strict", false, "")
+	cmd.Flags().StringVar(&output, "output", "text", "")
+	if err := cmd.Flags().Parse([]string{"--output", "json"}); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := &config{Chart: "./my-chart", IgnoreKeys: []string{"global.**", "extra"}, Strict: true, Output: "table"}
+	if err := applyConfig(cmd, cfg); err != nil {
+		t.Fatalf("applyConfig error: %v", err)
+	}
+	if len(charts) != 1 || charts[0] != "./my-chart" || len(ignore) != 2 || !strictFlag {
+		t.Errorf("expected the config to fill unset flags, got chart %v, ignore-keys %v, strict %t", charts, ignore, strictFlag)
+	}
+	if output != "json" {
+		t.Errorf("expected --output from the command line to win, got %q", output)
+	}
+}
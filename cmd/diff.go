@@ -0,0 +1,76 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/chrishham/helm-values-checker/internal/chart"
+	"github.com/chrishham/helm-values-checker/internal/output"
+	"github.com/chrishham/helm-values-checker/internal/validator"
+	"github.com/spf13/cobra"
+)
+
+var (
+	diffOldFile      string
+	diffNewFile      string
+	diffChartRef     string
+	diffChartVersion string
+	diffOutputFormat string
+	diffIgnoreKeys   []string
+)
+
+var diffCmd = &cobra.Command{
+	Use:   "diff",
+	Short: "Compare two values files against a Helm chart",
+	Long: `Compare an old and a new values file and report which leaf keys were
+added, removed, or changed. Added keys that the chart does not know about
+are marked as unknown.
+
+Examples:
+  helm-values-checker validate diff --old values-v1.yaml --new values-v2.yaml --chart bitnami/postgresql
+  helm-values-checker validate diff --old a.yaml --new b.yaml --chart ./local-chart/ --output json`,
+	RunE: runDiff,
+}
+
+func init() {
+	diffCmd.Flags().StringVar(&diffOldFile, "old", "", "Old values file (required)")
+	diffCmd.Flags().StringVar(&diffNewFile, "new", "", "New values file (required)")
+	diffCmd.Flags().StringVar(&diffChartRef, "chart", "", "Chart reference: repo/name, OCI URL, or local path (required)")
+	diffCmd.Flags().StringVar(&diffChartVersion, "version", "", "Chart version (optional, latest if omitted)")
+	diffCmd.Flags().StringVarP(&diffOutputFormat, "output", "o", "text", "Output format: text or json")
+	diffCmd.Flags().StringSliceVar(&diffIgnoreKeys, "ignore-keys", nil, "Key paths to ignore (glob patterns, e.g. 'global.*')")
+
+	_ = diffCmd.MarkFlagRequired("old")
+	_ = diffCmd.MarkFlagRequired("new")
+	_ = diffCmd.MarkFlagRequired("chart")
+
+	validateCmd.AddCommand(diffCmd)
+}
+
+func runDiff(cmd *cobra.Command, args []string) error {
+	resolved, err := chart.ResolveWithOptions(diffChartRef, diffChartVersion, chart.ResolveOptions{Timeout: pullTimeout})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return &ExitError{Code: 3}
+	}
+	defer resolved.Cleanup()
+
+	result, err := validator.Diff(diffOldFile, diffNewFile, resolved, diffIgnoreKeys)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return &ExitError{Code: 3}
+	}
+
+	switch diffOutputFormat {
+	case "json":
+		data, err := output.DiffToJSON(result)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: marshaling JSON: %v\n", err)
+			return &ExitError{Code: 3}
+		}
+		fmt.Println(string(data))
+	default:
+		output.PrintDiffText(result, os.Stdout)
+	}
+	return nil
+}
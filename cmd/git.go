@@ -0,0 +1,104 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// changedValuesFiles returns the *.yaml and *.yml files changed between the
+// merge base of ref and HEAD (git diff ref...HEAD) in the git repository
+// containing dir, as paths relative to dir, in git's order. Deleted files
+// are left out. If under is set, only files inside that directory
+// (relative to dir) are returned. ref must name a commit; anything else,
+// including refs starting with "-" that git would read as options, is
+// rejected.
+func changedValuesFiles(dir, ref, under string) ([]string, error) {
+	top, err := runGit(dir, "rev-parse", "--show-toplevel")
+	if err != nil {
+		return nil, fmt.Errorf("--since requires a git repository: %w", err)
+	}
+	if strings.HasPrefix(ref, "-") {
+		return nil, fmt.Errorf("--since must name a git ref, got %q", ref)
+	}
+	if _, err := runGit(dir, "rev-parse", "--verify", "--quiet", "--end-of-options", ref+"^{commit}"); err != nil {
+		return nil, fmt.Errorf("--since %s does not name a commit", ref)
+	}
+	out, err := runGit(dir, "diff", "--name-only", "--diff-filter=d", "-z", ref+"...HEAD", "--")
+	if err != nil {
+		return nil, fmt.Errorf("listing files changed since %s: %w", ref, err)
+	}
+
+	// git prints the toplevel with symlinks resolved, so dir and under are
+	// resolved too before paths are compared
+	base, err := realPath(dir)
+	if err != nil {
+		return nil, err
+	}
+	if under != "" {
+		if !filepath.IsAbs(under) {
+			under = filepath.Join(base, under)
+		}
+		if under, err = realPath(under); err != nil {
+			return nil, err
+		}
+	}
+
+	var files []string
+	for _, name := range strings.Split(out, "\x00") {
+		ext := strings.ToLower(filepath.Ext(name))
+		if name == "" || (ext != ".yaml" && ext != ".yml") {
+			continue
+		}
+		path := filepath.Join(strings.TrimSpace(top), filepath.FromSlash(name))
+		if under != "" && !fileWithin(path, under) {
+			continue
+		}
+		if rel, err := filepath.Rel(base, path); err == nil {
+			path = rel
+		}
+		files = append(files, path)
+	}
+	return files, nil
+}
+
+// realPath returns the absolute form of path with symlinks resolved where
+// it exists.
+func realPath(path string) (string, error) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return "", err
+	}
+	if real, err := filepath.EvalSymlinks(abs); err == nil {
+		return real, nil
+	}
+	return abs, nil
+}
+
+// fileWithin reports whether the file path is dir or lies inside it.
+func fileWithin(path, dir string) bool {
+	rel, err := filepath.Rel(dir, path)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+// runGit runs a git subcommand in dir and returns its standard output. On
+// failure the error carries git's own message.
+func runGit(dir string, args ...string) (string, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+	if err := cmd.Run(); err != nil {
+		if errors.Is(err, exec.ErrNotFound) {
+			return "", errors.New("git is not installed or not in PATH")
+		}
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", errors.New(msg)
+		}
+		return "", err
+	}
+	return stdout.String(), nil
+}
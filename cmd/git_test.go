@@ -0,0 +1,114 @@
+package cmd
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// gitRepo creates a repository in a temp dir with files committed on main.
+func gitRepo(t *testing.T, files map[string]string) string {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	dir := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	git(t, dir, "init", "-q", "-b", "main")
+	git(t, dir, "add", "-A")
+	git(t, dir, "commit", "-q", "-m", "initial")
+	return dir
+}
+
+func git(t *testing.T, dir string, args ...string) {
+	t.Helper()
+	args = append([]string{"-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)
+	if out, err := exec.Command("git", append([]string{"-C", dir}, args...)...).CombinedOutput(); err != nil {
+		t.Fatalf("git %v: %v\n%s", args, err, out)
+	}
+}
+
+func TestChangedValuesFiles(t *testing.T) {
+	dir := gitRepo(t, map[string]string{
+		"apps/web/values.yaml": "replicaCount: 1\n",
+		"apps/api/values.yml":  "replicaCount: 1\n",
+		"infra/values.yaml":    "replicaCount: 1\n",
+		"old.yaml":             "replicaCount: 1\n",
+	})
+	git(t, dir, "checkout", "-q", "-b", "feature")
+	for name, content := range map[string]string{
+		"apps/web/values.yaml": "replicaCount: 2\n",
+		"apps/api/values.yml":  "replicaCount: 2\n",
+		"infra/values.yaml":    "replicaCount: 2\n",
+		"README.md":            "docs\n",
+	} {
+		if err := os.WriteFile(filepath.Join(dir, filepath.FromSlash(name)), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	git(t, dir, "rm", "-q", "old.yaml")
+	git(t, dir, "add", "-A")
+	git(t, dir, "commit", "-q", "-m", "change")
+
+	files, err := changedValuesFiles(dir, "main", "")
+	if err != nil {
+		t.Fatalf("changedValuesFiles error: %v", err)
+	}
+	got := strings.Join(toSlash(files), ",")
+	if got != "apps/api/values.yml,apps/web/values.yaml,infra/values.yaml" {
+		t.Errorf("expected the changed YAML files without deletions, got %s", got)
+	}
+
+	files, err = changedValuesFiles(filepath.Join(dir, "apps"), "main", "web")
+	if err != nil {
+		t.Fatalf("changedValuesFiles error: %v", err)
+	}
+	if got := strings.Join(toSlash(files), ","); got != "web/values.yaml" {
+		t.Errorf("expected only files under apps/web relative to apps, got %s", got)
+	}
+}
+
+func TestChangedValuesFiles_NotARepo(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	_, err := changedValuesFiles(t.TempDir(), "main", "")
+	if err == nil || !strings.Contains(err.Error(), "--since requires a git repository") {
+		t.Errorf("expected a not-a-repository error, got %v", err)
+	}
+}
+
+func TestChangedValuesFiles_RejectsOptionRefs(t *testing.T) {
+	dir := gitRepo(t, map[string]string{"values.yaml": "replicaCount: 1\n"})
+	out := filepath.Join(t.TempDir(), "written")
+
+	_, err := changedValuesFiles(dir, "--output="+out, "")
+	if err == nil || !strings.Contains(err.Error(), "must name a git ref") {
+		t.Errorf("expected an option-like ref to be rejected, got %v", err)
+	}
+	if _, statErr := os.Stat(out); statErr == nil {
+		t.Error("expected git not to write the --output file")
+	}
+
+	if _, err := changedValuesFiles(dir, "no-such-branch", ""); err == nil || !strings.Contains(err.Error(), "does not name a commit") {
+		t.Errorf("expected an unknown ref to be rejected, got %v", err)
+	}
+}
+
+func toSlash(paths []string) []string {
+	out := make([]string, len(paths))
+	for i, p := range paths {
+		out[i] = filepath.ToSlash(p)
+	}
+	return out
+}
@@ -0,0 +1,52 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+var initForce bool
+
+var initCmd = &cobra.Command{
+	Use:   "init",
+	Short: "Write a commented " + configFileName + " config",
+	Long: `Write a commented ` + configFileName + ` to the current directory
+with defaults for the chart, ignored keys, strict mode, and output format
+of validate. An existing file is only replaced with --force.`,
+	RunE: runInit,
+}
+
+func init() {
+	initCmd.Flags().BoolVar(&initForce, "force", false, "Overwrite an existing config file")
+	rootCmd.AddCommand(initCmd)
+}
+
+func runInit(cmd *cobra.Command, args []string) error {
+	flags := os.O_WRONLY | os.O_CREATE | os.O_TRUNC
+	if !initForce {
+		flags |= os.O_EXCL
+	}
+	f, err := os.OpenFile(configFileName, flags, 0o644)
+	if errors.Is(err, os.ErrExist) {
+		fmt.Fprintf(os.Stderr, "Error: %s already exists (use --force to overwrite it)\n", configFileName)
+		return &ExitError{Code: 3}
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return &ExitError{Code: 3}
+	}
+	if _, err := f.WriteString(configTemplate); err != nil {
+		f.Close()
+		fmt.Fprintf(os.Stderr, "Error: writing %s: %v\n", configFileName, err)
+		return &ExitError{Code: 3}
+	}
+	if err := f.Close(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: writing %s: %v\n", configFileName, err)
+		return &ExitError{Code: 3}
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "Wrote %s\n", configFileName)
+	return nil
+}
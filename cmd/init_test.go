@@ -0,0 +1,39 @@
+package cmd
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestInitCmd(t *testing.T) {
+	t.Chdir(t.TempDir())
+	t.Cleanup(func() { initForce = false })
+
+	if out := runRoot(t, "init"); !strings.Contains(out, "Wrote "+configFileName) {
+		t.Errorf("expected a confirmation, got %q", out)
+	}
+	cfg, err := loadConfig(configFileName)
+	if err != nil || cfg == nil || cfg.Output != "text" {
+		t.Fatalf("expected the scaffold to load as a config, got %+v, %v", cfg, err)
+	}
+
+	if err := os.WriteFile(configFileName, []byte("chart: ./my-chart\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	rootCmd.SetArgs([]string{"init"})
+	defer rootCmd.SetArgs(nil)
+	var exitErr *ExitError
+	if err := rootCmd.Execute(); !errors.As(err, &exitErr) || exitErr.Code != 3 {
+		t.Errorf("expected exit code 3 for an existing config, got %v", err)
+	}
+	if data, _ := os.ReadFile(configFileName); string(data) != "chart: ./my-chart\n" {
+		t.Errorf("expected the existing config to be kept, got %q", data)
+	}
+
+	runRoot(t, "init", "--force")
+	if data, _ := os.ReadFile(configFileName); string(data) != configTemplate {
+		t.Errorf("expected --force to rewrite the config, got %q", data)
+	}
+}
@@ -0,0 +1,40 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/chrishham/helm-values-checker/internal/model"
+)
+
+// printResolveProfile writes the --profile line for resolving one chart.
+func printResolveProfile(w io.Writer, chartRef string, d time.Duration) {
+	fmt.Fprintf(w, "profile: resolve %s: %s\n", chartRef, roundDuration(d))
+}
+
+// printProfile writes one --profile line per result, listing the time each
+// validation phase took and their total.
+func printProfile(w io.Writer, results []*model.ValidationResult) {
+	for _, r := range results {
+		if len(r.Timings) == 0 {
+			continue
+		}
+		var phases []string
+		var total time.Duration
+		for _, t := range r.Timings {
+			phases = append(phases, fmt.Sprintf("%s %s", t.Phase, roundDuration(t.Duration)))
+			total += t.Duration
+		}
+		fmt.Fprintf(w, "profile: %s: %s, total %s\n", r.ValuesFile, strings.Join(phases, ", "), roundDuration(total))
+	}
+}
+
+// roundDuration trims durations to a readable precision.
+func roundDuration(d time.Duration) time.Duration {
+	if d >= time.Second {
+		return d.Round(time.Millisecond)
+	}
+	return d.Round(time.Microsecond)
+}
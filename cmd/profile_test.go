@@ -0,0 +1,33 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/chrishham/helm-values-checker/internal/model"
+)
+
+func TestPrintProfile(t *testing.T) {
+	results := []*model.ValidationResult{
+		{ValuesFile: "values.yaml", Timings: []model.PhaseTiming{
+			{Phase: "parse", Duration: 2 * time.Millisecond},
+			{Phase: "unknown keys", Duration: 1500 * time.Microsecond},
+			{Phase: "schema", Duration: 40 * time.Millisecond},
+		}},
+		{ValuesFile: "unprofiled.yaml"},
+	}
+
+	var buf bytes.Buffer
+	printResolveProfile(&buf, "./my-chart", 1234567*time.Nanosecond)
+	printProfile(&buf, results)
+	want := "profile: resolve ./my-chart: 1.235ms\n" +
+		"profile: values.yaml: parse 2ms, unknown keys 1.5ms, schema 40ms, total 43.5ms\n"
+	if buf.String() != want {
+		t.Errorf("unexpected profile output:\n%s\nwant:\n%s", buf.String(), want)
+	}
+	if strings.Contains(buf.String(), "unprofiled.yaml") {
+		t.Error("expected results without timings to be skipped")
+	}
+}
@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/chrishham/helm-values-checker/internal/model"
+	"github.com/spf13/cobra"
+)
+
+var rulesOutput string
+
+// ruleInfo is the JSON form of one rule in the rules command's output.
+type ruleInfo struct {
+	ID          string `json:"id"`
+	Severity    string `json:"severity"`
+	Description string `json:"description"`
+}
+
+var rulesCmd = &cobra.Command{
+	Use:   "rules",
+	Short: "List the checks and their rule IDs",
+	Long: `List every check the validator performs with its rule ID, default
+severity, and a one-line description. Rule IDs are what --show-rules
+prints and what JSON output and other formats report.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		out := cmd.OutOrStdout()
+
+		switch rulesOutput {
+		case "json":
+			rules := make([]ruleInfo, 0, len(model.Rules))
+			for _, r := range model.Rules {
+				rules = append(rules, ruleInfo{ID: r.ID, Severity: strings.ToLower(r.Severity.String()), Description: r.Description})
+			}
+			data, err := json.MarshalIndent(rules, "", "  ")
+			if err != nil {
+				return fmt.Errorf("marshaling rules: %w", err)
+			}
+			fmt.Fprintln(out, string(data))
+		case "text":
+			tw := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+			fmt.Fprintln(tw, "RULE\tSEVERITY\tDESCRIPTION")
+			for _, r := range model.Rules {
+				fmt.Fprintf(tw, "%s\t%s\t%s\n", r.ID, strings.ToLower(r.Severity.String()), r.Description)
+			}
+			return tw.Flush()
+		default:
+			return fmt.Errorf("invalid --output value %q (must be text or json)", rulesOutput)
+		}
+		return nil
+	},
+}
+
+func init() {
+	rulesCmd.Flags().StringVarP(&rulesOutput, "output", "o", "text", "Output format: text or json")
+	rootCmd.AddCommand(rulesCmd)
+}
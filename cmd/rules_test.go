@@ -0,0 +1,30 @@
+package cmd
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestRulesCmd(t *testing.T) {
+	defer func() { rulesOutput = "text" }()
+
+	text := runRoot(t, "rules")
+	for _, id := range []string{"unknown-key", "type-mismatch", "schema-required", "schema-deprecated", "schema-one-of", "schema-not", "schema-items", "schema-unique"} {
+		if !strings.Contains(text, id) {
+			t.Errorf("expected rule %s to be listed, got:\n%s", id, text)
+		}
+	}
+
+	var rules []ruleInfo
+	if err := json.Unmarshal([]byte(runRoot(t, "rules", "--output", "json")), &rules); err != nil {
+		t.Fatalf("expected JSON output: %v", err)
+	}
+	severities := map[string]string{}
+	for _, r := range rules {
+		severities[r.ID] = r.Severity
+	}
+	if severities["unknown-key"] != "error" || severities["schema-deprecated"] != "warning" || severities["required-default"] != "info" {
+		t.Errorf("unexpected severities: %v", severities)
+	}
+}
@@ -1,14 +1,24 @@
 package cmd
 
 import (
+	"bufio"
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
+	"runtime"
+	"strings"
+	"text/template"
+	"time"
 
+	"github.com/chrishham/helm-values-checker/internal/baseline"
 	"github.com/chrishham/helm-values-checker/internal/chart"
+	"github.com/chrishham/helm-values-checker/internal/model"
 	"github.com/chrishham/helm-values-checker/internal/output"
 	"github.com/chrishham/helm-values-checker/internal/validator"
 	"github.com/spf13/cobra"
+	"golang.org/x/term"
+	"gopkg.in/yaml.v3"
 )
 
 // ExitError is returned from runValidate to signal a non-zero exit code
@@ -22,12 +32,66 @@ func (e *ExitError) Error() string {
 }
 
 var (
-	valuesFiles []string
-	chartRef    string
-	chartVersion string
-	outputFormat string
-	strict       bool
-	ignoreKeys   []string
+	valuesFiles       []string
+	filesFrom         string
+	sinceRef          string
+	sincePath         string
+	chartRefs         []string
+	chartVersion      string
+	chartDigest       string
+	chartValues       string
+	valuesKey         string
+	pullTimeout       time.Duration
+	outputFormat      string
+	strict            bool
+	failOn            string
+	ignoreKeys        []string
+	ignoreRequired    []string
+	allowKeys         []string
+	quantityPaths     []string
+	onlyChecks        []string
+	maxErrors         int
+	showInfo          bool
+	showRules         bool
+	explain           bool
+	groupBy           string
+	sortBy            string
+	coverage          bool
+	fix               bool
+	noSuggestions     bool
+	commentDirectives bool
+	profile           bool
+	assumeYes         bool
+	allowPlaceholders bool
+	allowTemplates    bool
+	allowEmpty        bool
+	strictUnknown     bool
+	jobs              int
+	setValues         []string
+	setStringValues   []string
+	baselineFile      string
+	writeBaseline     bool
+	useCache          bool
+	cacheDir          string
+	printChartInfo    bool
+	warnRedundant     bool
+	templateText      string
+	templateFile      string
+
+	// reportTemplate is the parsed --template or --template-file, set when
+	// --output is template.
+	reportTemplate *template.Template
+
+	errorExitCode   int
+	warningExitCode int
+
+	releaseName      string
+	releaseNamespace string
+	chartFromRelease string
+	escalations      []string
+	repoURL          string
+	baseFile         string
+	overlayFiles     []string
 )
 
 var validateCmd = &cobra.Command{
@@ -45,63 +109,675 @@ Checks performed:
 Examples:
   helm-values-checker validate -f my-values.yaml --chart bitnami/postgresql
   helm-values-checker validate -f my-values.yaml --chart ./local-chart/ --strict
-  helm-values-checker validate -f my-values.yaml --chart bitnami/postgresql --output json`,
+  helm-values-checker validate -f my-values.yaml --chart bitnami/postgresql --output json
+  helm-values-checker validate --release my-db --namespace data --chart bitnami/postgresql
+  helm-values-checker validate --release my-db --namespace data --chart-from-release my-db
+  git diff --name-only -- '*values*.yaml' | helm-values-checker validate --files-from - --chart ./local-chart/`,
 	RunE: runValidate,
 }
 
 func init() {
-	validateCmd.Flags().StringSliceVarP(&valuesFiles, "file", "f", nil, "Values file(s) to validate (required)")
-	validateCmd.Flags().StringVar(&chartRef, "chart", "", "Chart reference: repo/name, OCI URL, or local path (required)")
-	validateCmd.Flags().StringVar(&chartVersion, "version", "", "Chart version (optional, latest if omitted)")
-	validateCmd.Flags().StringVarP(&outputFormat, "output", "o", "text", "Output format: text or json")
-	validateCmd.Flags().BoolVar(&strict, "strict", false, "Treat warnings as errors (exit code 2)")
+	validateCmd.Flags().StringSliceVarP(&valuesFiles, "file", "f", nil, "Values file(s), directories of them, or http(s) URLs to validate (required unless --release is set)")
+	validateCmd.Flags().StringVar(&baseFile, "base", "", "Base values file to merge --overlay files over, validating the merged result")
+	validateCmd.Flags().StringArrayVar(&overlayFiles, "overlay", nil, "Values file merged over --base (maps merge, scalars and lists replace); repeat to apply several in order")
+	validateCmd.Flags().StringVar(&filesFrom, "files-from", "", "Read newline-delimited values file paths from a file, or '-' for stdin")
+	validateCmd.Flags().StringVar(&sinceRef, "since", "", "Validate the *.yaml and *.yml files changed since this git ref (git diff <ref>...HEAD)")
+	validateCmd.Flags().StringVar(&sincePath, "path", "", "With --since, only validate changed files under this directory")
+	validateCmd.Flags().StringArrayVar(&chartRefs, "chart", nil, "Chart reference: repo/name, OCI URL, or local path (required unless set in .helm-values-checker.yaml; repeat to validate against several charts)")
+	validateCmd.Flags().StringVar(&repoURL, "repo", "", "Chart repository URL to pull --chart from by name, without helm repo add (like helm install --repo)")
+	validateCmd.Flags().StringVar(&chartVersion, "version", "", "Chart version (optional, latest if omitted; only with a single --chart)")
+	validateCmd.Flags().StringVar(&chartDigest, "chart-digest", "", "Expected sha256:<hex> digest of the chart archive; fail if the pulled chart differs (only with a single --chart)")
+	validateCmd.Flags().StringVar(&valuesKey, "values-key", "", "Dotted key of the mapping in each values file that holds the chart's values (e.g., helm); only it is validated")
+	validateCmd.Flags().StringVar(&chartValues, "chart-values", "", "Values file in the chart to use as the defaults instead of values.yaml (e.g., values-production.yaml)")
+	validateCmd.Flags().StringVarP(&outputFormat, "output", "o", "text", "Output format: text, table, json, ndjson, yaml, tap, gitlab, or template")
+	validateCmd.Flags().StringVar(&templateText, "template", "", "Go text/template for --output template, executed once per values file")
+	validateCmd.Flags().StringVar(&templateFile, "template-file", "", "File holding a Go text/template for --output template")
+	validateCmd.Flags().BoolVar(&strict, "strict", false, "Treat warnings as errors (alias for --fail-on=warning)")
+	validateCmd.Flags().StringVar(&failOn, "fail-on", "error", "Lowest severity that causes a nonzero exit: error, warning, or none (overrides --strict when set)")
+	validateCmd.Flags().IntVar(&errorExitCode, "error-exit-code", 1, "Exit code when validation errors are found")
+	validateCmd.Flags().IntVar(&warningExitCode, "warning-exit-code", 2, "Exit code when only warnings are found with --fail-on=warning")
+	validateCmd.Flags().StringArrayVar(&setValues, "set", nil, "Override values like helm install --set (key1=val1,key2=val2), merged over each values file")
+	validateCmd.Flags().StringArrayVar(&setStringValues, "set-string", nil, "Like --set, but values are always strings")
 	validateCmd.Flags().StringSliceVar(&ignoreKeys, "ignore-keys", nil, "Key paths to ignore (glob patterns, e.g. 'global.*')")
-
-	_ = validateCmd.MarkFlagRequired("file")
-	_ = validateCmd.MarkFlagRequired("chart")
+	validateCmd.Flags().StringSliceVar(&ignoreRequired, "ignore-required", nil, "Schema-required key paths that may be missing, e.g. when supplied at deploy time (glob patterns, e.g. 'auth.password')")
+	validateCmd.Flags().StringSliceVar(&allowKeys, "allow-keys", nil, "Only allow these key paths to be set; any other key is an error (glob patterns, e.g. 'image.tag,resources.**')")
+	validateCmd.Flags().StringSliceVar(&quantityPaths, "quantity-paths", nil, "Key paths holding Kubernetes quantities, where strings like 10Gi and numbers are interchangeable (glob patterns, e.g. 'persistence.size')")
+	validateCmd.Flags().StringArrayVar(&escalations, "escalate", nil, "Set the severity of findings under a key path, as <glob>=error|warning|info (e.g. 'security.**=error'); repeatable, the last match wins")
+	validateCmd.Flags().StringSliceVar(&onlyChecks, "only", nil, "Run only these checks: unknown (which includes disabled and missing subcharts), type, schema, deprecated (default: all)")
+	validateCmd.Flags().StringVar(&releaseName, "release", "", "Validate the user-supplied values of a deployed release")
+	validateCmd.Flags().StringVar(&chartFromRelease, "chart-from-release", "", "Validate against the chart stored with this deployed release instead of --chart (in --namespace)")
+	validateCmd.Flags().StringVarP(&releaseNamespace, "namespace", "n", "", "Namespace of the release (default: current kube context namespace)")
+	validateCmd.Flags().StringVar(&baselineFile, "baseline", "", "Baseline file of known findings to suppress, so that only new findings are reported")
+	validateCmd.Flags().BoolVar(&writeBaseline, "write-baseline", false, "Write the current findings to the --baseline file instead of reporting them")
+	validateCmd.PersistentFlags().DurationVar(&pullTimeout, "timeout", 60*time.Second, "Give up pulling a remote chart after this long (0 = no limit)")
+	validateCmd.Flags().BoolVar(&useCache, "cache", false, "Cache pulled remote charts and reuse them on later runs")
+	validateCmd.Flags().StringVar(&cacheDir, "cache-dir", "", "Directory for cached remote charts (implies --cache; default: user cache dir)")
+	validateCmd.Flags().BoolVar(&showInfo, "show-info", false, "Show informational findings (e.g., required keys left at their chart default)")
+	validateCmd.Flags().BoolVar(&showRules, "show-rules", false, "Append the rule ID of each finding in text output")
+	validateCmd.Flags().BoolVar(&explain, "explain", false, "Print guidance on how to fix each finding in text output")
+	validateCmd.Flags().StringVar(&groupBy, "group-by", "", "Group findings in text output: path (by top-level key)")
+	validateCmd.Flags().StringVar(&sortBy, "sort", "", "Order findings by line, severity, or key (default: discovery order)")
+	validateCmd.Flags().BoolVar(&allowPlaceholders, "allow-placeholders", false, "Accept unexpanded ${VAR} placeholders in non-string fields without a warning")
+	validateCmd.Flags().BoolVar(&allowTemplates, "allow-templates", false, "Accept strings containing Go template actions ({{ ... }}) in non-string fields")
+	validateCmd.Flags().BoolVar(&strictUnknown, "strict-unknown", false, "Report keys missing from the chart's values.yaml even if the schema declares them")
+	validateCmd.Flags().BoolVar(&warnRedundant, "warn-redundant", false, "Warn about keys set to exactly their chart default value")
+	validateCmd.Flags().BoolVar(&coverage, "coverage", false, "Report which keys with a chart or schema default each values file leaves unset (text, json, and yaml output)")
+	validateCmd.Flags().BoolVar(&fix, "fix", false, "Rename misspelled or misplaced keys in the values files to their suggestion, after confirmation")
+	validateCmd.Flags().BoolVar(&noSuggestions, "no-suggestions", false, "Skip \"did you mean\" suggestions for unknown keys (faster on very large charts)")
+	validateCmd.Flags().BoolVar(&commentDirectives, "comment-directives", true, "Suppress findings on keys annotated with a '# values-checker:ignore' comment")
+	validateCmd.Flags().BoolVar(&profile, "profile", false, "Print the time spent resolving each chart and in each validation phase per file to stderr")
+	validateCmd.Flags().BoolVarP(&assumeYes, "yes", "y", false, "Apply --fix changes without asking for confirmation")
+	validateCmd.Flags().BoolVar(&allowEmpty, "allow-empty", false, "Treat empty or comments-only values files as valid instead of an error")
+	validateCmd.Flags().BoolVar(&printChartInfo, "print-chart-info", false, "Print what was loaded for the chart (metadata, schema, default keys, subcharts) and exit without validating")
+	validateCmd.Flags().IntVarP(&jobs, "jobs", "j", runtime.NumCPU(), "Number of values files to validate in parallel")
+	validateCmd.Flags().IntVar(&maxErrors, "max-errors", 0, "Maximum number of findings to list per file (0 = unlimited)")
 
 	rootCmd.AddCommand(validateCmd)
 }
 
 func runValidate(cmd *cobra.Command, args []string) error {
-	// Resolve chart
-	resolved, err := chart.Resolve(chartRef, chartVersion)
+	if chartFromRelease != "" {
+		for _, name := range []string{"chart", "repo", "version", "chart-digest", "chart-values"} {
+			if cmd.Flags().Changed(name) {
+				fmt.Fprintf(os.Stderr, "Error: --%s cannot be used with --chart-from-release\n", name)
+				return &ExitError{Code: 3}
+			}
+		}
+	}
+
+	cfg, err := loadConfig(configFileName)
+	if err == nil {
+		err = applyConfig(cmd, cfg)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return &ExitError{Code: 3}
+	}
+	if chartFromRelease != "" {
+		// The release's chart takes the place of one set in the config file
+		chartRefs = []string{"release/" + chartFromRelease}
+	} else if len(chartRefs) == 0 {
+		fmt.Fprintf(os.Stderr, "Error: --chart or --chart-from-release is required (or set chart in %s)\n", configFileName)
+		return &ExitError{Code: 3}
+	}
+
+	hasOverrides := len(setValues) > 0 || len(setStringValues) > 0
+	if len(valuesFiles) == 0 && filesFrom == "" && sinceRef == "" && releaseName == "" && baseFile == "" && !hasOverrides && !printChartInfo {
+		fmt.Fprintln(os.Stderr, "Error: at least one of --file, --files-from, --since, --release, --base, or --set is required")
+		return &ExitError{Code: 3}
+	}
+	if len(overlayFiles) > 0 && baseFile == "" {
+		fmt.Fprintln(os.Stderr, "Error: --overlay requires --base")
+		return &ExitError{Code: 3}
+	}
+	if sincePath != "" && sinceRef == "" {
+		fmt.Fprintln(os.Stderr, "Error: --path requires --since")
+		return &ExitError{Code: 3}
+	}
+
+	if groupBy != "" && groupBy != "path" {
+		fmt.Fprintf(os.Stderr, "Error: --group-by must be path, got %q\n", groupBy)
+		return &ExitError{Code: 3}
+	}
+	switch sortBy {
+	case "", output.SortLine, output.SortSeverity, output.SortKey:
+	default:
+		fmt.Fprintf(os.Stderr, "Error: --sort must be line, severity, or key, got %q\n", sortBy)
+		return &ExitError{Code: 3}
+	}
+
+	failOnLevel, err := resolveFailOn(cmd)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		return &ExitError{Code: 3}
 	}
-	defer resolved.Cleanup()
 
-	// Run validation for each values file
-	exitCode := 0
+	if jobs < 1 {
+		fmt.Fprintf(os.Stderr, "Error: --jobs must be at least 1, got %d\n", jobs)
+		return &ExitError{Code: 3}
+	}
+
+	if writeBaseline && baselineFile == "" {
+		fmt.Fprintln(os.Stderr, "Error: --write-baseline requires --baseline")
+		return &ExitError{Code: 3}
+	}
+
+	if outputFormat == "template" {
+		reportTemplate, err = output.LoadTemplate(templateText, templateFile)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return &ExitError{Code: 3}
+		}
+	} else if templateText != "" || templateFile != "" {
+		fmt.Fprintln(os.Stderr, "Error: --template and --template-file require --output template")
+		return &ExitError{Code: 3}
+	}
+
+	if err := checkOnly(onlyChecks); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return &ExitError{Code: 3}
+	}
+	escalationRules, err := validator.ParseEscalations(escalations)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return &ExitError{Code: 3}
+	}
+
+	if fix && len(chartRefs) > 1 {
+		fmt.Fprintln(os.Stderr, "Error: --fix cannot be used with more than one --chart")
+		return &ExitError{Code: 3}
+	}
+	if fix && noSuggestions {
+		fmt.Fprintln(os.Stderr, "Error: --fix applies suggestions, so it cannot be used with --no-suggestions")
+		return &ExitError{Code: 3}
+	}
+	if fix && baseFile != "" {
+		fmt.Fprintln(os.Stderr, "Error: --fix cannot be used with --base, whose findings span several files")
+		return &ExitError{Code: 3}
+	}
+	if fix && valuesKey != "" {
+		fmt.Fprintln(os.Stderr, "Error: --fix cannot be used with --values-key")
+		return &ExitError{Code: 3}
+	}
+	if releaseName != "" && valuesKey != "" {
+		fmt.Fprintln(os.Stderr, "Error: --values-key cannot be used with --release, whose values are the chart's already")
+		return &ExitError{Code: 3}
+	}
+	if fix && !assumeYes && filesFrom == "-" {
+		fmt.Fprintln(os.Stderr, "Error: --fix reads confirmation from stdin, so --files-from - requires --yes")
+		return &ExitError{Code: 3}
+	}
+
+	if len(chartRefs) > 1 && chartVersion != "" {
+		fmt.Fprintln(os.Stderr, "Error: --version cannot be used with more than one --chart")
+		return &ExitError{Code: 3}
+	}
+	if len(chartRefs) > 1 && chartDigest != "" {
+		fmt.Fprintln(os.Stderr, "Error: --chart-digest cannot be used with more than one --chart")
+		return &ExitError{Code: 3}
+	}
+
+	for _, code := range []int{errorExitCode, warningExitCode} {
+		if code < 0 || code > 255 {
+			fmt.Fprintf(os.Stderr, "Error: exit codes must be between 0 and 255, got %d\n", code)
+			return &ExitError{Code: 3}
+		}
+	}
+
+	// A directory given to --file stands for the values files inside it
+	var explicitFiles []string
 	for _, vf := range valuesFiles {
-		result, err := validator.Validate(vf, resolved, ignoreKeys)
+		if info, err := os.Stat(vf); err == nil && info.IsDir() {
+			dirFiles, err := validator.ValuesFilesInDir(vf)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				return &ExitError{Code: 3}
+			}
+			explicitFiles = append(explicitFiles, dirFiles...)
+			continue
+		}
+		explicitFiles = append(explicitFiles, vf)
+	}
+
+	// Files changed on the current branch count as given with --file
+	if sinceRef != "" {
+		changed, err := changedValuesFiles(".", sinceRef, sincePath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return &ExitError{Code: 3}
+		}
+		if len(changed) == 0 && len(valuesFiles) == 0 && filesFrom == "" && releaseName == "" && baseFile == "" && !printChartInfo {
+			fmt.Fprintf(os.Stderr, "No values files changed since %s\n", sinceRef)
+			return nil
+		}
+		explicitFiles = append(explicitFiles, changed...)
+	}
+
+	var listedFiles []string
+	if filesFrom != "" {
+		listedFiles, err = readFileList(filesFrom)
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error validating %s: %v\n", vf, err)
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 			return &ExitError{Code: 3}
 		}
+	}
 
-		switch outputFormat {
-		case "json":
-			data, err := json.MarshalIndent(output.ToJSON(result), "", "  ")
+	resolveOpts := chart.ResolveOptions{ValuesFile: chartValues, Timeout: pullTimeout, Digest: chartDigest, RepoURL: repoURL}
+	if useCache || cacheDir != "" {
+		resolveOpts.CacheDir = cacheDir
+		if resolveOpts.CacheDir == "" {
+			dir, err := chart.DefaultCacheDir()
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "Error marshaling JSON: %v\n", err)
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 				return &ExitError{Code: 3}
 			}
-			fmt.Println(string(data))
-		default:
-			output.PrintText(result, os.Stdout)
+			resolveOpts.CacheDir = dir
+		}
+	}
+
+	// Resolve charts
+	var charts []*chart.ResolvedChart
+	for _, ref := range chartRefs {
+		start := time.Now()
+		var resolved *chart.ResolvedChart
+		var err error
+		if chartFromRelease != "" {
+			resolved, err = chart.ResolveRelease(chartFromRelease, releaseNamespace)
+		} else {
+			resolved, err = chart.ResolveWithOptions(ref, chartVersion, resolveOpts)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return &ExitError{Code: 3}
+		}
+		if profile {
+			printResolveProfile(os.Stderr, ref, time.Since(start))
+		}
+		defer resolved.Cleanup()
+		charts = append(charts, resolved)
+	}
+
+	if printChartInfo {
+		var infos []chart.Info
+		for _, resolved := range charts {
+			infos = append(infos, resolved.Info())
+		}
+		return printInfo(infos)
+	}
+
+	valOpts := validator.Options{
+		IgnoreKeys:        ignoreKeys,
+		IgnoreRequired:    ignoreRequired,
+		QuantityPaths:     quantityPaths,
+		Only:              onlyChecks,
+		AllowPlaceholders: allowPlaceholders,
+		AllowTemplates:    allowTemplates,
+		AllowEmpty:        allowEmpty,
+		StrictUnknown:     strictUnknown,
+		WarnRedundant:     warnRedundant,
+		AllowKeys:         allowKeys,
+		Coverage:          coverage,
+		NoSuggestions:     noSuggestions,
+		Profile:           profile,
+		ValuesKey:         valuesKey,
+		CommentDirectives: commentDirectives,
+		Escalations:       escalationRules,
+	}
+	if hasOverrides {
+		overrides, err := validator.ParseOverrides(setValues, setStringValues)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return &ExitError{Code: 3}
+		}
+		valOpts.Overrides = overrides
+	}
+	outOpts := output.Options{MaxFindings: maxErrors, ShowInfo: showInfo, ShowRules: showRules, Explain: explain, GroupBy: groupBy, Sort: sortBy, Strict: failOnLevel == "warning"}
+	if outputFormat == "table" && term.IsTerminal(int(os.Stdout.Fd())) {
+		if width, _, err := term.GetSize(int(os.Stdout.Fd())); err == nil {
+			outOpts.Width = width
 		}
+	}
 
-		if result.HasErrors() {
-			exitCode = 1
-		} else if strict && result.HasWarnings() && exitCode < 2 {
-			exitCode = 2
+	// Fetch the values of a deployed release once, for all charts
+	var releaseData []byte
+	if releaseName != "" {
+		releaseData, err = chart.ReleaseValues(releaseName, releaseNamespace)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return &ExitError{Code: 3}
 		}
 	}
 
-	if exitCode != 0 {
-		return &ExitError{Code: exitCode}
+	// Each input is validated against every chart, chart by chart
+	files := append(append([]string(nil), explicitFiles...), listedFiles...)
+	var results []*model.ValidationResult
+	listFailed := false
+	for c, resolved := range charts {
+		// against names the chart in errors when there are several
+		against := ""
+		if len(charts) > 1 {
+			against = " against " + chartRefs[c]
+		}
+
+		// Run validation for all values files in parallel, collecting results
+		// in input order before printing so that multi-file output can carry
+		// aggregate totals
+		fileResults, fileErrs := validator.ValidateFiles(files, resolved, valOpts, jobs)
+		for i, vf := range files {
+			if err := fileErrs[i]; err != nil {
+				fmt.Fprintf(os.Stderr, "Error validating %s%s: %v\n", vf, against, err)
+
+				// Files from --files-from are validated independently: one that
+				// cannot be read or parsed does not abort the rest of the batch
+				if i < len(explicitFiles) {
+					return &ExitError{Code: 3}
+				}
+				listFailed = true
+				continue
+			}
+			results = append(results, fileResults[i])
+		}
+
+		// Validate the user-supplied values of a deployed release
+		if releaseName != "" {
+			source := "release/" + releaseName
+			result, err := validator.ValidateBytesWithOptions(source, releaseData, resolved, valOpts)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error validating %s%s: %v\n", source, against, err)
+				return &ExitError{Code: 3}
+			}
+			results = append(results, result)
+		}
+
+		// Validate a base file merged with its overlays
+		if baseFile != "" {
+			result, err := validator.ValidateOverlays(baseFile, overlayFiles, resolved, valOpts)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error validating %s%s: %v\n", baseFile, against, err)
+				return &ExitError{Code: 3}
+			}
+			results = append(results, result)
+		}
+
+		// With nothing else to validate, check the overrides on their own
+		if len(valuesFiles) == 0 && filesFrom == "" && sinceRef == "" && releaseName == "" && baseFile == "" {
+			result, err := validator.ValidateNodeWithOptions(&yaml.Node{Kind: yaml.MappingNode}, resolved, valOpts)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error validating --set values%s: %v\n", against, err)
+				return &ExitError{Code: 3}
+			}
+			result.ValuesFile = "(--set)"
+			results = append(results, result)
+		}
+	}
+	validator.AttributeUnknownKeys(results)
+	printProfile(os.Stderr, results)
+
+	if baselineFile != "" {
+		if writeBaseline {
+			if listFailed {
+				fmt.Fprintln(os.Stderr, "Error: not writing a baseline because some files could not be validated")
+				return &ExitError{Code: 3}
+			}
+			b := baseline.FromResults(results)
+			if err := b.Write(baselineFile); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				return &ExitError{Code: 3}
+			}
+			fmt.Fprintf(os.Stderr, "Wrote %d finding(s) to baseline %s\n", len(b.Findings), baselineFile)
+			return nil
+		}
+
+		b, err := baseline.Load(baselineFile)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return &ExitError{Code: 3}
+		}
+		if suppressed := b.Filter(results); suppressed > 0 {
+			fmt.Fprintf(os.Stderr, "Suppressed %d known finding(s) from baseline %s\n", suppressed, baselineFile)
+		}
+	}
+
+	if err := printResults(results, outOpts); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return &ExitError{Code: 3}
+	}
+
+	if fix {
+		if err := fixValuesFiles(results, files); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return &ExitError{Code: 3}
+		}
+	}
+
+	if listFailed {
+		return &ExitError{Code: 3}
+	}
+
+	sawErrors, sawWarnings := false, false
+	for _, result := range results {
+		sawErrors = sawErrors || result.HasErrors()
+		sawWarnings = sawWarnings || result.HasWarnings()
+	}
+
+	// Errors take precedence over warnings across all files
+	switch {
+	case failOnLevel == "none":
+	case sawErrors && errorExitCode != 0:
+		return &ExitError{Code: errorExitCode}
+	case !sawErrors && failOnLevel == "warning" && sawWarnings && warningExitCode != 0:
+		return &ExitError{Code: warningExitCode}
+	}
+	return nil
+}
+
+// fixValuesFiles offers the high-confidence key renames found in each
+// values file and applies them once confirmed (or with --yes). Prompts and
+// progress go to stderr so that report output on stdout stays parseable.
+// The results still describe the files as they were before fixing.
+func fixValuesFiles(results []*model.ValidationResult, files []string) error {
+	local := make(map[string]bool, len(files))
+	for _, f := range files {
+		local[f] = true
+	}
+
+	in := bufio.NewReader(os.Stdin)
+	for _, result := range results {
+		fixes := validator.Fixes(result)
+		if !local[result.ValuesFile] || len(fixes) == 0 {
+			continue
+		}
+
+		fmt.Fprintf(os.Stderr, "\nFixes for %s:\n", result.ValuesFile)
+		for _, f := range fixes {
+			fmt.Fprintf(os.Stderr, "  line %d: rename %q to %q\n", f.Line, f.From, f.To)
+		}
+		if !assumeYes {
+			fmt.Fprintf(os.Stderr, "Apply %d fix(es) to %s? [y/N] ", len(fixes), result.ValuesFile)
+			answer, _ := in.ReadString('\n')
+			if a := strings.ToLower(strings.TrimSpace(answer)); a != "y" && a != "yes" {
+				fmt.Fprintln(os.Stderr, "Skipped.")
+				continue
+			}
+		}
+
+		applied, err := validator.ApplyFixes(result.ValuesFile, fixes)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(os.Stderr, "Applied %d of %d fix(es) to %s\n", len(applied), len(fixes), result.ValuesFile)
+	}
+	return nil
+}
+
+// checkOnly rejects --only values that do not name a check.
+func checkOnly(checks []string) error {
+	for _, c := range checks {
+		known := false
+		for _, name := range validator.Checks {
+			known = known || c == name
+		}
+		if !known {
+			return fmt.Errorf("invalid --only value %q (must be one of %s)", c, strings.Join(validator.Checks, ", "))
+		}
+	}
+	return nil
+}
+
+// printInfo writes the --print-chart-info summary of each chart to stdout,
+// as JSON with --output json and as plain text otherwise. JSON output is a
+// single object for one chart and an array for several.
+func printInfo(infos []chart.Info) error {
+	if outputFormat == "json" {
+		var v interface{} = infos
+		if len(infos) == 1 {
+			v = infos[0]
+		}
+		data, err := json.MarshalIndent(v, "", "  ")
+		if err != nil {
+			return fmt.Errorf("marshaling chart info: %w", err)
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+
+	for i, info := range infos {
+		if i > 0 {
+			fmt.Println()
+		}
+		printInfoText(info)
+	}
+	return nil
+}
+
+// printInfoText writes the plain-text summary of one chart.
+func printInfoText(info chart.Info) {
+	fmt.Printf("Chart:     %s\n", info.Name)
+	fmt.Printf("Version:   %s\n", info.Version)
+	if info.HasSchema {
+		fmt.Printf("Schema:    values.schema.json%s\n", draftNote(info.SchemaDraft))
+	} else {
+		fmt.Println("Schema:    none")
+	}
+	fmt.Printf("Defaults:  %d top-level key(s)\n", len(info.TopLevelKeys))
+	for _, key := range info.TopLevelKeys {
+		fmt.Printf("  %s\n", key)
+	}
+	fmt.Printf("Subcharts: %d\n", len(info.Subcharts))
+	for _, sub := range info.Subcharts {
+		var notes []string
+		if sub.HasSchema {
+			note := strings.TrimSpace("schema " + sub.SchemaDraft)
+			if !chart.SupportedSchemaDraft(sub.SchemaDraft) {
+				note += ", not supported"
+			}
+			notes = append(notes, note)
+		}
+		if !sub.HasDefaults {
+			notes = append(notes, "no values.yaml")
+		}
+		line := fmt.Sprintf("  %s %s", sub.Name, sub.Version)
+		if len(notes) > 0 {
+			line += " (" + strings.Join(notes, ", ") + ")"
+		}
+		fmt.Println(line)
+	}
+}
+
+// draftNote describes a schema's declared draft for --print-chart-info,
+// flagging drafts that are not fully supported.
+func draftNote(draft string) string {
+	switch {
+	case draft == "":
+		return ""
+	case !chart.SupportedSchemaDraft(draft):
+		return fmt.Sprintf(" (%s, not supported: only draft-04 to draft-07 keywords are checked)", draft)
+	default:
+		return fmt.Sprintf(" (%s)", draft)
+	}
+}
+
+// readFileList reads newline-delimited file paths from path, or from stdin
+// when path is "-". Surrounding whitespace is trimmed and empty lines are
+// skipped.
+func readFileList(path string) ([]string, error) {
+	var r io.Reader = os.Stdin
+	if path != "-" {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, fmt.Errorf("reading --files-from: %w", err)
+		}
+		defer f.Close()
+		r = f
+	}
+
+	var files []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		if line := strings.TrimSpace(scanner.Text()); line != "" {
+			files = append(files, line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading --files-from: %w", err)
+	}
+	return files, nil
+}
+
+// resolveFailOn returns the effective --fail-on level. An explicitly set
+// --fail-on always wins; otherwise --strict selects "warning".
+func resolveFailOn(cmd *cobra.Command) (string, error) {
+	switch failOn {
+	case "error", "warning", "none":
+	default:
+		return "", fmt.Errorf("invalid --fail-on value %q (must be error, warning, or none)", failOn)
+	}
+	if cmd.Flags().Changed("fail-on") || !strict {
+		return failOn, nil
+	}
+	return "warning", nil
+}
+
+// printResults writes all validation results to stdout in the selected
+// output format. With more than one result, text output ends with aggregate
+// totals and JSON/YAML output becomes a single document holding every result
+// plus a summary.
+func printResults(results []*model.ValidationResult, outOpts output.Options) error {
+	switch outputFormat {
+	case "tap":
+		output.PrintTAP(results, os.Stdout, outOpts)
+	case "ndjson":
+		for _, result := range results {
+			if err := output.PrintNDJSON(result, os.Stdout, outOpts); err != nil {
+				return fmt.Errorf("writing NDJSON: %w", err)
+			}
+		}
+	case "template":
+		return output.PrintTemplate(reportTemplate, results, os.Stdout, outOpts)
+	case "gitlab":
+		data, err := output.ToGitLab(results, outOpts)
+		if err != nil {
+			return fmt.Errorf("marshaling GitLab report: %w", err)
+		}
+		fmt.Println(string(data))
+	case "table":
+		for _, result := range results {
+			output.PrintTable(result, os.Stdout, outOpts)
+		}
+		if len(results) > 1 {
+			output.PrintTextTotals(results, os.Stdout)
+		}
+	case "json":
+		var data []byte
+		var err error
+		if len(results) == 1 {
+			data, err = output.ToJSON(results[0], outOpts)
+		} else {
+			data, err = output.ToJSONAll(results, outOpts)
+		}
+		if err != nil {
+			return fmt.Errorf("marshaling JSON: %w", err)
+		}
+		fmt.Println(string(data))
+	case "yaml":
+		var data []byte
+		var err error
+		if len(results) == 1 {
+			data, err = output.ToYAML(results[0], outOpts)
+		} else {
+			data, err = output.ToYAMLAll(results, outOpts)
+		}
+		if err != nil {
+			return fmt.Errorf("marshaling YAML: %w", err)
+		}
+		fmt.Print(string(data))
+	default:
+		for _, result := range results {
+			output.PrintText(result, os.Stdout, outOpts)
+		}
+		if len(results) > 1 {
+			output.PrintTextTotals(results, os.Stdout)
+		}
 	}
 	return nil
 }
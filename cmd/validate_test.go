@@ -0,0 +1,122 @@
+package cmd
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/spf13/pflag"
+)
+
+// resetValidateFlags restores the validate flags to their defaults, since
+// cobra keeps flag values between executions.
+func resetValidateFlags() {
+	validateCmd.Flags().VisitAll(func(f *pflag.Flag) {
+		if !f.Changed {
+			return
+		}
+		if sv, ok := f.Value.(pflag.SliceValue); ok {
+			_ = sv.Replace(nil)
+		} else {
+			_ = f.Value.Set(f.DefValue)
+		}
+		f.Changed = false
+	})
+}
+
+// captureStdout runs fn with os.Stdout redirected to a pipe and returns
+// what it wrote, since validate prints its report straight to os.Stdout.
+func captureStdout(t *testing.T, fn func()) string {
+	t.Helper()
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	out := make(chan string)
+	go func() {
+		data, _ := io.ReadAll(r)
+		out <- string(data)
+	}()
+	stdout := os.Stdout
+	os.Stdout = w
+	defer func() { os.Stdout = stdout }()
+	fn()
+	w.Close()
+	return <-out
+}
+
+func TestValidateCmd_EscalateExitCode(t *testing.T) {
+	chartDir, err := filepath.Abs(filepath.Join("..", "testdata", "test-chart"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Chdir(t.TempDir())
+	if err := os.WriteFile("values.yaml", []byte("replicaCount: \"${REPLICAS}\"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(resetValidateFlags)
+	runRoot(t, "validate", "-f", "values.yaml", "--chart", chartDir, "--output", "json")
+
+	resetValidateFlags()
+	rootCmd.SetArgs([]string{"validate", "-f", "values.yaml", "--chart", chartDir, "--output", "json", "--escalate", "replicaCount=error"})
+	defer rootCmd.SetArgs(nil)
+	var exitErr *ExitError
+	if err := rootCmd.Execute(); !errors.As(err, &exitErr) || exitErr.Code != 1 {
+		t.Errorf("expected the escalated warning to exit 1, got %v", err)
+	}
+}
+
+func TestReadFileList_SkipsBlankLines(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "files.txt")
+	if err := os.WriteFile(path, []byte("a.yaml\n\n   \n  b.yaml  \n\t\nc.yaml"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	files, err := readFileList(path)
+	if err != nil {
+		t.Fatalf("readFileList error: %v", err)
+	}
+	if want := []string{"a.yaml", "b.yaml", "c.yaml"}; !reflect.DeepEqual(files, want) {
+		t.Errorf("expected %v, got %v", want, files)
+	}
+}
+
+func TestValidateCmd_FilesFromMissingFile(t *testing.T) {
+	chartDir, err := filepath.Abs(filepath.Join("..", "testdata", "test-chart"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Chdir(t.TempDir())
+	for name, content := range map[string]string{
+		"good.yaml": "replicaCount: 2\n",
+		"typo.yaml": "replicaCont: 2\n",
+		"files.txt": "good.yaml\n\nmissing.yaml\n  \ntypo.yaml\n",
+	} {
+		if err := os.WriteFile(name, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	t.Cleanup(resetValidateFlags)
+	rootCmd.SetArgs([]string{"validate", "--files-from", "files.txt", "--chart", chartDir, "--output", "json"})
+	defer rootCmd.SetArgs(nil)
+	var runErr error
+	out := captureStdout(t, func() { runErr = rootCmd.Execute() })
+
+	var exitErr *ExitError
+	if !errors.As(runErr, &exitErr) || exitErr.Code != 3 {
+		t.Errorf("expected the missing file to exit 3, got %v", runErr)
+	}
+	for _, file := range []string{`"good.yaml"`, `"typo.yaml"`} {
+		if !strings.Contains(out, file) {
+			t.Errorf("expected the other listed files to be validated, missing %s in:\n%s", file, out)
+		}
+	}
+	if strings.Contains(out, "missing.yaml") {
+		t.Errorf("expected no result for the missing file, got:\n%s", out)
+	}
+}
@@ -1,26 +1,82 @@
 package cmd
 
 import (
+	"encoding/json"
 	"fmt"
+	"os"
+	"path/filepath"
 
 	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
 )
 
 // Set via ldflags at build time.
 var (
-	version = "dev"
-	commit  = "none"
-	date    = "unknown"
+	Version = "dev"
+	Commit  = "none"
+	Date    = "unknown"
 )
 
+var versionOutput string
+
+// versionInfo is the JSON form of the version command's output.
+type versionInfo struct {
+	Version       string `json:"version"`
+	Commit        string `json:"commit"`
+	Date          string `json:"date"`
+	PluginVersion string `json:"pluginVersion,omitempty"`
+}
+
 var versionCmd = &cobra.Command{
 	Use:   "version",
 	Short: "Print version information",
-	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Printf("helm-values-checker %s (commit: %s, built: %s)\n", version, commit, date)
+	Long: `Print the version, commit, and build date of the binary. When run as a
+Helm plugin, the version declared in the installed plugin.yaml is shown
+as well, which helps spot a plugin whose binary was not updated.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		info := versionInfo{Version: Version, Commit: Commit, Date: Date, PluginVersion: pluginVersion()}
+		out := cmd.OutOrStdout()
+
+		switch versionOutput {
+		case "json":
+			data, err := json.MarshalIndent(info, "", "  ")
+			if err != nil {
+				return fmt.Errorf("marshaling version: %w", err)
+			}
+			fmt.Fprintln(out, string(data))
+		case "text":
+			fmt.Fprintf(out, "helm-values-checker %s (commit: %s, built: %s)\n", info.Version, info.Commit, info.Date)
+			if info.PluginVersion != "" {
+				fmt.Fprintf(out, "Helm plugin version: %s\n", info.PluginVersion)
+			}
+		default:
+			return fmt.Errorf("invalid --output value %q (must be text or json)", versionOutput)
+		}
+		return nil
 	},
 }
 
 func init() {
+	versionCmd.Flags().StringVarP(&versionOutput, "output", "o", "text", "Output format: text or json")
 	rootCmd.AddCommand(versionCmd)
 }
+
+// pluginVersion returns the version in plugin.yaml when running as a Helm
+// plugin (HELM_PLUGIN_DIR is set by helm), or "" otherwise.
+func pluginVersion() string {
+	dir := os.Getenv("HELM_PLUGIN_DIR")
+	if dir == "" {
+		return ""
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "plugin.yaml"))
+	if err != nil {
+		return ""
+	}
+	var plugin struct {
+		Version string `yaml:"version"`
+	}
+	if err := yaml.Unmarshal(data, &plugin); err != nil {
+		return ""
+	}
+	return plugin.Version
+}
@@ -0,0 +1,55 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// runRoot executes the root command with args and returns its stdout.
+func runRoot(t *testing.T, args ...string) string {
+	t.Helper()
+	var buf bytes.Buffer
+	rootCmd.SetOut(&buf)
+	rootCmd.SetArgs(args)
+	defer rootCmd.SetOut(nil)
+	defer rootCmd.SetArgs(nil)
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("%v: %v", args, err)
+	}
+	return buf.String()
+}
+
+func TestVersionCmd(t *testing.T) {
+	t.Setenv("HELM_PLUGIN_DIR", "")
+	defer func(v string) { Version = v }(Version)
+	Version = "1.2.3"
+
+	out := runRoot(t, "version")
+	if !strings.HasPrefix(out, "helm-values-checker 1.2.3 (commit: ") {
+		t.Errorf("expected the version string, got %q", out)
+	}
+	if strings.Contains(out, "plugin") {
+		t.Errorf("expected no plugin version outside helm, got %q", out)
+	}
+}
+
+func TestVersionCmd_JSONWithPlugin(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "plugin.yaml"), []byte("name: values-checker\nversion: \"0.4.0\"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("HELM_PLUGIN_DIR", dir)
+	defer func() { versionOutput = "text" }()
+
+	var info versionInfo
+	if err := json.Unmarshal([]byte(runRoot(t, "version", "-o", "json")), &info); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if info.Version != Version || info.PluginVersion != "0.4.0" {
+		t.Errorf("expected binary and plugin versions, got %+v", info)
+	}
+}
@@ -0,0 +1,126 @@
+// Package baseline records known findings so that later runs report only
+// new ones.
+package baseline
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/chrishham/helm-values-checker/internal/model"
+)
+
+// currentVersion is the baseline file format version.
+const currentVersion = 1
+
+// Entry identifies a finding independently of its line number, so that
+// edits elsewhere in a values file do not invalidate the baseline. Chart
+// scopes it to the chart the file was validated against.
+type Entry struct {
+	File    string `json:"file"`
+	Chart   string `json:"chart,omitempty"`
+	Rule    string `json:"rule"`
+	KeyPath string `json:"keyPath"`
+	Message string `json:"message"`
+}
+
+// Baseline is a set of known findings, as stored in a baseline file.
+type Baseline struct {
+	Version  int     `json:"version"`
+	Findings []Entry `json:"findings"`
+}
+
+func entryFor(result *model.ValidationResult, f model.Finding) Entry {
+	return Entry{File: result.ValuesFile, Chart: result.ChartName, Rule: f.Rule, KeyPath: f.KeyPath, Message: f.Message}
+}
+
+// FromResults builds a baseline holding every finding in results.
+func FromResults(results []*model.ValidationResult) *Baseline {
+	b := &Baseline{Version: currentVersion, Findings: []Entry{}}
+	seen := make(map[Entry]bool)
+	for _, result := range results {
+		for _, f := range result.Findings {
+			e := entryFor(result, f)
+			if !seen[e] {
+				seen[e] = true
+				b.Findings = append(b.Findings, e)
+			}
+		}
+	}
+
+	// Sort so that regenerating an unchanged baseline gives an identical file
+	sort.Slice(b.Findings, func(i, j int) bool {
+		a, c := b.Findings[i], b.Findings[j]
+		if a.File != c.File {
+			return a.File < c.File
+		}
+		if a.Chart != c.Chart {
+			return a.Chart < c.Chart
+		}
+		if a.KeyPath != c.KeyPath {
+			return a.KeyPath < c.KeyPath
+		}
+		if a.Rule != c.Rule {
+			return a.Rule < c.Rule
+		}
+		return a.Message < c.Message
+	})
+	return b
+}
+
+// Load reads a baseline file.
+func Load(path string) (*Baseline, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading baseline %s: %w", path, err)
+	}
+
+	var b Baseline
+	if err := json.Unmarshal(data, &b); err != nil {
+		return nil, fmt.Errorf("parsing baseline %s: %w", path, err)
+	}
+	if b.Version != currentVersion {
+		return nil, fmt.Errorf("baseline %s has unsupported version %d", path, b.Version)
+	}
+	return &b, nil
+}
+
+// Write stores the baseline as indented JSON at path.
+func (b *Baseline) Write(path string) error {
+	data, err := json.MarshalIndent(b, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshaling baseline: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("writing baseline %s: %w", path, err)
+	}
+	return nil
+}
+
+// Filter removes the findings recorded in the baseline from results and
+// returns how many were suppressed. Entries without a chart, as in
+// baselines written before entries recorded one, match under any chart.
+func (b *Baseline) Filter(results []*model.ValidationResult) int {
+	known := make(map[Entry]bool, len(b.Findings))
+	for _, e := range b.Findings {
+		known[e] = true
+	}
+
+	suppressed := 0
+	for _, result := range results {
+		kept := result.Findings[:0]
+		for _, f := range result.Findings {
+			e := entryFor(result, f)
+			unscoped := e
+			unscoped.Chart = ""
+			if known[e] || known[unscoped] {
+				suppressed++
+				continue
+			}
+			kept = append(kept, f)
+		}
+		result.Findings = kept
+	}
+	return suppressed
+}
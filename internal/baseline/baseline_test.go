@@ -0,0 +1,111 @@
+package baseline
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/chrishham/helm-values-checker/internal/model"
+)
+
+func sampleResults() []*model.ValidationResult {
+	return []*model.ValidationResult{
+		{
+			ValuesFile: "values.yaml",
+			ChartName:  "web",
+			Findings: []model.Finding{
+				{Severity: model.SeverityError, Rule: model.RuleUnknownKey, Line: 3, KeyPath: "image.regsitry", Message: `Unknown key "image.regsitry"`},
+				{Severity: model.SeverityWarning, Rule: model.RuleSchemaDeprecated, Line: 9, KeyPath: "oldSetting", Message: `Deprecated key "oldSetting"`},
+			},
+		},
+	}
+}
+
+func TestWriteAndLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "baseline.json")
+	if err := FromResults(sampleResults()).Write(path); err != nil {
+		t.Fatalf("Write error: %v", err)
+	}
+
+	b, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load error: %v", err)
+	}
+	if len(b.Findings) != 2 || b.Findings[0].KeyPath != "image.regsitry" || b.Findings[1].KeyPath != "oldSetting" {
+		t.Errorf("unexpected baseline entries: %+v", b.Findings)
+	}
+}
+
+func TestFilter_SuppressesOnlyKnownFindings(t *testing.T) {
+	b := FromResults(sampleResults())
+
+	// The known findings moved to other lines, and a new one appeared
+	results := sampleResults()
+	results[0].Findings[0].Line = 5
+	results[0].Findings[1].Line = 12
+	results[0].Findings = append(results[0].Findings, model.Finding{
+		Severity: model.SeverityError, Rule: model.RuleTypeMismatch, Line: 7, KeyPath: "replicaCount",
+		Message: `Type mismatch at "replicaCount": expected int, got string ("three")`,
+	})
+
+	if suppressed := b.Filter(results); suppressed != 2 {
+		t.Errorf("expected 2 suppressed findings, got %d", suppressed)
+	}
+	if len(results[0].Findings) != 1 || results[0].Findings[0].KeyPath != "replicaCount" {
+		t.Errorf("expected only the new finding to remain, got %v", results[0].Findings)
+	}
+}
+
+func TestFilter_ScopedByFile(t *testing.T) {
+	b := FromResults(sampleResults())
+
+	results := sampleResults()
+	results[0].ValuesFile = "other-values.yaml"
+	if suppressed := b.Filter(results); suppressed != 0 {
+		t.Errorf("expected findings in another file to be kept, got %d suppressed", suppressed)
+	}
+}
+
+func TestFilter_ScopedByChart(t *testing.T) {
+	b := FromResults(sampleResults())
+
+	results := sampleResults()
+	results[0].ChartName = "worker"
+	if suppressed := b.Filter(results); suppressed != 0 {
+		t.Errorf("expected findings under another chart to be kept, got %d suppressed", suppressed)
+	}
+
+	// Entries recorded without a chart still apply
+	for i := range b.Findings {
+		b.Findings[i].Chart = ""
+	}
+	if suppressed := b.Filter(sampleResults()); suppressed != 2 {
+		t.Errorf("expected chart-less entries to match, got %d suppressed", suppressed)
+	}
+}
+
+func TestFilter_IgnoresChartAttribution(t *testing.T) {
+	b := FromResults(sampleResults())
+
+	// Validating against a second chart that defines the key attributes it
+	results := sampleResults()
+	results[0].Findings[0].KnownTo = []string{"worker 1.0.0"}
+	if suppressed := b.Filter(results); suppressed != 2 {
+		t.Errorf("expected the attributed finding to stay suppressed, got %d suppressed", suppressed)
+	}
+}
+
+func TestLoad_Errors(t *testing.T) {
+	dir := t.TempDir()
+	if _, err := Load(filepath.Join(dir, "missing.json")); err == nil {
+		t.Error("expected error for a missing baseline")
+	}
+
+	path := filepath.Join(dir, "future.json")
+	if err := os.WriteFile(path, []byte(`{"version": 2, "findings": []}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load(path); err == nil {
+		t.Error("expected error for an unsupported version")
+	}
+}
@@ -0,0 +1,43 @@
+package chart
+
+import (
+	"encoding/json"
+	"regexp"
+)
+
+// draftURIRe matches the meta-schema URIs of the JSON Schema drafts, both
+// the old draft-NN form and the newer dated one.
+var draftURIRe = regexp.MustCompile(`^https?://json-schema\.org/(?:(draft-0\d)|draft/(\d{4}-\d{2}))/schema#?$`)
+
+// SchemaDraft returns the JSON Schema draft a schema declares in $schema:
+// "draft-07" or "2020-12" style names for known meta-schemas, the raw value
+// for any other URI, and "" when the field is absent or the schema does not
+// parse.
+func SchemaDraft(schema []byte) string {
+	var header struct {
+		Schema string `json:"$schema"`
+	}
+	if len(schema) == 0 || json.Unmarshal(schema, &header) != nil {
+		return ""
+	}
+	m := draftURIRe.FindStringSubmatch(header.Schema)
+	switch {
+	case m == nil:
+		return header.Schema
+	case m[1] != "":
+		return m[1]
+	default:
+		return m[2]
+	}
+}
+
+// SupportedSchemaDraft reports whether schemas of the given draft are
+// validated with their own rules. Undeclared drafts are accepted too; they
+// are checked with the keywords of draft-04 through draft-07.
+func SupportedSchemaDraft(draft string) bool {
+	switch draft {
+	case "", "draft-04", "draft-06", "draft-07":
+		return true
+	}
+	return false
+}
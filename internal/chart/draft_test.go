@@ -0,0 +1,28 @@
+package chart
+
+import "testing"
+
+func TestSchemaDraft(t *testing.T) {
+	tests := []struct {
+		schema    string
+		draft     string
+		supported bool
+	}{
+		{`{"$schema": "http://json-schema.org/draft-07/schema#", "type": "object"}`, "draft-07", true},
+		{`{"$schema": "https://json-schema.org/draft-04/schema", "type": "object"}`, "draft-04", true},
+		{`{"$schema": "https://json-schema.org/draft/2020-12/schema", "type": "object"}`, "2020-12", false},
+		{`{"$schema": "https://json-schema.org/draft/2019-09/schema"}`, "2019-09", false},
+		{`{"$schema": "https://example.com/my-meta-schema"}`, "https://example.com/my-meta-schema", false},
+		{`{"type": "object"}`, "", true},
+		{`not json`, "", true},
+	}
+	for _, tt := range tests {
+		draft := SchemaDraft([]byte(tt.schema))
+		if draft != tt.draft {
+			t.Errorf("SchemaDraft(%s) = %q, want %q", tt.schema, draft, tt.draft)
+		}
+		if got := SupportedSchemaDraft(draft); got != tt.supported {
+			t.Errorf("SupportedSchemaDraft(%q) = %v, want %v", draft, got, tt.supported)
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package chart
+
+import (
+	"sort"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Info summarizes what was loaded for a resolved chart, for diagnosing
+// unexpected validation results.
+type Info struct {
+	Name         string     `json:"name"`
+	Version      string     `json:"version"`
+	HasSchema    bool       `json:"hasSchema"`
+	SchemaDraft  string     `json:"schemaDraft,omitempty"`
+	TopLevelKeys []string   `json:"topLevelKeys"`
+	Subcharts    []Subchart `json:"subcharts"`
+}
+
+// Subchart describes a dependency bundled with a resolved chart.
+type Subchart struct {
+	Name        string `json:"name"`
+	Version     string `json:"version"`
+	HasSchema   bool   `json:"hasSchema"`
+	SchemaDraft string `json:"schemaDraft,omitempty"`
+	HasDefaults bool   `json:"hasDefaults"`
+}
+
+// Info returns a summary of the chart metadata, schema, default keys, and
+// subcharts. Subcharts are sorted by name.
+func (r *ResolvedChart) Info() Info {
+	info := Info{
+		HasSchema:    r.SchemaBytes != nil,
+		SchemaDraft:  SchemaDraft(r.SchemaBytes),
+		TopLevelKeys: TopLevelKeys(r.DefaultsNode),
+		Subcharts:    []Subchart{},
+	}
+	if r.Chart != nil && r.Chart.Metadata != nil {
+		info.Name = r.Chart.Metadata.Name
+		info.Version = r.Chart.Metadata.Version
+	}
+	if r.Chart != nil {
+		for _, dep := range r.Chart.Dependencies() {
+			sub := Subchart{Name: dep.Name(), HasSchema: dep.Schema != nil, SchemaDraft: SchemaDraft(dep.Schema)}
+			if dep.Metadata != nil {
+				sub.Version = dep.Metadata.Version
+			}
+			_, sub.HasDefaults = r.SubchartDefaults[dep.Name()]
+			info.Subcharts = append(info.Subcharts, sub)
+		}
+	}
+	sort.Slice(info.Subcharts, func(i, j int) bool { return info.Subcharts[i].Name < info.Subcharts[j].Name })
+	return info
+}
+
+// TopLevelKeys returns the keys of a mapping node in document order, or
+// none if node is not a mapping.
+func TopLevelKeys(node *yaml.Node) []string {
+	keys := []string{}
+	if node == nil || node.Kind != yaml.MappingNode {
+		return keys
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		keys = append(keys, node.Content[i].Value)
+	}
+	return keys
+}
@@ -0,0 +1,47 @@
+package chart
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestInfo_ReportsSchema(t *testing.T) {
+	resolved, err := Resolve(filepath.Join(testdataDir(), "test-chart-with-schema"), "")
+	if err != nil {
+		t.Fatalf("Resolve error: %v", err)
+	}
+	defer resolved.Cleanup()
+
+	info := resolved.Info()
+	if info.Name != "test-chart-with-schema" || !info.HasSchema {
+		t.Errorf("expected schema to be reported for %s, got %+v", info.Name, info)
+	}
+	if info.SchemaDraft != "draft-07" {
+		t.Errorf("expected the draft-07 header to be detected, got %q", info.SchemaDraft)
+	}
+	if len(info.TopLevelKeys) == 0 || info.TopLevelKeys[0] != "replicaCount" {
+		t.Errorf("expected top-level keys in document order, got %v", info.TopLevelKeys)
+	}
+
+	plain, err := Resolve(filepath.Join(testdataDir(), "test-chart"), "")
+	if err != nil {
+		t.Fatalf("Resolve error: %v", err)
+	}
+	defer plain.Cleanup()
+	if plain.Info().HasSchema {
+		t.Error("expected no schema for test-chart")
+	}
+}
+
+func TestInfo_ReportsSubcharts(t *testing.T) {
+	resolved, err := Resolve(filepath.Join(testdataDir(), "test-chart-with-subchart-schema"), "")
+	if err != nil {
+		t.Fatalf("Resolve error: %v", err)
+	}
+	defer resolved.Cleanup()
+
+	subs := resolved.Info().Subcharts
+	if len(subs) != 1 || subs[0].Name != "database" || !subs[0].HasSchema || !subs[0].HasDefaults {
+		t.Errorf("expected the database subchart with schema and defaults, got %+v", subs)
+	}
+}
@@ -0,0 +1,109 @@
+package chart
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v3"
+	"helm.sh/helm/v3/pkg/action"
+	helmchart "helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/cli"
+	"helm.sh/helm/v3/pkg/release"
+	"helm.sh/helm/v3/pkg/storage/driver"
+)
+
+// ReleaseValues fetches the user-supplied values of a deployed release and
+// returns them as a YAML document. An empty namespace uses the current
+// kube context's namespace, like helm itself.
+func ReleaseValues(name, namespace string) ([]byte, error) {
+	cfg, namespace, err := releaseConfig(namespace)
+	if err != nil {
+		return nil, err
+	}
+	return releaseValues(cfg, name, namespace)
+}
+
+// ResolveRelease loads the chart stored with a deployed release, so that
+// values are checked against exactly what is installed, schema included.
+// Helm does not store a release's subcharts, so their defaults are
+// unavailable. An empty namespace is handled as in ReleaseValues.
+func ResolveRelease(name, namespace string) (*ResolvedChart, error) {
+	cfg, namespace, err := releaseConfig(namespace)
+	if err != nil {
+		return nil, err
+	}
+	return releaseChart(cfg, name, namespace)
+}
+
+// releaseConfig initializes a Helm client for namespace (or the current
+// kube context's namespace when empty), which it returns.
+func releaseConfig(namespace string) (*action.Configuration, string, error) {
+	settings := cli.New()
+	if namespace != "" {
+		settings.SetNamespace(namespace)
+	}
+
+	cfg := new(action.Configuration)
+	if err := cfg.Init(settings.RESTClientGetter(), settings.Namespace(), os.Getenv("HELM_DRIVER"), func(string, ...interface{}) {}); err != nil {
+		return nil, "", fmt.Errorf("initializing helm client: %w", err)
+	}
+	return cfg, settings.Namespace(), nil
+}
+
+// getRelease fetches the latest revision of a release.
+func getRelease(cfg *action.Configuration, name, namespace string) (*release.Release, error) {
+	rel, err := action.NewGet(cfg).Run(name)
+	if err != nil {
+		if errors.Is(err, driver.ErrReleaseNotFound) {
+			return nil, fmt.Errorf("release %q not found in namespace %q", name, namespace)
+		}
+		return nil, fmt.Errorf("getting release %s: %w", name, err)
+	}
+	return rel, nil
+}
+
+func releaseValues(cfg *action.Configuration, name, namespace string) ([]byte, error) {
+	rel, err := getRelease(cfg, name, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(rel.Config) == 0 {
+		return []byte("{}\n"), nil
+	}
+
+	data, err := yaml.Marshal(rel.Config)
+	if err != nil {
+		return nil, fmt.Errorf("encoding values of release %s: %w", name, err)
+	}
+	return data, nil
+}
+
+func releaseChart(cfg *action.Configuration, name, namespace string) (*ResolvedChart, error) {
+	rel, err := getRelease(cfg, name, namespace)
+	if err != nil {
+		return nil, err
+	}
+	ch := rel.Chart
+	if ch == nil || ch.Metadata == nil {
+		return nil, fmt.Errorf("release %s has no stored chart", name)
+	}
+
+	// Stored charts keep their parsed values but not the raw files, so the
+	// defaults are re-encoded (in key order, without comments)
+	data := []byte("{}\n")
+	if len(ch.Values) > 0 {
+		if data, err = yaml.Marshal(ch.Values); err != nil {
+			return nil, fmt.Errorf("encoding chart values of release %s: %w", name, err)
+		}
+	}
+	stored := *ch
+	stored.Raw = append([]*helmchart.File{{Name: "values.yaml", Data: data}}, ch.Raw...)
+	resolved, err := buildResolved(&stored, "", "")
+	if err != nil {
+		return nil, err
+	}
+	resolved.FromRelease = true
+	return resolved, nil
+}
@@ -0,0 +1,129 @@
+package chart
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"helm.sh/helm/v3/pkg/action"
+	"helm.sh/helm/v3/pkg/chart"
+	kubefake "helm.sh/helm/v3/pkg/kube/fake"
+	"helm.sh/helm/v3/pkg/release"
+	"helm.sh/helm/v3/pkg/storage"
+	"helm.sh/helm/v3/pkg/storage/driver"
+)
+
+// fakeActionConfig returns an action.Configuration backed by in-memory
+// release storage and a no-op Kubernetes client.
+func fakeActionConfig(t *testing.T, releases ...*release.Release) *action.Configuration {
+	t.Helper()
+	cfg := &action.Configuration{
+		Releases:   storage.Init(driver.NewMemory()),
+		KubeClient: &kubefake.PrintingKubeClient{Out: io.Discard},
+		Log:        func(string, ...interface{}) {},
+	}
+	for _, r := range releases {
+		if err := cfg.Releases.Create(r); err != nil {
+			t.Fatalf("storing release: %v", err)
+		}
+	}
+	return cfg
+}
+
+func TestReleaseValues(t *testing.T) {
+	cfg := fakeActionConfig(t, &release.Release{
+		Name:      "web",
+		Namespace: "default",
+		Version:   1,
+		Info:      &release.Info{Status: release.StatusDeployed},
+		Chart:     &chart.Chart{Metadata: &chart.Metadata{Name: "test-chart", Version: "1.0.0"}},
+		Config:    map[string]interface{}{"replicaCount": 3, "image": map[string]interface{}{"tag": "v2"}},
+	})
+
+	data, err := releaseValues(cfg, "web", "default")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	out := string(data)
+	if !strings.Contains(out, "replicaCount: 3") || !strings.Contains(out, "tag: v2") {
+		t.Errorf("expected release values in YAML, got:\n%s", out)
+	}
+}
+
+func TestReleaseValues_NotFound(t *testing.T) {
+	cfg := fakeActionConfig(t)
+
+	_, err := releaseValues(cfg, "missing", "prod")
+	if err == nil {
+		t.Fatal("expected error for missing release, got nil")
+	}
+	if !strings.Contains(err.Error(), `release "missing" not found in namespace "prod"`) {
+		t.Errorf("expected clear not-found error, got: %v", err)
+	}
+}
+
+func TestReleaseChart(t *testing.T) {
+	cfg := fakeActionConfig(t, &release.Release{
+		Name:      "web",
+		Namespace: "default",
+		Version:   1,
+		Info:      &release.Info{Status: release.StatusDeployed},
+		Chart: &chart.Chart{
+			Metadata: &chart.Metadata{Name: "test-chart", Version: "1.2.0"},
+			Values:   map[string]interface{}{"replicaCount": 1, "image": map[string]interface{}{"tag": "v1"}},
+			Schema:   []byte(`{"type": "object", "required": ["image"]}`),
+		},
+	})
+
+	resolved, err := releaseChart(cfg, "web", "default")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer resolved.Cleanup()
+
+	info := resolved.Info()
+	if info.Name != "test-chart" || info.Version != "1.2.0" || !info.HasSchema {
+		t.Errorf("expected the stored chart with its schema, got %+v", info)
+	}
+	if keys := TopLevelKeys(resolved.DefaultsNode); len(keys) != 2 {
+		t.Errorf("expected the stored chart's defaults, got keys %v", keys)
+	}
+}
+
+func TestReleaseChart_NotFound(t *testing.T) {
+	cfg := fakeActionConfig(t)
+
+	_, err := releaseChart(cfg, "missing", "prod")
+	if err == nil || !strings.Contains(err.Error(), `release "missing" not found in namespace "prod"`) {
+		t.Errorf("expected clear not-found error, got: %v", err)
+	}
+}
+
+func TestReleaseChart_DeclaredDependency(t *testing.T) {
+	// Release storage keeps Metadata.Dependencies but not the subcharts
+	cfg := fakeActionConfig(t, &release.Release{
+		Name:      "web",
+		Namespace: "default",
+		Version:   1,
+		Info:      &release.Info{Status: release.StatusDeployed},
+		Chart: &chart.Chart{
+			Metadata: &chart.Metadata{Name: "test-chart", Version: "1.0.0", Dependencies: []*chart.Dependency{
+				{Name: "mysubchart", Version: "1.0.0", Condition: "mysubchart.enabled"},
+			}},
+			Values: map[string]interface{}{"replicaCount": 1},
+		},
+	})
+
+	resolved, err := releaseChart(cfg, "web", "default")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer resolved.Cleanup()
+
+	if !resolved.FromRelease {
+		t.Error("expected the chart to be marked as loaded from a release")
+	}
+	if toggles := resolved.SubchartToggles(); len(toggles) != 1 || !toggles[0].Bundled {
+		t.Errorf("expected the installed subchart's toggle to count as bundled, got %+v", toggles)
+	}
+}
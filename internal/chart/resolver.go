@@ -1,12 +1,19 @@
 package chart
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"io"
+	"net"
+	"net/http"
 	"os"
 	"path/filepath"
 	"regexp"
+	"sort"
 	"strings"
+	"time"
 
 	"gopkg.in/yaml.v3"
 	"helm.sh/helm/v3/pkg/chart"
@@ -15,6 +22,7 @@ import (
 	"helm.sh/helm/v3/pkg/downloader"
 	"helm.sh/helm/v3/pkg/getter"
 	"helm.sh/helm/v3/pkg/registry"
+	"helm.sh/helm/v3/pkg/repo"
 )
 
 // ResolvedChart holds a loaded chart with its parsed default values tree.
@@ -23,6 +31,8 @@ type ResolvedChart struct {
 	DefaultsNode     *yaml.Node            // yaml.Node tree of values.yaml
 	SchemaBytes      []byte                // raw values.schema.json, nil if absent
 	SubchartDefaults map[string]*yaml.Node // dependency name -> defaults node
+	SubchartSchemas  map[string][]byte     // dependency name -> raw values.schema.json
+	FromRelease      bool                  // loaded from release storage, which keeps no subcharts
 	tempDir          string                // set if we pulled a remote chart
 }
 
@@ -32,30 +42,110 @@ var (
 )
 
 // Cleanup removes any temporary files created during chart resolution.
+// Charts stored in a cache directory are left in place.
 func (r *ResolvedChart) Cleanup() {
 	if r.tempDir != "" {
 		os.RemoveAll(r.tempDir)
 	}
 }
 
+// ResolveOptions configures how charts are resolved.
+type ResolveOptions struct {
+	// CacheDir, when non-empty, stores pulled remote charts keyed by
+	// chartRef@version and reuses them on later runs instead of re-pulling.
+	CacheDir string
+
+	// ValuesFile, when non-empty, names the file in the chart (e.g.,
+	// "values-production.yaml") to use as the defaults instead of
+	// values.yaml. Resolution fails if the chart has no such file.
+	ValuesFile string
+
+	// Timeout, when positive, bounds pulling a remote chart: each HTTP
+	// request, and the pull as a whole. 0 means no limit.
+	Timeout time.Duration
+
+	// Digest, when non-empty, is the expected "sha256:<hex>" digest of the
+	// chart archive. A pulled (or cached) chart or a local archive that does
+	// not match fails resolution.
+	Digest string
+
+	// RepoURL, when non-empty, is the URL of a chart repository to pull
+	// the chart from by name, without it being added with helm repo add
+	// first (like helm install --repo).
+	RepoURL string
+}
+
+// cacheMaxAge bounds how long a cached chart pulled without an explicit
+// version is reused, since "latest" moves. Pinned versions never expire.
+const cacheMaxAge = 24 * time.Hour
+
 // Resolve loads a chart from a local path or pulls it from a remote repository.
 func Resolve(chartRef, version string) (*ResolvedChart, error) {
+	return ResolveWithOptions(chartRef, version, ResolveOptions{})
+}
+
+// ResolveWithOptions is like Resolve but accepts additional resolution options.
+func ResolveWithOptions(chartRef, version string, opts ResolveOptions) (*ResolvedChart, error) {
+	if opts.Digest != "" {
+		if _, err := parseDigest(opts.Digest); err != nil {
+			return nil, err
+		}
+	}
+	if opts.RepoURL != "" {
+		if err := checkRepoChart(chartRef, opts.RepoURL); err != nil {
+			return nil, err
+		}
+		return resolveRemote(chartRef, version, opts)
+	}
 	if isLocalPath(chartRef) {
-		return resolveLocal(chartRef)
+		return resolveLocal(chartRef, opts)
+	}
+	return resolveRemote(chartRef, version, opts)
+}
+
+// checkRepoChart checks that a chart pulled from an ad-hoc repository is
+// given by name, and that the repository is a classic HTTP one.
+func checkRepoChart(chartRef, repoURL string) error {
+	if registry.IsOCI(repoURL) {
+		return fmt.Errorf("--repo takes an http(s) chart repository, not %s; pass OCI charts as --chart oci://...", repoURL)
+	}
+	if !strings.HasPrefix(repoURL, "http://") && !strings.HasPrefix(repoURL, "https://") {
+		return fmt.Errorf("--repo must be an http(s) URL, got %s", repoURL)
+	}
+	if strings.Contains(chartRef, "/") {
+		return fmt.Errorf("with --repo, the chart must be given by name (e.g. postgresql), got %s", chartRef)
+	}
+	return nil
+}
+
+// DefaultCacheDir returns the default directory for cached remote charts.
+func DefaultCacheDir() (string, error) {
+	base, err := os.UserCacheDir()
+	if err != nil {
+		return "", fmt.Errorf("locating user cache dir: %w", err)
 	}
-	return resolveRemote(chartRef, version)
+	return filepath.Join(base, "helm-values-checker", "charts"), nil
 }
 
 func isLocalPath(ref string) bool {
-	// Treat as local if it starts with ., /, or ~ or exists on disk
+	// Treat as local if it starts with ., /, or ~, or is a directory or
+	// chart archive that exists on disk
 	if strings.HasPrefix(ref, ".") || strings.HasPrefix(ref, "/") || strings.HasPrefix(ref, "~") {
 		return true
 	}
 	info, err := os.Stat(ref)
-	return err == nil && info.IsDir()
+	if err != nil {
+		return false
+	}
+	return info.IsDir() || isChartArchive(ref)
+}
+
+// isChartArchive reports whether path names a packaged chart (.tgz or .tar.gz).
+func isChartArchive(path string) bool {
+	return strings.HasSuffix(path, ".tgz") || strings.HasSuffix(path, ".tar.gz")
 }
 
-func resolveLocal(path string) (*ResolvedChart, error) {
+func resolveLocal(path string, opts ResolveOptions) (*ResolvedChart, error) {
 	// Expand ~ if needed
 	if strings.HasPrefix(path, "~") {
 		home, err := os.UserHomeDir()
@@ -65,47 +155,113 @@ func resolveLocal(path string) (*ResolvedChart, error) {
 		path = filepath.Join(home, path[1:])
 	}
 
+	if opts.Digest != "" {
+		if !isChartArchive(path) {
+			return nil, fmt.Errorf("a chart digest can only be checked for a chart archive or remote chart, not %s", path)
+		}
+		if err := verifyDigest(path, opts.Digest); err != nil {
+			return nil, err
+		}
+	}
+
 	ch, err := loader.Load(path)
 	if err != nil {
 		return nil, fmt.Errorf("loading chart from %s: %w", path, err)
 	}
 
-	return buildResolved(ch, "")
+	return buildResolved(ch, "", opts.ValuesFile)
 }
 
-func resolveRemote(chartRef, version string) (*ResolvedChart, error) {
+func resolveRemote(chartRef, version string, opts ResolveOptions) (*ResolvedChart, error) {
+	if registry.IsOCI(chartRef) {
+		ref, tag := splitOCITag(chartRef)
+		if tag != "" {
+			if version != "" && version != tag {
+				return nil, fmt.Errorf("chart %s is tagged %s but --version %s was also given; use one or the other", chartRef, tag, version)
+			}
+			chartRef, version = ref, tag
+		}
+	}
+
+	// Charts from an ad-hoc repository are cached under the repository URL,
+	// since the bare name says nothing about where they came from
+	cacheRef := chartRef
+	if opts.RepoURL != "" {
+		cacheRef = strings.TrimSuffix(opts.RepoURL, "/") + "/" + chartRef
+	}
+
+	if opts.CacheDir != "" {
+		if cached := cachedChart(opts.CacheDir, cacheRef, version); cached != "" && (opts.Digest == "" || verifyDigest(cached, opts.Digest) == nil) {
+			if ch, err := loader.Load(cached); err == nil {
+				return buildResolved(ch, "", opts.ValuesFile)
+			}
+			// Unreadable cache entry: fall through and pull again
+		}
+	}
+
 	settings := cli.New()
 
+	// Look the chart up in the ad-hoc repository's index, then pull it
+	// from the URL listed there
+	pullRef := chartRef
+	if opts.RepoURL != "" {
+		getters := getter.All(settings)
+		if opts.Timeout > 0 {
+			getters = append(getter.Providers{{
+				Schemes: []string{"http", "https"},
+				New: func(o ...getter.Option) (getter.Getter, error) {
+					return getter.NewHTTPGetter(append(o, getter.WithTimeout(opts.Timeout))...)
+				},
+			}}, getters...)
+		}
+		chartURL, err := repo.FindChartInRepoURL(opts.RepoURL, chartRef, version, "", "", "", getters)
+		if err != nil {
+			return nil, fmt.Errorf("pulling chart %s: %w", chartRef, err)
+		}
+		pullRef = chartURL
+	}
+
 	tmpDir, err := os.MkdirTemp("", "helm-values-checker-*")
 	if err != nil {
 		return nil, fmt.Errorf("creating temp dir: %w", err)
 	}
 
-	regClient, err := registry.NewClient(registry.ClientOptDebug(debugEnabled()), registry.ClientOptWriter(io.Discard))
+	regOpts := []registry.ClientOption{registry.ClientOptDebug(debugEnabled()), registry.ClientOptWriter(io.Discard)}
+	getterOpts := []getter.Option{}
+	if opts.Timeout > 0 {
+		regOpts = append(regOpts, registry.ClientOptHTTPClient(&http.Client{Timeout: opts.Timeout}))
+		getterOpts = append(getterOpts, getter.WithTimeout(opts.Timeout))
+	}
+	regClient, err := registry.NewClient(regOpts...)
 	if err != nil {
 		os.RemoveAll(tmpDir)
 		return nil, fmt.Errorf("initializing helm registry client: %w", err)
 	}
 
 	var out strings.Builder
-	opts := []getter.Option{}
-	if registry.IsOCI(chartRef) {
-		opts = append(opts, getter.WithRegistryClient(regClient))
+	if registry.IsOCI(pullRef) {
+		getterOpts = append(getterOpts, getter.WithRegistryClient(regClient))
 	}
 
 	dl := downloader.ChartDownloader{
 		Out:              &out,
 		Verify:           downloader.VerifyNever,
 		Getters:          getter.All(settings),
-		Options:          opts,
+		Options:          getterOpts,
 		RegistryClient:   regClient,
 		RepositoryConfig: settings.RepositoryConfig,
 		RepositoryCache:  settings.RepositoryCache,
 	}
 
-	saved, _, err := dl.DownloadTo(chartRef, version, tmpDir)
+	saved, err := downloadWithTimeout(&dl, pullRef, version, tmpDir, opts.Timeout)
 	if err != nil {
-		os.RemoveAll(tmpDir)
+		// An abandoned pull removes tmpDir itself once it ends
+		if !errors.Is(err, errPullTimeout) {
+			os.RemoveAll(tmpDir)
+		}
+		if errors.Is(err, errPullTimeout) || (opts.Timeout > 0 && isTimeout(err)) {
+			return nil, fmt.Errorf("pulling chart %s: timed out after %s (raise it with --timeout)", chartRef, opts.Timeout)
+		}
 		// Downloader output can contain URLs and other user-specific details (including credentials in rare cases).
 		// Only include a redacted version when explicitly debugging.
 		if debugEnabled() && strings.TrimSpace(out.String()) != "" {
@@ -114,13 +270,186 @@ func resolveRemote(chartRef, version string) (*ResolvedChart, error) {
 		return nil, fmt.Errorf("pulling chart %s: %w", chartRef, err)
 	}
 
+	if opts.Digest != "" {
+		if err := verifyDigest(saved, opts.Digest); err != nil {
+			os.RemoveAll(tmpDir)
+			return nil, fmt.Errorf("pulling chart %s: %w", chartRef, err)
+		}
+	}
+
 	ch, err := loader.Load(saved)
 	if err != nil {
 		os.RemoveAll(tmpDir)
 		return nil, fmt.Errorf("loading pulled chart: %w", err)
 	}
 
-	return buildResolved(ch, tmpDir)
+	if opts.CacheDir != "" {
+		// A failed cache write only costs a re-pull next time
+		_ = storeCachedChart(opts.CacheDir, cacheRef, version, saved)
+	}
+
+	resolved, err := buildResolved(ch, tmpDir, opts.ValuesFile)
+	if err != nil {
+		os.RemoveAll(tmpDir)
+		return nil, err
+	}
+	return resolved, nil
+}
+
+// splitOCITag splits a tag off the last path segment of an OCI chart
+// reference, so oci://registry/chart:1.2.3 pulls version 1.2.3. A port in the
+// registry host and a digest reference (chart@sha256:...) are left alone.
+func splitOCITag(ref string) (string, string) {
+	i := strings.LastIndex(ref, ":")
+	if i <= strings.LastIndex(ref, "/") || strings.Contains(ref[strings.LastIndex(ref, "/"):], "@") {
+		return ref, ""
+	}
+	return ref[:i], ref[i+1:]
+}
+
+// errPullTimeout is returned by downloadWithTimeout when the pull overruns.
+var errPullTimeout = errors.New("chart pull timed out")
+
+// downloadWithTimeout runs dl.DownloadTo into dir, giving up after timeout
+// (if positive) in case a server accepts the connection but stalls.
+// DownloadTo takes no context, so an abandoned pull finishes in the
+// background, bounded by the HTTP client timeout, and removes dir itself.
+func downloadWithTimeout(dl *downloader.ChartDownloader, chartRef, version, dir string, timeout time.Duration) (string, error) {
+	if timeout <= 0 {
+		saved, _, err := dl.DownloadTo(chartRef, version, dir)
+		return saved, err
+	}
+
+	type pull struct {
+		saved string
+		err   error
+	}
+	done := make(chan pull, 1)
+	go func() {
+		saved, _, err := dl.DownloadTo(chartRef, version, dir)
+		done <- pull{saved, err}
+	}()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case p := <-done:
+		return p.saved, p.err
+	case <-timer.C:
+		go func() {
+			<-done
+			os.RemoveAll(dir)
+		}()
+		return "", errPullTimeout
+	}
+}
+
+// isTimeout reports whether err comes from an HTTP client timeout. Helm's
+// getters do not always wrap the underlying error, so the message is
+// checked as well.
+func isTimeout(err error) bool {
+	if err == nil {
+		return false
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+	msg := err.Error()
+	return strings.Contains(msg, "Client.Timeout exceeded") || strings.Contains(msg, "context deadline exceeded")
+}
+
+// parseDigest returns the hex sum of a "sha256:<hex>" digest.
+func parseDigest(digest string) (string, error) {
+	sum, ok := strings.CutPrefix(digest, "sha256:")
+	if _, err := hex.DecodeString(sum); !ok || err != nil || len(sum) != 2*sha256.Size {
+		return "", fmt.Errorf("invalid chart digest %q: expected sha256:<64 hex digits>", digest)
+	}
+	return strings.ToLower(sum), nil
+}
+
+// verifyDigest checks that the SHA-256 digest of the file at path matches
+// the expected "sha256:<hex>" digest.
+func verifyDigest(path, digest string) error {
+	want, err := parseDigest(digest)
+	if err != nil {
+		return err
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("reading chart archive: %w", err)
+	}
+	defer f.Close()
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return fmt.Errorf("reading chart archive: %w", err)
+	}
+	if got := hex.EncodeToString(h.Sum(nil)); got != want {
+		return fmt.Errorf("chart archive digest mismatch: expected sha256:%s, got sha256:%s", want, got)
+	}
+	return nil
+}
+
+// cacheEntryDir returns the cache directory for a chartRef@version pair.
+// The key is hashed so arbitrary refs (URLs, OCI paths) map to safe names.
+func cacheEntryDir(cacheDir, chartRef, version string) string {
+	sum := sha256.Sum256([]byte(chartRef + "@" + version))
+	return filepath.Join(cacheDir, hex.EncodeToString(sum[:16]))
+}
+
+// cachedChart returns the path of a fresh cached archive for chartRef@version,
+// or empty string if there is none.
+func cachedChart(cacheDir, chartRef, version string) string {
+	dir := cacheEntryDir(cacheDir, chartRef, version)
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return ""
+	}
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), ".tgz") {
+			continue
+		}
+		info, err := e.Info()
+		if err != nil {
+			continue
+		}
+		if version == "" && time.Since(info.ModTime()) > cacheMaxAge {
+			continue
+		}
+		return filepath.Join(dir, e.Name())
+	}
+	return ""
+}
+
+// storeCachedChart copies a pulled archive into the cache entry for
+// chartRef@version, writing to a temp file first so readers never see
+// a partial archive.
+func storeCachedChart(cacheDir, chartRef, version, saved string) error {
+	dir := cacheEntryDir(cacheDir, chartRef, version)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+
+	data, err := os.ReadFile(saved)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(dir, ".tmp-*")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+
+	return os.Rename(tmp.Name(), filepath.Join(dir, filepath.Base(saved)))
 }
 
 func debugEnabled() bool {
@@ -138,19 +467,24 @@ func redactSensitive(s string) string {
 	return redacted
 }
 
-func buildResolved(ch *chart.Chart, tempDir string) (*ResolvedChart, error) {
+// buildResolved parses the chart's defaults and schemas. The defaults come
+// from valuesFile when set, and from values.yaml (or values.yml) otherwise.
+func buildResolved(ch *chart.Chart, tempDir, valuesFile string) (*ResolvedChart, error) {
 	resolved := &ResolvedChart{
 		Chart:            ch,
 		SubchartDefaults: make(map[string]*yaml.Node),
+		SubchartSchemas:  make(map[string][]byte),
 		tempDir:          tempDir,
 	}
 
 	// Parse main values.yaml into yaml.Node tree
+	found := false
 	for _, f := range ch.Raw {
-		if f.Name == "values.yaml" || f.Name == "values.yml" {
+		if (valuesFile == "" && (f.Name == "values.yaml" || f.Name == "values.yml")) || (valuesFile != "" && f.Name == valuesFile) {
+			found = true
 			node := &yaml.Node{}
 			if err := yaml.Unmarshal(f.Data, node); err != nil {
-				return nil, fmt.Errorf("parsing values.yaml: %w", err)
+				return nil, fmt.Errorf("parsing %s: %w", f.Name, err)
 			}
 			// yaml.Unmarshal wraps in a Document node
 			if node.Kind == yaml.DocumentNode && len(node.Content) > 0 {
@@ -162,6 +496,10 @@ func buildResolved(ch *chart.Chart, tempDir string) (*ResolvedChart, error) {
 		}
 	}
 
+	if valuesFile != "" && !found {
+		return nil, fmt.Errorf("chart %s has no values file %q%s", ch.Metadata.Name, valuesFile, availableValuesFiles(ch))
+	}
+
 	if resolved.DefaultsNode == nil {
 		// Create empty mapping if no values.yaml
 		resolved.DefaultsNode = &yaml.Node{Kind: yaml.MappingNode}
@@ -172,8 +510,11 @@ func buildResolved(ch *chart.Chart, tempDir string) (*ResolvedChart, error) {
 		resolved.SchemaBytes = ch.Schema
 	}
 
-	// Parse subchart defaults
+	// Parse subchart defaults and collect subchart schemas
 	for _, dep := range ch.Dependencies() {
+		if dep.Schema != nil {
+			resolved.SubchartSchemas[dep.Name()] = dep.Schema
+		}
 		for _, f := range dep.Raw {
 			if f.Name == "values.yaml" || f.Name == "values.yml" {
 				node := &yaml.Node{}
@@ -192,3 +533,19 @@ func buildResolved(ch *chart.Chart, tempDir string) (*ResolvedChart, error) {
 
 	return resolved, nil
 }
+
+// availableValuesFiles lists the chart's top-level YAML files for an error
+// message, or returns "" when there are none.
+func availableValuesFiles(ch *chart.Chart) string {
+	var names []string
+	for _, f := range ch.Raw {
+		if !strings.Contains(f.Name, "/") && (strings.HasSuffix(f.Name, ".yaml") || strings.HasSuffix(f.Name, ".yml")) && f.Name != "Chart.yaml" {
+			names = append(names, f.Name)
+		}
+	}
+	if len(names) == 0 {
+		return ""
+	}
+	sort.Strings(names)
+	return " (available: " + strings.Join(names, ", ") + ")"
+}
@@ -0,0 +1,314 @@
+package chart
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"helm.sh/helm/v3/pkg/chart/loader"
+	"helm.sh/helm/v3/pkg/chartutil"
+)
+
+func testdataDir() string {
+	_, filename, _, _ := runtime.Caller(0)
+	return filepath.Join(filepath.Dir(filename), "..", "..", "testdata")
+}
+
+// packageTestChart packages a testdata chart into a .tgz and returns its path.
+func packageTestChart(t *testing.T, name string) string {
+	t.Helper()
+	ch, err := loader.Load(filepath.Join(testdataDir(), name))
+	if err != nil {
+		t.Fatalf("loading test chart: %v", err)
+	}
+	archive, err := chartutil.Save(ch, t.TempDir())
+	if err != nil {
+		t.Fatalf("packaging test chart: %v", err)
+	}
+	return archive
+}
+
+// isolateHelm points Helm's config and cache at empty temp dirs so tests
+// don't depend on the developer's repositories.
+func isolateHelm(t *testing.T) {
+	t.Helper()
+	home := t.TempDir()
+	t.Setenv("HELM_REPOSITORY_CONFIG", filepath.Join(home, "repositories.yaml"))
+	t.Setenv("HELM_REPOSITORY_CACHE", filepath.Join(home, "repository"))
+	t.Setenv("HELM_REGISTRY_CONFIG", filepath.Join(home, "registry.json"))
+}
+
+func TestResolve_CacheReusesPulledChart(t *testing.T) {
+	isolateHelm(t)
+	archive := packageTestChart(t, "test-chart")
+
+	var hits int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		http.ServeFile(w, r, archive)
+	}))
+	defer srv.Close()
+
+	ref := srv.URL + "/" + filepath.Base(archive)
+	opts := ResolveOptions{CacheDir: t.TempDir()}
+
+	first, err := ResolveWithOptions(ref, "1.0.0", opts)
+	if err != nil {
+		t.Fatalf("first resolve: %v", err)
+	}
+	first.Cleanup()
+	if got := atomic.LoadInt32(&hits); got != 1 {
+		t.Fatalf("expected 1 download after first resolve, got %d", got)
+	}
+
+	second, err := ResolveWithOptions(ref, "1.0.0", opts)
+	if err != nil {
+		t.Fatalf("second resolve: %v", err)
+	}
+	defer second.Cleanup()
+	if got := atomic.LoadInt32(&hits); got != 1 {
+		t.Errorf("expected second resolve to hit the cache, got %d downloads", got)
+	}
+	if second.Chart.Metadata.Name != "test-chart" {
+		t.Errorf("expected cached chart 'test-chart', got %q", second.Chart.Metadata.Name)
+	}
+
+	if cachedChart(opts.CacheDir, ref, "1.0.0") == "" {
+		t.Error("expected cache entry to survive Cleanup")
+	}
+}
+
+func TestResolve_CacheMissForDifferentVersion(t *testing.T) {
+	dir := t.TempDir()
+	archive := packageTestChart(t, "test-chart")
+	if err := storeCachedChart(dir, "repo/test-chart", "1.0.0", archive); err != nil {
+		t.Fatalf("storing cache entry: %v", err)
+	}
+
+	if cachedChart(dir, "repo/test-chart", "1.0.0") == "" {
+		t.Error("expected cache hit for stored version")
+	}
+	if cachedChart(dir, "repo/test-chart", "2.0.0") != "" {
+		t.Error("expected cache miss for a different version")
+	}
+	if _, err := os.Stat(cacheEntryDir(dir, "repo/test-chart", "2.0.0")); !os.IsNotExist(err) {
+		t.Error("expected no entry directory for an uncached version")
+	}
+}
+
+func TestResolve_LocalArchive(t *testing.T) {
+	isolateHelm(t)
+	// A bare relative file name has no ./ prefix, so it must be recognized
+	// as a local archive rather than a repo reference.
+	t.Chdir(testdataDir())
+
+	resolved, err := Resolve("test-chart-1.0.0.tgz", "")
+	if err != nil {
+		t.Fatalf("resolving chart archive: %v", err)
+	}
+	defer resolved.Cleanup()
+
+	if resolved.Chart.Metadata.Name != "test-chart" || resolved.Chart.Metadata.Version != "1.0.0" {
+		t.Errorf("unexpected chart %s-%s", resolved.Chart.Metadata.Name, resolved.Chart.Metadata.Version)
+	}
+	if resolved.DefaultsNode == nil {
+		t.Error("expected defaults from the archived values.yaml")
+	}
+}
+
+func TestResolve_AlternateValuesFile(t *testing.T) {
+	chartPath := filepath.Join(testdataDir(), "test-chart")
+	resolved, err := ResolveWithOptions(chartPath, "", ResolveOptions{ValuesFile: "values-production.yaml"})
+	if err != nil {
+		t.Fatalf("resolving chart: %v", err)
+	}
+	defer resolved.Cleanup()
+
+	keys := TopLevelKeys(resolved.DefaultsNode)
+	if len(keys) != 3 || keys[0] != "replicaCount" || keys[2] != "podDisruptionBudget" {
+		t.Errorf("expected defaults from values-production.yaml, got keys %v", keys)
+	}
+
+	_, err = ResolveWithOptions(chartPath, "", ResolveOptions{ValuesFile: "values-staging.yaml"})
+	if err == nil || !strings.Contains(err.Error(), `no values file "values-staging.yaml"`) || !strings.Contains(err.Error(), "values-production.yaml") {
+		t.Errorf("expected a missing values file error listing the available files, got %v", err)
+	}
+}
+
+func TestIsLocalPath(t *testing.T) {
+	t.Chdir(testdataDir())
+
+	tests := []struct {
+		ref   string
+		local bool
+	}{
+		{"test-chart", true},
+		{"test-chart-1.0.0.tgz", true},
+		{"./missing-1.0.0.tgz", true},
+		{"missing-1.0.0.tgz", false},
+		{"good-values.yaml", false},
+		{"bitnami/postgresql", false},
+	}
+	for _, tt := range tests {
+		if got := isLocalPath(tt.ref); got != tt.local {
+			t.Errorf("isLocalPath(%q) = %v, want %v", tt.ref, got, tt.local)
+		}
+	}
+}
+
+func TestResolve_TimeoutOnStalledServer(t *testing.T) {
+	isolateHelm(t)
+
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer srv.Close()
+	defer close(release)
+
+	start := time.Now()
+	_, err := ResolveWithOptions(srv.URL+"/test-chart-1.0.0.tgz", "1.0.0", ResolveOptions{Timeout: 200 * time.Millisecond})
+	if err == nil || !strings.Contains(err.Error(), "timed out after 200ms") {
+		t.Fatalf("expected a timeout error, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("expected the pull to give up promptly, took %s", elapsed)
+	}
+}
+
+func TestSplitOCITag(t *testing.T) {
+	tests := []struct {
+		ref, base, tag string
+	}{
+		{"oci://registry.example.com/charts/app:1.2.3", "oci://registry.example.com/charts/app", "1.2.3"},
+		{"oci://registry.example.com/charts/app", "oci://registry.example.com/charts/app", ""},
+		{"oci://localhost:5000/app", "oci://localhost:5000/app", ""},
+		{"oci://localhost:5000/app:0.1.0", "oci://localhost:5000/app", "0.1.0"},
+		{"oci://registry.example.com/app@sha256:abc123", "oci://registry.example.com/app@sha256:abc123", ""},
+	}
+	for _, tt := range tests {
+		base, tag := splitOCITag(tt.ref)
+		if base != tt.base || tag != tt.tag {
+			t.Errorf("splitOCITag(%q) = %q, %q, want %q, %q", tt.ref, base, tag, tt.base, tt.tag)
+		}
+	}
+}
+
+func TestResolve_OCITagConflictsWithVersion(t *testing.T) {
+	_, err := Resolve("oci://registry.example.com/charts/app:1.2.3", "2.0.0")
+	if err == nil || !strings.Contains(err.Error(), "--version 2.0.0") {
+		t.Errorf("expected an error for a tag and a different --version, got %v", err)
+	}
+}
+
+// fileDigest returns the sha256:<hex> digest of the file at path.
+func fileDigest(t *testing.T, path string) string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sum := sha256.Sum256(data)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+func TestVerifyDigest(t *testing.T) {
+	archive := packageTestChart(t, "test-chart")
+	digest := fileDigest(t, archive)
+
+	if err := verifyDigest(archive, digest); err != nil {
+		t.Errorf("expected the archive's own digest to pass, got %v", err)
+	}
+	if err := verifyDigest(archive, "sha256:"+strings.ToUpper(strings.TrimPrefix(digest, "sha256:"))); err != nil {
+		t.Errorf("expected upper-case hex digits to pass, got %v", err)
+	}
+	if err := verifyDigest(archive, "sha256:"+strings.Repeat("0", 64)); err == nil || !strings.Contains(err.Error(), "digest mismatch") {
+		t.Errorf("expected a mismatch error, got %v", err)
+	}
+	if err := verifyDigest(archive, "md5:abc"); err == nil || !strings.Contains(err.Error(), "invalid chart digest") {
+		t.Errorf("expected an invalid digest error, got %v", err)
+	}
+}
+
+func TestResolve_ChartDigest(t *testing.T) {
+	isolateHelm(t)
+	archive := packageTestChart(t, "test-chart")
+	digest := fileDigest(t, archive)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.ServeFile(w, r, archive)
+	}))
+	defer srv.Close()
+	ref := srv.URL + "/" + filepath.Base(archive)
+
+	resolved, err := ResolveWithOptions(ref, "", ResolveOptions{Digest: digest})
+	if err != nil {
+		t.Fatalf("expected the matching digest to pass, got %v", err)
+	}
+	resolved.Cleanup()
+
+	_, err = ResolveWithOptions(ref, "", ResolveOptions{Digest: "sha256:" + strings.Repeat("ab", 32)})
+	if err == nil || !strings.Contains(err.Error(), "digest mismatch") {
+		t.Errorf("expected a digest mismatch for the pulled chart, got %v", err)
+	}
+
+	local, err := ResolveWithOptions(archive, "", ResolveOptions{Digest: digest})
+	if err != nil {
+		t.Fatalf("expected the local archive to match, got %v", err)
+	}
+	local.Cleanup()
+	if _, err := ResolveWithOptions(filepath.Join(testdataDir(), "test-chart"), "", ResolveOptions{Digest: digest}); err == nil {
+		t.Error("expected an error for a digest with a chart directory")
+	}
+}
+
+func TestResolve_AdHocRepo(t *testing.T) {
+	isolateHelm(t)
+	t.Setenv("HELM_CACHE_HOME", t.TempDir())
+	archive := packageTestChart(t, "test-chart")
+
+	index := "apiVersion: v1\nentries:\n  test-chart:\n    - name: test-chart\n      version: 1.0.0\n      apiVersion: v2\n      urls:\n        - charts/" + filepath.Base(archive) + "\n"
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/index.yaml":
+			w.Write([]byte(index))
+		case "/charts/" + filepath.Base(archive):
+			http.ServeFile(w, r, archive)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	resolved, err := ResolveWithOptions("test-chart", "1.0.0", ResolveOptions{RepoURL: srv.URL, Timeout: 10 * time.Second})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer resolved.Cleanup()
+	if resolved.Chart.Metadata.Name != "test-chart" || resolved.DefaultsNode == nil {
+		t.Errorf("expected test-chart with its defaults, got %+v", resolved.Chart.Metadata)
+	}
+
+	if _, err := ResolveWithOptions("missing", "", ResolveOptions{RepoURL: srv.URL}); err == nil || !strings.Contains(err.Error(), `chart "missing" not found`) {
+		t.Errorf("expected a not-found error for an unknown chart, got %v", err)
+	}
+	for _, tc := range []struct{ ref, repo string }{
+		{"stable/test-chart", srv.URL},
+		{"test-chart", "oci://registry.example.com/charts"},
+	} {
+		if _, err := ResolveWithOptions(tc.ref, "", ResolveOptions{RepoURL: tc.repo}); err == nil || !strings.Contains(err.Error(), "--repo") {
+			t.Errorf("expected %s from %s to be rejected, got %v", tc.ref, tc.repo, err)
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package chart
+
+import "strings"
+
+// SubchartToggle describes how Helm decides whether a dependency declared in
+// Chart.yaml is enabled: the first of its condition paths that holds a bool
+// in the values wins, and otherwise it is enabled unless all of its tags set
+// in the values are false.
+type SubchartToggle struct {
+	Key        string   // values key of the subchart: its alias, or its name
+	Conditions []string // dot-separated values paths, in order
+	Tags       []string // names looked up under the top-level tags key
+	Bundled    bool     // the chart includes the subchart under charts/, or was installed with it
+}
+
+// SubchartToggles returns the dependencies that have a condition or tags, in
+// Chart.yaml order.
+func (r *ResolvedChart) SubchartToggles() []SubchartToggle {
+	var toggles []SubchartToggle
+	if r.Chart == nil || r.Chart.Metadata == nil {
+		return toggles
+	}
+
+	bundled := make(map[string]bool)
+	for _, dep := range r.Chart.Dependencies() {
+		bundled[dep.Name()] = true
+	}
+	for _, dep := range r.Chart.Metadata.Dependencies {
+		if dep == nil || (dep.Condition == "" && len(dep.Tags) == 0) {
+			continue
+		}
+		t := SubchartToggle{Key: dep.Name, Tags: dep.Tags, Bundled: bundled[dep.Name] || r.FromRelease}
+		if dep.Alias != "" {
+			t.Key = dep.Alias
+		}
+		for _, c := range strings.Split(dep.Condition, ",") {
+			if c = strings.TrimSpace(c); c != "" {
+				t.Conditions = append(t.Conditions, c)
+			}
+		}
+		toggles = append(toggles, t)
+	}
+	return toggles
+}
+
+// UnvendoredDependencies returns the values keys (alias, or name) of the
+// dependencies Chart.yaml declares but the chart does not bundle under
+// charts/, as when helm dependency build has not been run, in Chart.yaml
+// order. Values for them cannot be checked against subchart defaults. For
+// a release's chart, which Helm stores without its subcharts, these are
+// all of its dependencies.
+func (r *ResolvedChart) UnvendoredDependencies() []string {
+	var keys []string
+	if r.Chart == nil || r.Chart.Metadata == nil {
+		return keys
+	}
+
+	bundled := make(map[string]bool)
+	for _, dep := range r.Chart.Dependencies() {
+		bundled[dep.Name()] = true
+	}
+	for _, dep := range r.Chart.Metadata.Dependencies {
+		if dep == nil || bundled[dep.Name] {
+			continue
+		}
+		key := dep.Name
+		if dep.Alias != "" {
+			key = dep.Alias
+		}
+		keys = append(keys, key)
+	}
+	return keys
+}
@@ -0,0 +1,49 @@
+package chart
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestSubchartToggles(t *testing.T) {
+	resolved, err := Resolve(filepath.Join(testdataDir(), "test-chart-with-conditions"), "")
+	if err != nil {
+		t.Fatalf("Resolve error: %v", err)
+	}
+	defer resolved.Cleanup()
+
+	toggles := resolved.SubchartToggles()
+	if len(toggles) != 3 {
+		t.Fatalf("expected 3 toggles, got %+v", toggles)
+	}
+	if redis := toggles[0]; redis.Key != "redis" || len(redis.Conditions) != 1 || redis.Conditions[0] != "redis.enabled" || !redis.Bundled {
+		t.Errorf("expected a bundled redis with condition redis.enabled, got %+v", redis)
+	}
+	if exporter := toggles[1]; exporter.Key != "exporter" || len(exporter.Tags) != 1 || exporter.Tags[0] != "monitoring" {
+		t.Errorf("expected exporter toggled by the monitoring tag, got %+v", exporter)
+	}
+	if metrics := toggles[2]; len(metrics.Conditions) != 2 || metrics.Conditions[1] != "global.metrics.enabled" || metrics.Bundled {
+		t.Errorf("expected an unbundled metrics with two conditions, got %+v", metrics)
+	}
+}
+
+func TestUnvendoredDependencies(t *testing.T) {
+	resolved, err := Resolve(filepath.Join(testdataDir(), "test-chart-with-unvendored-dependency"), "")
+	if err != nil {
+		t.Fatalf("Resolve error: %v", err)
+	}
+	defer resolved.Cleanup()
+
+	if keys := resolved.UnvendoredDependencies(); len(keys) != 1 || keys[0] != "db" {
+		t.Errorf("expected the postgresql dependency under its alias db, got %v", keys)
+	}
+
+	bundled, err := Resolve(filepath.Join(testdataDir(), "test-chart-with-conditions"), "")
+	if err != nil {
+		t.Fatalf("Resolve error: %v", err)
+	}
+	defer bundled.Cleanup()
+	if keys := bundled.UnvendoredDependencies(); len(keys) != 1 || keys[0] != "metrics" {
+		t.Errorf("expected only metrics to be unvendored, got %v", keys)
+	}
+}
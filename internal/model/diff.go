@@ -0,0 +1,65 @@
+package model
+
+// DiffKind describes how a leaf path changed between two values files.
+type DiffKind int
+
+const (
+	DiffAdded DiffKind = iota
+	DiffRemoved
+	DiffChanged
+)
+
+func (k DiffKind) String() string {
+	switch k {
+	case DiffAdded:
+		return "added"
+	case DiffRemoved:
+		return "removed"
+	case DiffChanged:
+		return "changed"
+	default:
+		return "unknown"
+	}
+}
+
+// DiffEntry is a single leaf path that differs between two values files.
+type DiffEntry struct {
+	Kind     DiffKind
+	Line     int // line in the new file, or in the old file for removed keys
+	KeyPath  string
+	OldValue string
+	NewValue string
+	OldType  string
+	NewType  string
+	Unknown  bool // added key that the chart does not know about
+}
+
+// DiffResult holds the differences between two values files for a chart.
+type DiffResult struct {
+	OldFile      string
+	NewFile      string
+	ChartName    string
+	ChartVersion string
+	Entries      []DiffEntry
+}
+
+// Count returns the number of entries of the given kind.
+func (r *DiffResult) Count(kind DiffKind) int {
+	n := 0
+	for _, e := range r.Entries {
+		if e.Kind == kind {
+			n++
+		}
+	}
+	return n
+}
+
+// HasUnknown returns true if any added key is unknown to the chart.
+func (r *DiffResult) HasUnknown() bool {
+	for _, e := range r.Entries {
+		if e.Unknown {
+			return true
+		}
+	}
+	return false
+}
@@ -1,13 +1,18 @@
 package model
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+	"time"
+)
 
 // Severity represents the severity of a validation finding.
 type Severity int
 
 const (
-	SeverityError   Severity = iota
+	SeverityError Severity = iota
 	SeverityWarning
+	SeverityInfo
 )
 
 func (s Severity) String() string {
@@ -16,34 +21,134 @@ func (s Severity) String() string {
 		return "ERROR"
 	case SeverityWarning:
 		return "WARNING"
+	case SeverityInfo:
+		return "INFO"
 	default:
 		return "UNKNOWN"
 	}
 }
 
+// Rule IDs are stable identifiers for the check that produced a finding,
+// intended for downstream filtering.
+const (
+	RuleUnknownKey            = "unknown-key"
+	RuleTypeMismatch          = "type-mismatch"
+	RuleSchemaRequired        = "schema-required"
+	RuleSchemaDeprecated      = "schema-deprecated"
+	RuleSchemaEnum            = "schema-enum"
+	RuleSchemaConst           = "schema-const"
+	RuleSchemaRange           = "schema-range"
+	RuleSchemaLength          = "schema-length"
+	RuleSchemaPattern         = "schema-pattern"
+	RuleSchemaFormat          = "schema-format"
+	RuleSchemaMultipleOf      = "schema-multiple-of"
+	RuleSchemaOneOf           = "schema-one-of"
+	RuleSchemaAnyOf           = "schema-any-of"
+	RuleSchemaNot             = "schema-not"
+	RuleSchemaCondition       = "schema-condition"
+	RuleSchemaItems           = "schema-items"
+	RuleSchemaUnique          = "schema-unique"
+	RuleSchemaProperties      = "schema-properties"
+	RuleSchemaDependency      = "schema-dependency"
+	RuleSchemaConstraint      = "schema-constraint"
+	RuleSchemaType            = "schema-type"
+	RuleSchemaExternalRef     = "schema-external-ref"
+	RuleRequiredDefault       = "required-default"
+	RuleYAMLAlias             = "yaml-alias"
+	RuleUnexpandedPlaceholder = "unexpanded-placeholder"
+	RuleInvalidQuantity       = "invalid-quantity"
+	RuleIntOverflow           = "int-overflow"
+	RuleRedundantValue        = "redundant-value"
+	RuleSchemaDraft           = "schema-draft"
+	RuleDisallowedKey         = "disallowed-key"
+	RuleDisabledSubchart      = "disabled-subchart"
+	RuleMissingSubchart       = "missing-subchart"
+	RuleReleaseSubchart       = "release-subchart"
+)
+
 // Finding represents a single validation issue found in user values.
 type Finding struct {
-	Severity   Severity
-	Line       int
-	KeyPath    string
-	Message    string
-	Suggestion string // "did you mean?" suggestion, if any
+	Severity       Severity
+	Rule           string // stable rule ID, e.g. RuleUnknownKey
+	Line           int
+	Column         int // 1-based column of the offending key or value, 0 when unknown
+	KeyPath        string
+	Message        string
+	Suggestion     string   // "did you mean?" suggestion, if any
+	Suggestions    []string // ranked candidates, Suggestion first
+	Confidence     float64  // 0-1 confidence in Suggestion, 0 when there is none
+	SourceLine     string   // trimmed text of the offending line, empty when Line is 0
+	SuggestionLine int      // line defining a relocated Suggestion in the chart defaults, 0 otherwise
+	DocURL         string   // documentation link for the key from the schema (x-docs or a $comment URL), if any
+	File           string   // values file that set the offending key when several were merged, empty otherwise
+	KnownTo        []string // other charts that define an unknown key, when validated against several
+	LimitedSearch  bool     // the misspelling search for Suggestion was skipped on a large chart
+}
+
+// Position formats the finding's location as "line" or "line:column".
+func (f Finding) Position() string {
+	if f.Column > 0 {
+		return fmt.Sprintf("%d:%d", f.Line, f.Column)
+	}
+	return fmt.Sprintf("%d", f.Line)
+}
+
+// DisplayMessage returns the message as reports show it: with a note when
+// the suggestion search was limited, and the charts that define an
+// unknown key appended, as in ` (known to chart "web 1.2.0")`. Message
+// itself stays free of them, so that it identifies the finding whichever
+// charts were validated and however large they are.
+func (f Finding) DisplayMessage() string {
+	msg := f.Message
+	if f.LimitedSearch {
+		msg += " (suggestions limited on large charts: only keys of the same name were searched)"
+	}
+	if len(f.KnownTo) == 0 {
+		return msg
+	}
+	quoted := make([]string, len(f.KnownTo))
+	for i, c := range f.KnownTo {
+		quoted[i] = fmt.Sprintf("%q", c)
+	}
+	return fmt.Sprintf("%s (known to chart %s)", msg, strings.Join(quoted, ", "))
 }
 
 func (f Finding) String() string {
-	s := fmt.Sprintf("line %d: %s", f.Line, f.Message)
+	s := fmt.Sprintf("line %s: %s", f.Position(), f.DisplayMessage())
 	if f.Suggestion != "" {
-		s += fmt.Sprintf(" (did you mean %q?)", f.Suggestion)
+		s += fmt.Sprintf(" (did you mean %q", f.Suggestion)
+		if f.SuggestionLine > 0 {
+			s += fmt.Sprintf(" (defined at line %d)", f.SuggestionLine)
+		}
+		s += "?)"
 	}
 	return s
 }
 
 // ValidationResult holds the complete result of a validation run.
 type ValidationResult struct {
-	ValuesFile string
-	ChartName  string
+	ValuesFile   string
+	ChartName    string
 	ChartVersion string
-	Findings   []Finding
+	AppVersion   string // the chart's appVersion, empty if it declares none
+	Findings     []Finding
+	CheckedKeys  int           // leaf keys in the values file that were checked (not ignored)
+	KnownKeys    int           // checked keys not reported as unknown
+	Coverage     *Coverage     // set when coverage was requested
+	Timings      []PhaseTiming // set when profiling was requested, in run order
+}
+
+// PhaseTiming is the wall time one validation phase took.
+type PhaseTiming struct {
+	Phase    string
+	Duration time.Duration
+}
+
+// Coverage reports how many of the chart's defaulted keys a values file sets.
+type Coverage struct {
+	DefaultedKeys int      // leaf keys with a default in values.yaml or the schema
+	SetKeys       int      // defaulted keys the values file sets
+	Unset         []string // paths of defaulted keys left unset, in chart order
 }
 
 // Errors returns all findings with error severity.
@@ -68,6 +173,17 @@ func (r *ValidationResult) Warnings() []Finding {
 	return out
 }
 
+// Infos returns all findings with info severity.
+func (r *ValidationResult) Infos() []Finding {
+	var out []Finding
+	for _, f := range r.Findings {
+		if f.Severity == SeverityInfo {
+			out = append(out, f)
+		}
+	}
+	return out
+}
+
 // HasErrors returns true if any error-level findings exist.
 func (r *ValidationResult) HasErrors() bool {
 	for _, f := range r.Findings {
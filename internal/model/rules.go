@@ -0,0 +1,47 @@
+package model
+
+// RuleInfo describes a check: its rule ID, the severity of its findings
+// (before any --escalate rules), and a one-line summary of what it reports.
+type RuleInfo struct {
+	ID          string
+	Severity    Severity
+	Description string
+}
+
+// Rules lists every rule the validator can report, in the order of the
+// checks table in the README.
+var Rules = []RuleInfo{
+	{RuleUnknownKey, SeverityError, "Key not defined in the chart's values.yaml or schema, with a \"did you mean?\" suggestion"},
+	{RuleTypeMismatch, SeverityError, "Value whose type or shape differs from the chart default or schema type"},
+	{RuleInvalidQuantity, SeverityError, "String at a Kubernetes quantity field that does not parse as a quantity"},
+	{RuleIntOverflow, SeverityError, "Integer outside the int32 or int64 format declared in the schema"},
+	{RuleSchemaRequired, SeverityError, "Key required by values.schema.json that is not set"},
+	{RuleSchemaDeprecated, SeverityWarning, "Key the schema marks as deprecated"},
+	{RuleSchemaEnum, SeverityError, "Value not among those the schema's enum allows"},
+	{RuleSchemaConst, SeverityError, "Value other than the one the schema's const allows"},
+	{RuleSchemaRange, SeverityError, "Number outside the schema's minimum/maximum"},
+	{RuleSchemaLength, SeverityError, "String shorter or longer than the schema's minLength/maxLength"},
+	{RuleSchemaPattern, SeverityError, "String that does not match the schema's pattern"},
+	{RuleSchemaFormat, SeverityError, "String not in the schema's format (e.g., email or uri)"},
+	{RuleSchemaMultipleOf, SeverityError, "Number that is not a multiple of the schema's multipleOf"},
+	{RuleSchemaOneOf, SeverityError, "Value matching none, or more than one, of the schema's oneOf branches"},
+	{RuleSchemaAnyOf, SeverityError, "Value matching none of the schema's anyOf branches"},
+	{RuleSchemaNot, SeverityError, "Value matching a schema's not, or set where the schema is false"},
+	{RuleSchemaCondition, SeverityError, "Value failing the then or else branch of a schema's if, or an allOf branch"},
+	{RuleSchemaItems, SeverityError, "List with fewer or more items than minItems/maxItems allow, or none matching contains"},
+	{RuleSchemaUnique, SeverityError, "List with duplicate items where the schema sets uniqueItems"},
+	{RuleSchemaProperties, SeverityError, "Map with too few or too many keys, or keys the schema's properties do not allow"},
+	{RuleSchemaDependency, SeverityError, "Key set without another key its schema dependencies require"},
+	{RuleSchemaConstraint, SeverityError, "Value the schema rejects for a reason without a dedicated rule"},
+	{RuleSchemaType, SeverityError, "Value of a type the schema does not allow"},
+	{RuleSchemaExternalRef, SeverityError, "Schema $ref to a document outside the chart, which is not checked"},
+	{RuleSchemaDraft, SeverityWarning, "Schema declaring a JSON Schema draft newer than draft-07"},
+	{RuleUnexpandedPlaceholder, SeverityWarning, "Placeholder like ${REPLICAS} in a field that expects a non-string type"},
+	{RuleRedundantValue, SeverityWarning, "Scalar set to exactly the chart default (with --warn-redundant)"},
+	{RuleDisallowedKey, SeverityError, "Key matching none of the --allow-keys patterns"},
+	{RuleDisabledSubchart, SeverityWarning, "Values for a subchart that its condition or tags turn off"},
+	{RuleMissingSubchart, SeverityWarning, "Values for a subchart that Chart.yaml declares but the chart does not bundle"},
+	{RuleYAMLAlias, SeverityError, "Alias that references no anchor or forms a cycle"},
+	{RuleReleaseSubchart, SeverityInfo, "Values for a subchart of a --chart-from-release chart, which Helm stores without its subcharts (with --show-info)"},
+	{RuleRequiredDefault, SeverityInfo, "Required key left to the chart default (with --show-info)"},
+}
@@ -0,0 +1,105 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/chrishham/helm-values-checker/internal/model"
+	"github.com/fatih/color"
+)
+
+// DiffOutput is the structured output format for a values diff.
+type DiffOutput struct {
+	OldFile      string      `json:"oldFile"`
+	NewFile      string      `json:"newFile"`
+	ChartName    string      `json:"chartName"`
+	ChartVersion string      `json:"chartVersion"`
+	Changes      []DiffEntry `json:"changes"`
+	AddedCount   int         `json:"addedCount"`
+	RemovedCount int         `json:"removedCount"`
+	ChangedCount int         `json:"changedCount"`
+}
+
+// DiffEntry is a single changed path in JSON format.
+type DiffEntry struct {
+	Kind     string `json:"kind"`
+	Line     int    `json:"line"`
+	KeyPath  string `json:"keyPath"`
+	OldValue string `json:"oldValue,omitempty"`
+	NewValue string `json:"newValue,omitempty"`
+	OldType  string `json:"oldType,omitempty"`
+	NewType  string `json:"newType,omitempty"`
+	Unknown  bool   `json:"unknown,omitempty"`
+}
+
+// DiffToJSON encodes a DiffResult as indented JSON.
+func DiffToJSON(result *model.DiffResult) ([]byte, error) {
+	out := DiffOutput{
+		OldFile:      result.OldFile,
+		NewFile:      result.NewFile,
+		ChartName:    result.ChartName,
+		ChartVersion: result.ChartVersion,
+		Changes:      make([]DiffEntry, 0, len(result.Entries)),
+		AddedCount:   result.Count(model.DiffAdded),
+		RemovedCount: result.Count(model.DiffRemoved),
+		ChangedCount: result.Count(model.DiffChanged),
+	}
+	for _, e := range result.Entries {
+		out.Changes = append(out.Changes, DiffEntry{
+			Kind:     e.Kind.String(),
+			Line:     e.Line,
+			KeyPath:  e.KeyPath,
+			OldValue: e.OldValue,
+			NewValue: e.NewValue,
+			OldType:  e.OldType,
+			NewType:  e.NewType,
+			Unknown:  e.Unknown,
+		})
+	}
+	return json.MarshalIndent(out, "", "  ")
+}
+
+// PrintDiffText writes a human-readable values diff to w.
+func PrintDiffText(result *model.DiffResult, w io.Writer) {
+	header := color.New(color.Bold)
+	header.Fprintf(w, "Comparing %s -> %s against %s", sanitize(result.OldFile), sanitize(result.NewFile), sanitize(result.ChartName))
+	if result.ChartVersion != "" {
+		header.Fprintf(w, " (%s)", sanitize(result.ChartVersion))
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintln(w)
+
+	if len(result.Entries) == 0 {
+		color.New(color.FgGreen, color.Bold).Fprintln(w, "No differences found.")
+		return
+	}
+
+	for _, e := range result.Entries {
+		fmt.Fprintf(w, "  ")
+		switch e.Kind {
+		case model.DiffAdded:
+			color.New(color.FgGreen).Fprintf(w, "+ line %d", e.Line)
+			fmt.Fprintf(w, ": %s = %s", sanitize(e.KeyPath), sanitize(e.NewValue))
+			if e.Unknown {
+				color.New(color.FgRed).Fprintf(w, " (unknown to chart)")
+			}
+		case model.DiffRemoved:
+			color.New(color.FgRed).Fprintf(w, "- line %d", e.Line)
+			fmt.Fprintf(w, ": %s = %s", sanitize(e.KeyPath), sanitize(e.OldValue))
+		case model.DiffChanged:
+			color.New(color.FgYellow).Fprintf(w, "~ line %d", e.Line)
+			if e.OldType != e.NewType {
+				fmt.Fprintf(w, ": %s: %s (%s) -> %s (%s)", sanitize(e.KeyPath),
+					sanitize(e.OldValue), sanitize(e.OldType), sanitize(e.NewValue), sanitize(e.NewType))
+			} else {
+				fmt.Fprintf(w, ": %s: %s -> %s", sanitize(e.KeyPath), sanitize(e.OldValue), sanitize(e.NewValue))
+			}
+		}
+		fmt.Fprintln(w)
+	}
+	fmt.Fprintln(w)
+
+	color.New(color.Bold).Fprintf(w, "Summary: %d added, %d removed, %d changed\n",
+		result.Count(model.DiffAdded), result.Count(model.DiffRemoved), result.Count(model.DiffChanged))
+}
@@ -0,0 +1,66 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/chrishham/helm-values-checker/internal/model"
+)
+
+func testDiffResult() *model.DiffResult {
+	return &model.DiffResult{
+		OldFile:   "old.yaml",
+		NewFile:   "new.yaml",
+		ChartName: "test-chart",
+		Entries: []model.DiffEntry{
+			{Kind: model.DiffAdded, Line: 4, KeyPath: "image.regsitry", NewValue: "docker.io", NewType: "string", Unknown: true},
+			{Kind: model.DiffRemoved, Line: 3, KeyPath: "image.pullPolicy", OldValue: "Always", OldType: "string"},
+			{Kind: model.DiffChanged, Line: 1, KeyPath: "replicaCount", OldValue: "1", NewValue: "2", OldType: "int", NewType: "string"},
+		},
+	}
+}
+
+func TestPrintDiffText(t *testing.T) {
+	var buf bytes.Buffer
+	PrintDiffText(testDiffResult(), &buf)
+	out := buf.String()
+
+	for _, want := range []string{
+		"+ line 4: image.regsitry = docker.io (unknown to chart)",
+		"- line 3: image.pullPolicy = Always",
+		"~ line 1: replicaCount: 1 (int) -> 2 (string)",
+		"Summary: 1 added, 1 removed, 1 changed",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in output, got:\n%s", want, out)
+		}
+	}
+}
+
+func TestPrintDiffText_NoDifferences(t *testing.T) {
+	var buf bytes.Buffer
+	PrintDiffText(&model.DiffResult{OldFile: "a.yaml", NewFile: "b.yaml", ChartName: "test-chart"}, &buf)
+	if !strings.Contains(buf.String(), "No differences found.") {
+		t.Errorf("expected 'No differences found.', got:\n%s", buf.String())
+	}
+}
+
+func TestDiffToJSON(t *testing.T) {
+	data, err := DiffToJSON(testDiffResult())
+	if err != nil {
+		t.Fatalf("DiffToJSON error: %v", err)
+	}
+
+	var out DiffOutput
+	if err := json.Unmarshal(data, &out); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if out.AddedCount != 1 || out.RemovedCount != 1 || out.ChangedCount != 1 {
+		t.Errorf("unexpected counts: %+v", out)
+	}
+	if out.Changes[0].Kind != "added" || !out.Changes[0].Unknown {
+		t.Errorf("expected first change to be an unknown addition, got %+v", out.Changes[0])
+	}
+}
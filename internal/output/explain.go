@@ -0,0 +1,72 @@
+package output
+
+import (
+	"io"
+	"strings"
+
+	"github.com/chrishham/helm-values-checker/internal/model"
+	"github.com/fatih/color"
+)
+
+// ruleExplanations holds remediation guidance per rule ID, shown after
+// each finding in text output with --explain.
+var ruleExplanations = map[string]string{
+	model.RuleUnknownKey:            "This key is not defined in the chart's values.yaml or schema; Helm will ignore it silently. Check the spelling and nesting against the chart's values.yaml, or remove it.",
+	model.RuleTypeMismatch:          "The value has a different YAML type than the chart default (e.g. a string where a number is expected). Templates may render it incorrectly or fail; quote or unquote it to match the default.",
+	model.RuleSchemaRequired:        "The chart's values.schema.json requires this key, so helm install and helm upgrade will refuse to render without it. Set it in the values file or with --set.",
+	model.RuleSchemaDeprecated:      "The chart marks this key as deprecated. It may stop working in a future chart version; move to the replacement named in the chart's documentation.",
+	model.RuleSchemaEnum:            "The value is not one of those allowed by the chart's values.schema.json, so Helm will reject it. Use one of the listed values.",
+	model.RuleSchemaConst:           "The chart's values.schema.json allows only one value here, so Helm will reject anything else. Use that value or remove the key.",
+	model.RuleSchemaRange:           "The number is outside the range allowed by the chart's values.schema.json, so Helm will reject it. Choose a value within the bounds.",
+	model.RuleSchemaLength:          "The string is shorter or longer than allowed by the chart's values.schema.json, so Helm will reject it.",
+	model.RuleSchemaPattern:         "The string does not match the pattern required by the chart's values.schema.json, so Helm will reject it.",
+	model.RuleSchemaFormat:          "The string is not in the format required by the chart's values.schema.json (e.g. an email address or URI), so Helm will reject it.",
+	model.RuleSchemaOneOf:           "The value must match exactly one of the alternatives in the chart's values.schema.json, but matches none or several. Often this means setting only one of mutually exclusive keys.",
+	model.RuleSchemaAnyOf:           "The value matches none of the alternatives allowed by the chart's values.schema.json, so Helm will reject it.",
+	model.RuleSchemaNot:             "The chart's values.schema.json forbids this value or combination of keys, so Helm will reject it. Remove the conflicting setting.",
+	model.RuleSchemaCondition:       "The chart's values.schema.json applies extra constraints when other values are set (if/then/else or allOf), and this value does not meet them. See the other findings for the failing constraint.",
+	model.RuleSchemaItems:           "The list has fewer or more items than the chart's values.schema.json allows, or no item of the required kind, so Helm will reject it.",
+	model.RuleSchemaUnique:          "The chart's values.schema.json requires the items of this list to be unique, so Helm will reject the duplicates. Remove the repeated item.",
+	model.RuleSchemaProperties:      "The map has fewer or more keys than the chart's values.schema.json allows, or keys it does not permit, so Helm will reject it.",
+	model.RuleSchemaDependency:      "The chart's values.schema.json requires another key to be set alongside this one, so Helm will reject it. Set the missing key or remove this one.",
+	model.RuleSchemaConstraint:      schemaExplanation,
+	model.RuleSchemaType:            "The value has a type that the chart's values.schema.json does not allow, so Helm will reject it.",
+	model.RuleSchemaExternalRef:     "The chart's schema references a document outside the chart, which is not fetched. Values under it are not checked against that part of the schema.",
+	model.RuleSchemaDraft:           "The chart's schema uses a JSON Schema draft newer than draft-07. Keywords added in later drafts are not checked, so some constraints may go unreported.",
+	model.RuleRequiredDefault:       "This required key is not set in your values file and falls back to the chart default. Set it explicitly if the default is not right for your environment.",
+	model.RuleYAMLAlias:             "The alias refers to an anchor that does not exist or that contains itself, so the values file cannot be loaded as intended. Define the anchor before the alias, or inline the value.",
+	model.RuleUnexpandedPlaceholder: "The value looks like an environment variable placeholder that was never substituted. Run envsubst (or your templating step) before deploying, or pass --allow-placeholders.",
+	model.RuleInvalidQuantity:       "The value is not a valid Kubernetes quantity (e.g. 500m, 10Gi), so the API server will reject the rendered manifest.",
+	model.RuleIntOverflow:           "The number does not fit the integer size the chart expects. Kubernetes will reject it or it will wrap around; use a smaller value.",
+	model.RuleRedundantValue:        "The value is the same as the chart default, so setting it has no effect. Removing it keeps the values file short and lets you pick up future default changes.",
+	model.RuleDisabledSubchart:      "Chart.yaml enables this subchart through a condition or tags that currently turn it off, so Helm drops its values. Enable the subchart, or remove the values if it should stay off.",
+	model.RuleMissingSubchart:       "Chart.yaml declares this dependency, but the chart does not bundle it under charts/, so there is nothing to enable and its values cannot be checked. Run helm dependency build for the chart, or remove the setting.",
+	model.RuleReleaseSubchart:       "Helm stores a release's chart without its subcharts, so the defaults of this subchart are not available and its values were not checked. Validate against the chart itself with --chart to check them.",
+	model.RuleDisallowedKey:         "Only the keys allowed by --allow-keys may be set in this values file. Remove the key, or extend the allowlist if it should be permitted.",
+}
+
+// schemaExplanation is the fallback for schema-* rules without their own entry.
+const schemaExplanation = "The value violates a constraint in the chart's values.schema.json, so Helm will reject it at install or upgrade time."
+
+// Explanation returns the remediation guidance for a rule ID, or "" if
+// there is none.
+func Explanation(rule string) string {
+	if text, ok := ruleExplanations[rule]; ok {
+		return text
+	}
+	if strings.HasPrefix(rule, "schema-") {
+		return schemaExplanation
+	}
+	return ""
+}
+
+// printExplanation writes the rule's guidance under a finding when
+// explanations are enabled.
+func printExplanation(w io.Writer, f model.Finding, opts Options) {
+	if !opts.Explain {
+		return
+	}
+	if text := Explanation(f.Rule); text != "" {
+		color.New(color.Faint).Fprintf(w, "      %s\n", text)
+	}
+}
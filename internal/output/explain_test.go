@@ -0,0 +1,49 @@
+package output
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/chrishham/helm-values-checker/internal/model"
+)
+
+func TestPrintText_Explain(t *testing.T) {
+	result := &model.ValidationResult{
+		ValuesFile: "values.yaml",
+		ChartName:  "test-chart",
+		Findings: []model.Finding{
+			{Severity: model.SeverityError, Rule: model.RuleUnknownKey, Line: 3, KeyPath: "foo", Message: `Unknown key "foo"`},
+		},
+	}
+	guidance := "Helm will ignore it silently"
+
+	var buf bytes.Buffer
+	PrintText(result, &buf, Options{})
+	if strings.Contains(buf.String(), guidance) {
+		t.Errorf("expected no explanation by default, got:\n%s", buf.String())
+	}
+
+	buf.Reset()
+	PrintText(result, &buf, Options{Explain: true})
+	if !strings.Contains(buf.String(), guidance) {
+		t.Errorf("expected the unknown-key explanation with Explain, got:\n%s", buf.String())
+	}
+}
+
+func TestExplanation(t *testing.T) {
+	if Explanation("schema-multiple-of") != schemaExplanation {
+		t.Error("expected schema rules without an entry to fall back to the generic schema explanation")
+	}
+	if Explanation("no-such-rule") != "" {
+		t.Error("expected no explanation for an unknown rule")
+	}
+}
+
+func TestExplanation_CoversRegisteredRules(t *testing.T) {
+	for _, r := range model.Rules {
+		if Explanation(r.ID) == "" {
+			t.Errorf("rule %s has no explanation", r.ID)
+		}
+	}
+}
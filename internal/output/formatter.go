@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"io"
 	"regexp"
+	"strings"
 
 	"github.com/chrishham/helm-values-checker/internal/model"
 	"github.com/fatih/color"
@@ -27,50 +28,250 @@ func sanitize(s string) string {
 	return string(buf)
 }
 
-// PrintText writes a human-readable validation report to w.
-func PrintText(result *model.ValidationResult, w io.Writer) {
+// Options controls how validation results are rendered.
+type Options struct {
+	// MaxFindings caps the number of findings listed per file. The cap is
+	// applied to errors first, then warnings. 0 means unlimited.
+	MaxFindings int
+
+	// ShowInfo includes info-level findings in the rendered output.
+	ShowInfo bool
+
+	// ShowRules appends each finding's rule ID (e.g., "[unknown-key]") in text output.
+	ShowRules bool
+
+	// GroupBy clusters text output findings within each severity section:
+	// "path" groups them by the top-level key of their key path. "" lists
+	// them in order.
+	GroupBy string
+
+	// Sort reorders findings before they are rendered: SortLine, SortSeverity,
+	// or SortKey. "" keeps discovery order. Reports split into severity
+	// sections apply it within each section.
+	Sort string
+
+	// Explain adds rule-specific remediation guidance after each finding in
+	// text output.
+	Explain bool
+
+	// Width is the terminal width that table output is cut to. 0 means
+	// rows are never cut.
+	Width int
+
+	// Strict counts warnings, like errors, against the valid field of JSON
+	// and YAML output, as --strict does for the exit code.
+	Strict bool
+}
+
+// limitFindings applies the MaxFindings cap to the error and warning lists,
+// returning the findings to display and whether anything was dropped.
+func limitFindings(errors, warnings []model.Finding, max int) ([]model.Finding, []model.Finding, bool) {
+	if max <= 0 || len(errors)+len(warnings) <= max {
+		return errors, warnings, false
+	}
+	if len(errors) >= max {
+		return errors[:max], nil, true
+	}
+	return errors, warnings[:max-len(errors)], true
+}
+
+// printHeader writes the "Validating ... against ..." line of a report,
+// naming the chart version and, if declared, the app version.
+func printHeader(w io.Writer, result *model.ValidationResult) {
 	header := color.New(color.Bold)
 	header.Fprintf(w, "Validating %s against %s", sanitize(result.ValuesFile), sanitize(result.ChartName))
-	if result.ChartVersion != "" {
+	switch {
+	case result.ChartVersion != "" && result.AppVersion != "":
+		header.Fprintf(w, " (%s, app %s)", sanitize(result.ChartVersion), sanitize(result.AppVersion))
+	case result.ChartVersion != "":
 		header.Fprintf(w, " (%s)", sanitize(result.ChartVersion))
 	}
 	fmt.Fprintln(w)
+}
+
+// PrintText writes a human-readable validation report to w.
+func PrintText(result *model.ValidationResult, w io.Writer, opts Options) {
+	result = sortedResult(result, opts)
+	printHeader(w, result)
 	fmt.Fprintln(w)
 
 	errors := result.Errors()
 	warnings := result.Warnings()
+	shownErrors, shownWarnings, _ := limitFindings(errors, warnings, opts.MaxFindings)
 
 	if len(errors) > 0 {
 		errHeader := color.New(color.FgRed, color.Bold)
 		errHeader.Fprintf(w, "ERRORS (%d)\n", len(errors))
-		for _, f := range errors {
-			fmt.Fprintf(w, "  ")
-			color.New(color.FgRed).Fprintf(w, "line %d", f.Line)
-			fmt.Fprintf(w, ": %s", sanitize(f.Message))
-			if f.Suggestion != "" {
-				color.New(color.FgYellow).Fprintf(w, " (did you mean %q?)", sanitize(f.Suggestion))
-			}
-			fmt.Fprintln(w)
-		}
+		printFindings(w, shownErrors, color.FgRed, opts)
+		printOmitted(w, len(errors)-len(shownErrors))
 		fmt.Fprintln(w)
 	}
 
 	if len(warnings) > 0 {
 		warnHeader := color.New(color.FgYellow, color.Bold)
 		warnHeader.Fprintf(w, "WARNINGS (%d)\n", len(warnings))
-		for _, f := range warnings {
-			fmt.Fprintf(w, "  ")
-			color.New(color.FgYellow).Fprintf(w, "line %d", f.Line)
-			fmt.Fprintf(w, ": %s", sanitize(f.Message))
-			fmt.Fprintln(w)
+		printFindings(w, shownWarnings, color.FgYellow, opts)
+		printOmitted(w, len(warnings)-len(shownWarnings))
+		fmt.Fprintln(w)
+	}
+
+	if infos := result.Infos(); opts.ShowInfo && len(infos) > 0 {
+		infoHeader := color.New(color.FgCyan, color.Bold)
+		infoHeader.Fprintf(w, "INFO (%d)\n", len(infos))
+		printFindings(w, infos, color.FgCyan, opts)
+		fmt.Fprintln(w)
+	}
+
+	if c := result.Coverage; c != nil {
+		color.New(color.Bold).Fprintf(w, "COVERAGE (%d of %d defaulted keys set)\n", c.SetKeys, c.DefaultedKeys)
+		for _, path := range c.Unset {
+			fmt.Fprintf(w, "  not set: %s\n", sanitize(path))
 		}
 		fmt.Fprintln(w)
 	}
 
 	summaryColor := color.New(color.Bold)
 	if len(errors) == 0 && len(warnings) == 0 {
-		color.New(color.FgGreen, color.Bold).Fprintln(w, "No issues found.")
+		color.New(color.FgGreen, color.Bold).Fprintf(w, "No issues found%s.\n", checkedKeys(result))
 	} else {
-		summaryColor.Fprintf(w, "Summary: %d error(s), %d warning(s)\n", len(errors), len(warnings))
+		summaryColor.Fprintf(w, "Summary: %d error(s), %d warning(s)%s\n", len(errors), len(warnings), checkedKeys(result))
+	}
+}
+
+// checkedKeys returns the " (checked N key(s))" note of a summary line, or
+// "" for results that counted no keys.
+func checkedKeys(result *model.ValidationResult) string {
+	if result.CheckedKeys == 0 {
+		return ""
+	}
+	return fmt.Sprintf(" (checked %d key(s))", result.CheckedKeys)
+}
+
+// printFindings writes the findings of one severity section, under a
+// sub-header per top-level key with opts.GroupBy "path".
+func printFindings(w io.Writer, findings []model.Finding, lineColor color.Attribute, opts Options) {
+	if opts.GroupBy != "path" {
+		for _, f := range findings {
+			printFinding(w, f, lineColor, "  ", opts)
+		}
+		return
+	}
+
+	var groups []string
+	byGroup := make(map[string][]model.Finding)
+	for _, f := range findings {
+		g := topLevelKey(f.KeyPath)
+		if _, ok := byGroup[g]; !ok {
+			groups = append(groups, g)
+		}
+		byGroup[g] = append(byGroup[g], f)
+	}
+	for _, g := range groups {
+		color.New(color.Bold).Fprintf(w, "  %s (%d)\n", sanitize(g), len(byGroup[g]))
+		for _, f := range byGroup[g] {
+			printFinding(w, f, lineColor, "    ", opts)
+		}
+	}
+}
+
+// printFinding writes one finding line, ending with the schema's
+// documentation link if any, with its source line and, if enabled, its
+// explanation below.
+func printFinding(w io.Writer, f model.Finding, lineColor color.Attribute, indent string, opts Options) {
+	fmt.Fprint(w, indent)
+	color.New(lineColor).Fprintf(w, "line %s", f.Position())
+	if f.File != "" {
+		fmt.Fprintf(w, " in %s", sanitize(f.File))
+	}
+	fmt.Fprintf(w, ": %s", sanitize(f.DisplayMessage()))
+	if f.Severity == model.SeverityError {
+		printSuggestion(w, f)
+	}
+	if f.DocURL != "" {
+		fmt.Fprintf(w, " (see: %s)", sanitize(f.DocURL))
+	}
+	printRule(w, f, opts)
+	fmt.Fprintln(w)
+	printSource(w, f)
+	printExplanation(w, f, opts)
+}
+
+// findingFile returns the values file a finding points into: the file
+// that set its key for merged overlays, otherwise the result's file.
+func findingFile(result *model.ValidationResult, f model.Finding) string {
+	if f.File != "" {
+		return f.File
+	}
+	return result.ValuesFile
+}
+
+// topLevelKey returns the first segment of a key path, or "(root)" for
+// findings about the values file as a whole.
+func topLevelKey(path string) string {
+	if i := strings.IndexAny(path, ".["); i >= 0 {
+		path = path[:i]
+	}
+	if path == "" {
+		return "(root)"
+	}
+	return path
+}
+
+// PrintTextTotals writes the aggregate totals line for a multi-file run.
+func PrintTextTotals(results []*model.ValidationResult, w io.Writer) {
+	s := summarize(results, Options{})
+	fmt.Fprintln(w)
+	color.New(color.Bold).Fprintf(w, "Total: %d error(s), %d warning(s) across %d files\n", s.ErrorCount, s.WarningCount, s.Files)
+}
+
+// printSuggestion writes the "did you mean?" hint, listing any runner-up
+// candidates after the primary suggestion and where a relocated suggestion
+// is defined.
+func printSuggestion(w io.Writer, f model.Finding) {
+	if f.Suggestion == "" {
+		return
+	}
+	var others []string
+	for _, s := range f.Suggestions {
+		if s != f.Suggestion {
+			others = append(others, fmt.Sprintf("%q", sanitize(s)))
+		}
+	}
+	primary := suggestionTarget(f)
+	if len(others) == 0 {
+		color.New(color.FgYellow).Fprintf(w, " (did you mean %s?)", primary)
+		return
+	}
+	color.New(color.FgYellow).Fprintf(w, " (did you mean %s (or %s)?)", primary, strings.Join(others, ", "))
+}
+
+// suggestionTarget quotes a finding's primary suggestion, adding the line
+// that defines it when the suggestion is a relocated key.
+func suggestionTarget(f model.Finding) string {
+	target := fmt.Sprintf("%q", sanitize(f.Suggestion))
+	if f.SuggestionLine > 0 {
+		target += fmt.Sprintf(" (defined at line %d)", f.SuggestionLine)
+	}
+	return target
+}
+
+// printRule writes a trailing "[rule]" when rule IDs are enabled.
+func printRule(w io.Writer, f model.Finding, opts Options) {
+	if opts.ShowRules && f.Rule != "" {
+		color.New(color.Faint).Fprintf(w, " [%s]", sanitize(f.Rule))
+	}
+}
+
+// printSource writes the offending source line under a finding, if known.
+func printSource(w io.Writer, f model.Finding) {
+	if f.SourceLine != "" {
+		color.New(color.Faint).Fprintf(w, "      > %s\n", sanitize(f.SourceLine))
+	}
+}
+
+// printOmitted writes a "... and N more" line when findings were truncated.
+func printOmitted(w io.Writer, n int) {
+	if n > 0 {
+		fmt.Fprintf(w, "  ... and %d more\n", n)
 	}
 }
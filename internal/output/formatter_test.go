@@ -2,6 +2,7 @@ package output
 
 import (
 	"bytes"
+	"encoding/json"
 	"strings"
 	"testing"
 
@@ -16,7 +17,7 @@ func TestPrintText_NoIssues(t *testing.T) {
 	}
 
 	var buf bytes.Buffer
-	PrintText(result, &buf)
+	PrintText(result, &buf, Options{})
 	output := buf.String()
 
 	if !strings.Contains(output, "No issues found") {
@@ -41,7 +42,7 @@ func TestPrintText_WithErrors(t *testing.T) {
 	}
 
 	var buf bytes.Buffer
-	PrintText(result, &buf)
+	PrintText(result, &buf, Options{})
 	output := buf.String()
 
 	if !strings.Contains(output, "ERRORS (1)") {
@@ -117,7 +118,7 @@ func TestPrintText_SanitizesOutput(t *testing.T) {
 	}
 
 	var buf bytes.Buffer
-	PrintText(result, &buf)
+	PrintText(result, &buf, Options{})
 	output := buf.String()
 
 	if strings.Contains(output, "\x1b[31m.yaml") {
@@ -145,7 +146,7 @@ func TestToJSON(t *testing.T) {
 		},
 	}
 
-	j := ToJSON(result)
+	j := buildOutput(result, Options{})
 	if j.ErrorCount != 1 {
 		t.Errorf("expected 1 error, got %d", j.ErrorCount)
 	}
@@ -153,3 +154,533 @@ func TestToJSON(t *testing.T) {
 		t.Errorf("expected 1 warning, got %d", j.WarningCount)
 	}
 }
+
+func TestPrintText_MaxFindings(t *testing.T) {
+	result := &model.ValidationResult{
+		ValuesFile: "values.yaml",
+		ChartName:  "test-chart",
+		Findings: []model.Finding{
+			{Severity: model.SeverityError, Line: 1, KeyPath: "a", Message: "err a"},
+			{Severity: model.SeverityError, Line: 2, KeyPath: "b", Message: "err b"},
+			{Severity: model.SeverityError, Line: 3, KeyPath: "c", Message: "err c"},
+			{Severity: model.SeverityWarning, Line: 4, KeyPath: "d", Message: "warn d"},
+		},
+	}
+
+	var buf bytes.Buffer
+	PrintText(result, &buf, Options{MaxFindings: 2})
+	output := buf.String()
+
+	if !strings.Contains(output, "ERRORS (3)") {
+		t.Errorf("expected full error count in header, got:\n%s", output)
+	}
+	if strings.Contains(output, "err c") || strings.Contains(output, "warn d") {
+		t.Errorf("expected findings beyond the cap to be omitted, got:\n%s", output)
+	}
+	if !strings.Contains(output, "... and 1 more") {
+		t.Errorf("expected '... and 1 more' in output, got:\n%s", output)
+	}
+	if len(result.Findings) != 4 {
+		t.Errorf("expected result findings to be preserved, got %d", len(result.Findings))
+	}
+}
+
+func TestToJSON_MaxFindings(t *testing.T) {
+	result := &model.ValidationResult{
+		ValuesFile: "values.yaml",
+		ChartName:  "test-chart",
+		Findings: []model.Finding{
+			{Severity: model.SeverityError, Line: 1, KeyPath: "a", Message: "err"},
+			{Severity: model.SeverityWarning, Line: 2, KeyPath: "b", Message: "warn"},
+			{Severity: model.SeverityWarning, Line: 3, KeyPath: "c", Message: "warn"},
+		},
+	}
+
+	j := buildOutput(result, Options{MaxFindings: 2})
+	if !j.Truncated {
+		t.Error("expected truncated to be true")
+	}
+	if len(j.Errors) != 1 || len(j.Warnings) != 1 {
+		t.Errorf("expected 1 error and 1 warning listed, got %d and %d", len(j.Errors), len(j.Warnings))
+	}
+	if j.ErrorCount != 1 || j.WarningCount != 2 {
+		t.Errorf("expected full counts 1/2, got %d/%d", j.ErrorCount, j.WarningCount)
+	}
+
+	if buildOutput(result, Options{}).Truncated {
+		t.Error("expected truncated to be false without a cap")
+	}
+}
+
+func TestPrintText_ShowInfo(t *testing.T) {
+	result := &model.ValidationResult{
+		ValuesFile: "values.yaml",
+		ChartName:  "test-chart",
+		Findings: []model.Finding{
+			{Severity: model.SeverityInfo, KeyPath: "auth.username", Message: "Required key \"auth.username\" is not set; using the chart default"},
+		},
+	}
+
+	var buf bytes.Buffer
+	PrintText(result, &buf, Options{})
+	if strings.Contains(buf.String(), "INFO") {
+		t.Errorf("expected no INFO section by default, got:\n%s", buf.String())
+	}
+
+	buf.Reset()
+	PrintText(result, &buf, Options{ShowInfo: true})
+	if !strings.Contains(buf.String(), "INFO (1)") {
+		t.Errorf("expected 'INFO (1)' with ShowInfo, got:\n%s", buf.String())
+	}
+
+	if j := buildOutput(result, Options{}); len(j.Infos) != 0 {
+		t.Errorf("expected no infos in JSON by default, got %d", len(j.Infos))
+	}
+	if j := buildOutput(result, Options{ShowInfo: true}); j.InfoCount != 1 {
+		t.Errorf("expected infoCount 1 with ShowInfo, got %d", j.InfoCount)
+	}
+}
+
+func TestToYAML_OmitsEmptySuggestion(t *testing.T) {
+	result := &model.ValidationResult{
+		ValuesFile:   "values.yaml",
+		ChartName:    "test-chart",
+		ChartVersion: "1.0.0",
+		Findings: []model.Finding{
+			{Severity: model.SeverityError, Line: 5, KeyPath: "image.regsitry", Message: "err", Suggestion: "image.registry"},
+			{Severity: model.SeverityWarning, Line: 10, KeyPath: "c.d", Message: "warn"},
+		},
+	}
+
+	data, err := ToYAML(result, Options{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	out := string(data)
+
+	if !strings.Contains(out, "valuesFile: values.yaml") {
+		t.Errorf("expected camelCase field names in YAML, got:\n%s", out)
+	}
+	if strings.Count(out, "suggestion:") != 1 {
+		t.Errorf("expected exactly one suggestion key, got:\n%s", out)
+	}
+	if !strings.Contains(out, "errorCount: 1") || !strings.Contains(out, "warningCount: 1") {
+		t.Errorf("expected counts in YAML, got:\n%s", out)
+	}
+}
+
+func TestPrintText_ShowRules(t *testing.T) {
+	result := &model.ValidationResult{
+		ValuesFile: "values.yaml",
+		ChartName:  "test-chart",
+		Findings: []model.Finding{
+			{Severity: model.SeverityError, Rule: model.RuleUnknownKey, Line: 3, KeyPath: "foo", Message: `Unknown key "foo"`},
+		},
+	}
+
+	var buf bytes.Buffer
+	PrintText(result, &buf, Options{})
+	if strings.Contains(buf.String(), "[unknown-key]") {
+		t.Errorf("expected no rule suffix by default, got:\n%s", buf.String())
+	}
+
+	buf.Reset()
+	PrintText(result, &buf, Options{ShowRules: true})
+	if !strings.Contains(buf.String(), "[unknown-key]") {
+		t.Errorf("expected '[unknown-key]' with ShowRules, got:\n%s", buf.String())
+	}
+
+	if j := buildOutput(result, Options{}); j.Errors[0].Rule != model.RuleUnknownKey {
+		t.Errorf("expected JSON rule %q, got %q", model.RuleUnknownKey, j.Errors[0].Rule)
+	}
+}
+
+func TestPrintText_SourceLine(t *testing.T) {
+	result := &model.ValidationResult{
+		ValuesFile: "values.yaml",
+		ChartName:  "test-chart",
+		Findings: []model.Finding{
+			{Severity: model.SeverityError, Line: 3, KeyPath: "image.regsitry", Message: `Unknown key "image.regsitry"`, SourceLine: "regsitry: docker.io"},
+			{Severity: model.SeverityError, Line: 0, KeyPath: "name", Message: "Schema validation: name is required"},
+		},
+	}
+
+	var buf bytes.Buffer
+	PrintText(result, &buf, Options{})
+	out := buf.String()
+	if !strings.Contains(out, "> regsitry: docker.io") {
+		t.Errorf("expected source snippet in output, got:\n%s", out)
+	}
+	if strings.Count(out, ">") != 1 {
+		t.Errorf("expected exactly one snippet, got:\n%s", out)
+	}
+
+	j := buildOutput(result, Options{})
+	if j.Errors[0].SourceLine != "regsitry: docker.io" || j.Errors[1].SourceLine != "" {
+		t.Errorf("unexpected JSON source lines: %+v", j.Errors)
+	}
+}
+
+func TestToJSONAll_Summary(t *testing.T) {
+	results := []*model.ValidationResult{
+		{
+			ValuesFile: "a.yaml",
+			Findings: []model.Finding{
+				{Severity: model.SeverityError, Line: 1, Message: "bad"},
+				{Severity: model.SeverityWarning, Line: 2, Message: "old"},
+			},
+		},
+		{
+			ValuesFile: "b.yaml",
+			Findings: []model.Finding{
+				{Severity: model.SeverityError, Line: 3, Message: "worse"},
+			},
+		},
+	}
+
+	data, err := ToJSONAll(results, Options{})
+	if err != nil {
+		t.Fatalf("ToJSONAll error: %v", err)
+	}
+	var out MultiOutput
+	if err := json.Unmarshal(data, &out); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if len(out.Results) != 2 || out.Results[1].ValuesFile != "b.yaml" {
+		t.Errorf("expected both results in order, got %+v", out.Results)
+	}
+	if out.Summary != (Summary{Files: 2, ErrorCount: 2, WarningCount: 1}) {
+		t.Errorf("unexpected summary: %+v", out.Summary)
+	}
+
+	var buf bytes.Buffer
+	PrintTextTotals(results, &buf)
+	if !strings.Contains(buf.String(), "Total: 2 error(s), 1 warning(s) across 2 files") {
+		t.Errorf("unexpected totals line: %q", buf.String())
+	}
+}
+
+func TestPrintText_MultipleSuggestions(t *testing.T) {
+	result := &model.ValidationResult{
+		ValuesFile: "values.yaml",
+		ChartName:  "test-chart",
+		Findings: []model.Finding{
+			{Severity: model.SeverityError, Line: 2, KeyPath: "service.prot", Message: `Unknown key "service.prot"`,
+				Suggestion: "service.port", Suggestions: []string{"service.port", "service.host", "service.ports"}, Confidence: 0.75},
+		},
+	}
+
+	var buf bytes.Buffer
+	PrintText(result, &buf, Options{})
+	want := `(did you mean "service.port" (or "service.host", "service.ports")?)`
+	if !strings.Contains(buf.String(), want) {
+		t.Errorf("expected %s in output, got:\n%s", want, buf.String())
+	}
+
+	j := buildOutput(result, Options{})
+	if len(j.Errors[0].Suggestions) != 3 || j.Errors[0].Suggestion != "service.port" || j.Errors[0].Confidence == nil || *j.Errors[0].Confidence != 0.75 {
+		t.Errorf("expected suggestions array in JSON output, got %+v", j.Errors[0])
+	}
+}
+
+func TestToJSON_ByRule(t *testing.T) {
+	result := &model.ValidationResult{
+		ValuesFile: "values.yaml",
+		Findings: []model.Finding{
+			{Severity: model.SeverityError, Rule: model.RuleUnknownKey, Line: 1, KeyPath: "a", Message: "unknown a"},
+			{Severity: model.SeverityError, Rule: model.RuleUnknownKey, Line: 2, KeyPath: "b", Message: "unknown b"},
+			{Severity: model.SeverityError, Rule: model.RuleTypeMismatch, Line: 3, KeyPath: "c", Message: "mismatch c"},
+			{Severity: model.SeverityWarning, Rule: model.RuleSchemaDeprecated, Line: 4, KeyPath: "d", Message: "deprecated d"},
+			{Severity: model.SeverityInfo, Rule: model.RuleRequiredDefault, KeyPath: "e", Message: "default e"},
+		},
+	}
+
+	// Counts cover every finding, even those cut by MaxFindings
+	data, err := ToJSON(result, Options{MaxFindings: 1})
+	if err != nil {
+		t.Fatalf("ToJSON error: %v", err)
+	}
+	var out JSONOutput
+	if err := json.Unmarshal(data, &out); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	want := map[string]int{model.RuleUnknownKey: 2, model.RuleTypeMismatch: 1, model.RuleSchemaDeprecated: 1}
+	if len(out.ByRule) != len(want) {
+		t.Fatalf("expected byRule %v, got %v", want, out.ByRule)
+	}
+	for rule, n := range want {
+		if out.ByRule[rule] != n {
+			t.Errorf("byRule[%s] = %d, want %d", rule, out.ByRule[rule], n)
+		}
+	}
+
+	clean, err := ToJSON(&model.ValidationResult{ValuesFile: "values.yaml"}, Options{})
+	if err != nil {
+		t.Fatalf("ToJSON error: %v", err)
+	}
+	if !strings.Contains(string(clean), `"byRule": {}`) {
+		t.Errorf("expected an empty byRule object for a clean file, got %s", clean)
+	}
+}
+
+func TestPrintText_SuggestionLine(t *testing.T) {
+	result := &model.ValidationResult{
+		ValuesFile: "values.yaml",
+		ChartName:  "test-chart",
+		Findings: []model.Finding{
+			{Severity: model.SeverityError, Line: 3, KeyPath: "config.cors", Message: `Unknown key "config.cors"`,
+				Suggestion: "config.security.cors", Suggestions: []string{"config.security.cors"}, SuggestionLine: 42},
+		},
+	}
+
+	var buf bytes.Buffer
+	PrintText(result, &buf, Options{})
+	want := `(did you mean "config.security.cors" (defined at line 42)?)`
+	if !strings.Contains(buf.String(), want) {
+		t.Errorf("expected %s in output, got:\n%s", want, buf.String())
+	}
+
+	if j := buildOutput(result, Options{}); j.Errors[0].SuggestionLine != 42 {
+		t.Errorf("expected suggestionLine in JSON output, got %+v", j.Errors[0])
+	}
+}
+
+func TestPrintText_Coverage(t *testing.T) {
+	result := &model.ValidationResult{
+		ValuesFile: "values.yaml",
+		ChartName:  "test-chart",
+		Coverage:   &model.Coverage{DefaultedKeys: 3, SetKeys: 1, Unset: []string{"image.repository", "service.port"}},
+	}
+
+	var buf bytes.Buffer
+	PrintText(result, &buf, Options{})
+	out := buf.String()
+	if !strings.Contains(out, "COVERAGE (1 of 3 defaulted keys set)") || !strings.Contains(out, "  not set: service.port") {
+		t.Errorf("expected the coverage section, got:\n%s", out)
+	}
+
+	j := buildOutput(result, Options{})
+	if j.Coverage == nil || j.Coverage.SetKeys != 1 || len(j.Coverage.Unset) != 2 {
+		t.Errorf("expected coverage in JSON output, got %+v", j.Coverage)
+	}
+	if buildOutput(&model.ValidationResult{}, Options{}).Coverage != nil {
+		t.Error("expected no JSON coverage unless requested")
+	}
+}
+
+func TestPrintText_GroupByPath(t *testing.T) {
+	result := &model.ValidationResult{
+		ValuesFile: "values.yaml",
+		ChartName:  "test-chart",
+		Findings: []model.Finding{
+			{Severity: model.SeverityError, Line: 3, KeyPath: "ingress.hosts[0].hots", Message: `Unknown key "ingress.hosts[0].hots"`},
+			{Severity: model.SeverityError, Line: 7, KeyPath: "image.tagg", Message: `Unknown key "image.tagg"`},
+			{Severity: model.SeverityError, Line: 9, KeyPath: "ingress.enabeld", Message: `Unknown key "ingress.enabeld"`},
+			{Severity: model.SeverityWarning, Line: 12, KeyPath: "ingress.className", Message: `Deprecated key "ingress.className"`},
+			{Severity: model.SeverityWarning, Line: 1, KeyPath: "", Message: "Values file is empty"},
+		},
+	}
+
+	var buf bytes.Buffer
+	PrintText(result, &buf, Options{GroupBy: "path"})
+	out := buf.String()
+	want := []string{
+		"ERRORS (3)",
+		"  ingress (2)",
+		"    line 3:",
+		"    line 9:",
+		"  image (1)",
+		"    line 7:",
+		"WARNINGS (2)",
+		"  ingress (1)",
+		"    line 12:",
+		"  (root) (1)",
+		"    line 1:",
+	}
+	pos := 0
+	for _, w := range want {
+		i := strings.Index(out[pos:], w)
+		if i < 0 {
+			t.Fatalf("expected %q after offset %d, got:\n%s", w, pos, out)
+		}
+		pos += i + len(w)
+	}
+
+	buf.Reset()
+	PrintText(result, &buf, Options{})
+	if strings.Contains(buf.String(), "ingress (2)") {
+		t.Errorf("expected no group headers by default, got:\n%s", buf.String())
+	}
+}
+
+func TestToJSON_AppVersion(t *testing.T) {
+	result := &model.ValidationResult{ValuesFile: "values.yaml", ChartName: "test-chart", ChartVersion: "1.0.0", AppVersion: "2.4.1"}
+
+	data, err := ToJSON(result, Options{})
+	if err != nil {
+		t.Fatalf("ToJSON error: %v", err)
+	}
+	if !strings.Contains(string(data), `"appVersion": "2.4.1"`) {
+		t.Errorf("expected appVersion in JSON output, got:\n%s", data)
+	}
+
+	var buf bytes.Buffer
+	PrintText(result, &buf, Options{})
+	if !strings.Contains(buf.String(), "Validating values.yaml against test-chart (1.0.0, app 2.4.1)") {
+		t.Errorf("expected the app version in the header, got:\n%s", buf.String())
+	}
+
+	data, err = ToJSON(&model.ValidationResult{ChartName: "test-chart"}, Options{})
+	if err != nil {
+		t.Fatalf("ToJSON error: %v", err)
+	}
+	if strings.Contains(string(data), "appVersion") {
+		t.Errorf("expected no appVersion for charts without one, got:\n%s", data)
+	}
+}
+
+func TestPrintText_Column(t *testing.T) {
+	result := &model.ValidationResult{
+		ValuesFile: "values.yaml",
+		ChartName:  "test-chart",
+		Findings: []model.Finding{
+			{Severity: model.SeverityError, Line: 4, Column: 5, KeyPath: "ingress.tls.secretNam", Message: `Unknown key "ingress.tls.secretNam"`},
+			{Severity: model.SeverityError, Line: 1, KeyPath: "", Message: "Schema validation: name is required"},
+		},
+	}
+
+	var buf bytes.Buffer
+	PrintText(result, &buf, Options{})
+	if !strings.Contains(buf.String(), "line 4:5: ") || !strings.Contains(buf.String(), "line 1: ") {
+		t.Errorf("expected line:column where known, got:\n%s", buf.String())
+	}
+
+	j := buildOutput(result, Options{})
+	if j.Errors[0].Column != 5 || j.Errors[1].Column != 0 {
+		t.Errorf("expected columns in JSON output, got %+v", j.Errors)
+	}
+}
+
+func TestToJSON_Valid(t *testing.T) {
+	clean := &model.ValidationResult{ValuesFile: "clean.yaml"}
+	warned := &model.ValidationResult{ValuesFile: "warned.yaml", Findings: []model.Finding{
+		{Severity: model.SeverityWarning, Line: 2, KeyPath: "old", Message: "deprecated"},
+	}}
+	failed := &model.ValidationResult{ValuesFile: "failed.yaml", Findings: []model.Finding{
+		{Severity: model.SeverityError, Line: 1, KeyPath: "bad", Message: "unknown"},
+	}}
+
+	tests := []struct {
+		result *model.ValidationResult
+		strict bool
+		want   bool
+	}{
+		{clean, false, true},
+		{clean, true, true},
+		{warned, false, true},
+		{warned, true, false},
+		{failed, false, false},
+		{failed, true, false},
+	}
+	for _, tt := range tests {
+		data, err := ToJSON(tt.result, Options{Strict: tt.strict})
+		if err != nil {
+			t.Fatalf("ToJSON error: %v", err)
+		}
+		var out map[string]interface{}
+		if err := json.Unmarshal(data, &out); err != nil {
+			t.Fatalf("invalid JSON: %v", err)
+		}
+		if out["valid"] != tt.want {
+			t.Errorf("%s (strict %t): expected valid %t, got %v", tt.result.ValuesFile, tt.strict, tt.want, out["valid"])
+		}
+	}
+
+	for _, strict := range []bool{false, true} {
+		data, err := ToJSONAll([]*model.ValidationResult{clean, warned}, Options{Strict: strict})
+		if err != nil {
+			t.Fatalf("ToJSONAll error: %v", err)
+		}
+		var out MultiOutput
+		if err := json.Unmarshal(data, &out); err != nil {
+			t.Fatalf("invalid JSON: %v", err)
+		}
+		if out.Valid != !strict || !out.Results[0].Valid {
+			t.Errorf("strict %t: expected top-level valid %t with the clean file valid, got %+v", strict, !strict, out)
+		}
+	}
+}
+
+func TestPrintText_DocURL(t *testing.T) {
+	result := &model.ValidationResult{
+		ValuesFile: "values.yaml",
+		ChartName:  "test-chart",
+		Findings: []model.Finding{
+			{Severity: model.SeverityWarning, Line: 4, KeyPath: "oldSetting", Message: `Deprecated key "oldSetting"`, DocURL: "https://example.com/docs/migration"},
+		},
+	}
+
+	var buf bytes.Buffer
+	PrintText(result, &buf, Options{})
+	if want := `Deprecated key "oldSetting" (see: https://example.com/docs/migration)`; !strings.Contains(buf.String(), want) {
+		t.Errorf("expected %q in output, got:\n%s", want, buf.String())
+	}
+
+	if j := buildOutput(result, Options{}); j.Warnings[0].DocURL != "https://example.com/docs/migration" {
+		t.Errorf("expected docURL in JSON output, got %+v", j.Warnings[0])
+	}
+}
+
+func TestPrintText_CheckedKeys(t *testing.T) {
+	result := &model.ValidationResult{ValuesFile: "values.yaml", ChartName: "test-chart", CheckedKeys: 42, KnownKeys: 41}
+
+	var buf bytes.Buffer
+	PrintText(result, &buf, Options{})
+	if !strings.Contains(buf.String(), "No issues found (checked 42 key(s)).") {
+		t.Errorf("expected the checked key count in the summary, got:\n%s", buf.String())
+	}
+
+	data, err := ToJSON(result, Options{})
+	if err != nil {
+		t.Fatalf("ToJSON error: %v", err)
+	}
+	if !strings.Contains(string(data), `"checkedKeys": 42`) || !strings.Contains(string(data), `"knownKeys": 41`) {
+		t.Errorf("expected key counts in JSON, got %s", data)
+	}
+}
+
+func TestPrintText_FindingFile(t *testing.T) {
+	result := &model.ValidationResult{ValuesFile: "base.yaml + patch.yaml", ChartName: "test-chart", Findings: []model.Finding{
+		{Severity: model.SeverityError, Rule: model.RuleUnknownKey, Line: 5, KeyPath: "image.regsitry", Message: `Unknown key "image.regsitry"`, File: "patch.yaml"},
+	}}
+
+	var buf bytes.Buffer
+	PrintText(result, &buf, Options{})
+	if !strings.Contains(buf.String(), `line 5 in patch.yaml: Unknown key "image.regsitry"`) {
+		t.Errorf("expected the finding to name the overlay that set it, got:\n%s", buf.String())
+	}
+}
+
+func TestToJSON_ZeroConfidence(t *testing.T) {
+	result := &model.ValidationResult{ValuesFile: "values.yaml", Findings: []model.Finding{
+		{Severity: model.SeverityError, Rule: model.RuleUnknownKey, Line: 1, KeyPath: "zzz", Message: `Unknown key "zzz"`, Suggestion: "image.tag"},
+		{Severity: model.SeverityError, Rule: model.RuleUnknownKey, Line: 2, KeyPath: "qqq", Message: `Unknown key "qqq"`},
+	}}
+
+	data, err := ToJSON(result, Options{})
+	if err != nil {
+		t.Fatalf("ToJSON error: %v", err)
+	}
+	var out struct {
+		Errors []map[string]interface{} `json:"errors"`
+	}
+	if err := json.Unmarshal(data, &out); err != nil {
+		t.Fatal(err)
+	}
+	if c, ok := out.Errors[0]["confidence"]; !ok || c != 0.0 {
+		t.Errorf("expected a zero confidence alongside the suggestion, got %v", out.Errors[0])
+	}
+	if _, ok := out.Errors[1]["confidence"]; ok {
+		t.Errorf("expected no confidence without a suggestion, got %v", out.Errors[1])
+	}
+}
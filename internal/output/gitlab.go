@@ -0,0 +1,89 @@
+package output
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	"github.com/chrishham/helm-values-checker/internal/model"
+)
+
+// GitLabIssue is a single entry of a GitLab Code Quality report.
+type GitLabIssue struct {
+	Description string         `json:"description"`
+	CheckName   string         `json:"check_name"`
+	Fingerprint string         `json:"fingerprint"`
+	Severity    string         `json:"severity"`
+	Location    GitLabLocation `json:"location"`
+}
+
+// GitLabLocation points a Code Quality issue at a file and line.
+type GitLabLocation struct {
+	Path  string      `json:"path"`
+	Lines GitLabLines `json:"lines"`
+}
+
+// GitLabLines holds the line a Code Quality issue starts at.
+type GitLabLines struct {
+	Begin int `json:"begin"`
+}
+
+// ToGitLab encodes the findings of all results as a GitLab Code Quality
+// report. Errors are "major" and warnings "minor"; info findings are
+// included as "info" only with ShowInfo. Fingerprints hash the file, rule,
+// and key path, so an issue keeps its fingerprint while its line or value
+// changes, letting GitLab track it across pipelines.
+func ToGitLab(results []*model.ValidationResult, opts Options) ([]byte, error) {
+	issues := []GitLabIssue{}
+	seen := make(map[string]int)
+	for _, result := range results {
+		errors, warnings, _ := limitFindings(result.Errors(), result.Warnings(), opts.MaxFindings)
+		findings := append(append([]model.Finding(nil), errors...), warnings...)
+		if opts.ShowInfo {
+			findings = append(findings, result.Infos()...)
+		}
+		sortFindings(findings, opts.Sort)
+
+		for _, f := range findings {
+			line := f.Line
+			if line < 1 {
+				line = 1
+			}
+			issues = append(issues, GitLabIssue{
+				Description: f.DisplayMessage(),
+				CheckName:   f.Rule,
+				Fingerprint: gitLabFingerprint(findingFile(result, f), f, seen),
+				Severity:    gitLabSeverity(f.Severity),
+				Location:    GitLabLocation{Path: findingFile(result, f), Lines: GitLabLines{Begin: line}},
+			})
+		}
+	}
+	return json.MarshalIndent(issues, "", "  ")
+}
+
+// gitLabFingerprint hashes a finding's file, rule, and key path. Repeats of
+// the same triple (e.g., several required keys missing from one object)
+// are numbered in report order so that fingerprints stay unique.
+func gitLabFingerprint(file string, f model.Finding, seen map[string]int) string {
+	key := fmt.Sprintf("%s\x00%s\x00%s", file, f.Rule, f.KeyPath)
+	if n := seen[key]; n > 0 {
+		seen[key] = n + 1
+		key = fmt.Sprintf("%s\x00%d", key, n)
+	} else {
+		seen[key] = 1
+	}
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+func gitLabSeverity(s model.Severity) string {
+	switch s {
+	case model.SeverityError:
+		return "major"
+	case model.SeverityWarning:
+		return "minor"
+	default:
+		return "info"
+	}
+}
@@ -0,0 +1,91 @@
+package output
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/chrishham/helm-values-checker/internal/model"
+)
+
+func gitLabIssues(t *testing.T, results []*model.ValidationResult, opts Options) []GitLabIssue {
+	t.Helper()
+	data, err := ToGitLab(results, opts)
+	if err != nil {
+		t.Fatalf("ToGitLab error: %v", err)
+	}
+	var issues []GitLabIssue
+	if err := json.Unmarshal(data, &issues); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, data)
+	}
+	return issues
+}
+
+func TestToGitLab(t *testing.T) {
+	result := &model.ValidationResult{
+		ValuesFile: "values.yaml",
+		Findings: []model.Finding{
+			{Severity: model.SeverityError, Rule: model.RuleUnknownKey, Line: 4, KeyPath: "image.regsitry", Message: `Unknown key "image.regsitry"`},
+			{Severity: model.SeverityWarning, Rule: model.RuleSchemaDeprecated, Line: 9, KeyPath: "oldSetting", Message: `Deprecated key "oldSetting"`},
+			{Severity: model.SeverityError, Rule: model.RuleSchemaRequired, KeyPath: "", Message: "Schema validation: name is required"},
+			{Severity: model.SeverityError, Rule: model.RuleSchemaRequired, KeyPath: "", Message: "Schema validation: port is required"},
+		},
+	}
+
+	issues := gitLabIssues(t, []*model.ValidationResult{result}, Options{})
+	if len(issues) != 4 {
+		t.Fatalf("expected 4 issues, got %d: %+v", len(issues), issues)
+	}
+
+	first := issues[0]
+	if first.Severity != "major" || first.CheckName != model.RuleUnknownKey || first.Location.Path != "values.yaml" || first.Location.Lines.Begin != 4 {
+		t.Errorf("unexpected first issue: %+v", first)
+	}
+	if issues[3].Severity != "minor" {
+		t.Errorf("expected the warning to be minor, got %+v", issues[3])
+	}
+	if issues[1].Location.Lines.Begin != 1 {
+		t.Errorf("expected findings without a line to point at line 1, got %d", issues[1].Location.Lines.Begin)
+	}
+
+	seen := make(map[string]bool)
+	for _, issue := range issues {
+		if seen[issue.Fingerprint] {
+			t.Errorf("duplicate fingerprint %s", issue.Fingerprint)
+		}
+		seen[issue.Fingerprint] = true
+	}
+}
+
+func TestToGitLab_FingerprintStable(t *testing.T) {
+	finding := model.Finding{Severity: model.SeverityError, Rule: model.RuleTypeMismatch, Line: 1, KeyPath: "replicaCount",
+		Message: `Type mismatch at "replicaCount": expected int, got string ("three")`}
+	before := &model.ValidationResult{ValuesFile: "values.yaml", Findings: []model.Finding{finding}}
+
+	// Same finding on another line and with another value keeps its fingerprint
+	moved := finding
+	moved.Line = 12
+	moved.Message = `Type mismatch at "replicaCount": expected int, got string ("four")`
+	after := &model.ValidationResult{ValuesFile: "values.yaml", Findings: []model.Finding{moved}}
+
+	a := gitLabIssues(t, []*model.ValidationResult{before}, Options{})
+	b := gitLabIssues(t, []*model.ValidationResult{before}, Options{})
+	c := gitLabIssues(t, []*model.ValidationResult{after}, Options{})
+	if a[0].Fingerprint != b[0].Fingerprint || a[0].Fingerprint != c[0].Fingerprint {
+		t.Errorf("expected stable fingerprints, got %s, %s, %s", a[0].Fingerprint, b[0].Fingerprint, c[0].Fingerprint)
+	}
+
+	other := &model.ValidationResult{ValuesFile: "prod-values.yaml", Findings: []model.Finding{finding}}
+	if d := gitLabIssues(t, []*model.ValidationResult{other}, Options{}); d[0].Fingerprint == a[0].Fingerprint {
+		t.Errorf("expected fingerprints to differ between files")
+	}
+}
+
+func TestToGitLab_EmptyIsArray(t *testing.T) {
+	data, err := ToGitLab([]*model.ValidationResult{{ValuesFile: "values.yaml"}}, Options{})
+	if err != nil {
+		t.Fatalf("ToGitLab error: %v", err)
+	}
+	if string(data) != "[]" {
+		t.Errorf("expected an empty JSON array, got %s", data)
+	}
+}
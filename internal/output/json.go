@@ -1,58 +1,194 @@
 package output
 
 import (
+	"encoding/json"
+
 	"github.com/chrishham/helm-values-checker/internal/model"
+	"gopkg.in/yaml.v3"
 )
 
-// JSONOutput is the structured JSON output format.
+// JSONOutput is the structured output format shared by the JSON and YAML encoders.
 type JSONOutput struct {
-	ValuesFile   string        `json:"valuesFile"`
-	ChartName    string        `json:"chartName"`
-	ChartVersion string        `json:"chartVersion"`
-	Errors       []JSONFinding `json:"errors"`
-	Warnings     []JSONFinding `json:"warnings"`
-	ErrorCount   int           `json:"errorCount"`
-	WarningCount int           `json:"warningCount"`
+	ValuesFile   string         `json:"valuesFile" yaml:"valuesFile"`
+	ChartName    string         `json:"chartName" yaml:"chartName"`
+	ChartVersion string         `json:"chartVersion" yaml:"chartVersion"`
+	AppVersion   string         `json:"appVersion,omitempty" yaml:"appVersion,omitempty"`
+	Valid        bool           `json:"valid" yaml:"valid"`
+	Errors       []JSONFinding  `json:"errors" yaml:"errors"`
+	Warnings     []JSONFinding  `json:"warnings" yaml:"warnings"`
+	Infos        []JSONFinding  `json:"infos,omitempty" yaml:"infos,omitempty"`
+	ErrorCount   int            `json:"errorCount" yaml:"errorCount"`
+	WarningCount int            `json:"warningCount" yaml:"warningCount"`
+	InfoCount    int            `json:"infoCount,omitempty" yaml:"infoCount,omitempty"`
+	ByRule       map[string]int `json:"byRule" yaml:"byRule"`
+	CheckedKeys  int            `json:"checkedKeys" yaml:"checkedKeys"`
+	KnownKeys    int            `json:"knownKeys" yaml:"knownKeys"`
+	Truncated    bool           `json:"truncated,omitempty" yaml:"truncated,omitempty"`
+	Coverage     *JSONCoverage  `json:"coverage,omitempty" yaml:"coverage,omitempty"`
+}
+
+// JSONCoverage reports which defaulted keys a values file sets, with --coverage.
+type JSONCoverage struct {
+	DefaultedKeys int      `json:"defaultedKeys" yaml:"defaultedKeys"`
+	SetKeys       int      `json:"setKeys" yaml:"setKeys"`
+	Unset         []string `json:"unset" yaml:"unset"`
+}
+
+// MultiOutput is the structured output for a run covering several values
+// files: whether all of them are valid, the per-file results, and
+// aggregate totals.
+type MultiOutput struct {
+	Valid   bool         `json:"valid" yaml:"valid"`
+	Results []JSONOutput `json:"results" yaml:"results"`
+	Summary Summary      `json:"summary" yaml:"summary"`
+}
+
+// Summary holds finding totals across all validated files.
+type Summary struct {
+	Files        int `json:"files" yaml:"files"`
+	ErrorCount   int `json:"errorCount" yaml:"errorCount"`
+	WarningCount int `json:"warningCount" yaml:"warningCount"`
+	InfoCount    int `json:"infoCount,omitempty" yaml:"infoCount,omitempty"`
 }
 
 // JSONFinding is a single finding in JSON format.
 type JSONFinding struct {
-	Line       int    `json:"line"`
-	KeyPath    string `json:"keyPath"`
-	Message    string `json:"message"`
-	Suggestion string `json:"suggestion,omitempty"`
+	Rule           string   `json:"rule" yaml:"rule"`
+	Line           int      `json:"line" yaml:"line"`
+	Column         int      `json:"column,omitempty" yaml:"column,omitempty"`
+	KeyPath        string   `json:"keyPath" yaml:"keyPath"`
+	Message        string   `json:"message" yaml:"message"`
+	Suggestion     string   `json:"suggestion,omitempty" yaml:"suggestion,omitempty"`
+	Suggestions    []string `json:"suggestions,omitempty" yaml:"suggestions,omitempty"`
+	SuggestionLine int      `json:"suggestionLine,omitempty" yaml:"suggestionLine,omitempty"`
+	Confidence     *float64 `json:"confidence,omitempty" yaml:"confidence,omitempty"`
+	SourceLine     string   `json:"sourceLine,omitempty" yaml:"sourceLine,omitempty"`
+	DocURL         string   `json:"docURL,omitempty" yaml:"docURL,omitempty"`
+	File           string   `json:"file,omitempty" yaml:"file,omitempty"`
+	KnownTo        []string `json:"knownTo,omitempty" yaml:"knownTo,omitempty"`
+	LimitedSearch  bool     `json:"suggestionsLimited,omitempty" yaml:"suggestionsLimited,omitempty"`
+}
+
+// ToJSON encodes a ValidationResult as indented JSON.
+func ToJSON(result *model.ValidationResult, opts Options) ([]byte, error) {
+	return json.MarshalIndent(buildOutput(result, opts), "", "  ")
+}
+
+// ToYAML encodes a ValidationResult as YAML using the same structure as ToJSON.
+func ToYAML(result *model.ValidationResult, opts Options) ([]byte, error) {
+	return yaml.Marshal(buildOutput(result, opts))
+}
+
+// ToJSONAll encodes several ValidationResults as one indented JSON object
+// with a "results" array and an aggregate "summary".
+func ToJSONAll(results []*model.ValidationResult, opts Options) ([]byte, error) {
+	return json.MarshalIndent(buildMultiOutput(results, opts), "", "  ")
+}
+
+// ToYAMLAll encodes several ValidationResults using the same structure as ToJSONAll.
+func ToYAMLAll(results []*model.ValidationResult, opts Options) ([]byte, error) {
+	return yaml.Marshal(buildMultiOutput(results, opts))
+}
+
+func buildMultiOutput(results []*model.ValidationResult, opts Options) MultiOutput {
+	out := MultiOutput{Valid: true, Results: make([]JSONOutput, 0, len(results))}
+	for _, result := range results {
+		r := buildOutput(result, opts)
+		out.Valid = out.Valid && r.Valid
+		out.Results = append(out.Results, r)
+	}
+	out.Summary = summarize(results, opts)
+	return out
+}
+
+// summarize totals findings across results. Info findings are only counted
+// when they are shown.
+func summarize(results []*model.ValidationResult, opts Options) Summary {
+	s := Summary{Files: len(results)}
+	for _, result := range results {
+		s.ErrorCount += len(result.Errors())
+		s.WarningCount += len(result.Warnings())
+		if opts.ShowInfo {
+			s.InfoCount += len(result.Infos())
+		}
+	}
+	return s
 }
 
-// ToJSON converts a ValidationResult to the JSON output structure.
-func ToJSON(result *model.ValidationResult) JSONOutput {
+// buildOutput converts a ValidationResult to the structured output format.
+// valid is true when the result has no errors (nor, with opts.Strict, any
+// warnings). valid, errorCount, warningCount, and byRule always reflect
+// the full result, even when opts.MaxFindings truncates the listed
+// findings.
+func buildOutput(result *model.ValidationResult, opts Options) JSONOutput {
+	result = sortedResult(result, opts)
 	out := JSONOutput{
 		ValuesFile:   result.ValuesFile,
 		ChartName:    result.ChartName,
 		ChartVersion: result.ChartVersion,
+		AppVersion:   result.AppVersion,
+		Valid:        !result.HasErrors() && !(opts.Strict && result.HasWarnings()),
 		Errors:       make([]JSONFinding, 0),
 		Warnings:     make([]JSONFinding, 0),
 	}
 
-	for _, f := range result.Errors() {
-		out.Errors = append(out.Errors, JSONFinding{
-			Line:       f.Line,
-			KeyPath:    f.KeyPath,
-			Message:    f.Message,
-			Suggestion: f.Suggestion,
-		})
+	errors, warnings, truncated := limitFindings(result.Errors(), result.Warnings(), opts.MaxFindings)
+
+	for _, f := range errors {
+		out.Errors = append(out.Errors, toJSONFinding(f))
+	}
+
+	for _, f := range warnings {
+		out.Warnings = append(out.Warnings, toJSONFinding(f))
 	}
 
-	for _, f := range result.Warnings() {
-		out.Warnings = append(out.Warnings, JSONFinding{
-			Line:       f.Line,
-			KeyPath:    f.KeyPath,
-			Message:    f.Message,
-			Suggestion: f.Suggestion,
-		})
+	if opts.ShowInfo {
+		for _, f := range result.Infos() {
+			out.Infos = append(out.Infos, toJSONFinding(f))
+		}
+		out.InfoCount = len(out.Infos)
 	}
 
-	out.ErrorCount = len(out.Errors)
-	out.WarningCount = len(out.Warnings)
+	out.ErrorCount = len(result.Errors())
+	out.WarningCount = len(result.Warnings())
+	out.ByRule = countByRule(append(result.Errors(), result.Warnings()...))
+	out.CheckedKeys, out.KnownKeys = result.CheckedKeys, result.KnownKeys
+	out.Truncated = truncated
+	if c := result.Coverage; c != nil {
+		out.Coverage = &JSONCoverage{DefaultedKeys: c.DefaultedKeys, SetKeys: c.SetKeys, Unset: c.Unset}
+	}
 
 	return out
 }
+
+// countByRule returns how many findings each rule produced.
+func countByRule(findings []model.Finding) map[string]int {
+	counts := make(map[string]int)
+	for _, f := range findings {
+		counts[f.Rule]++
+	}
+	return counts
+}
+
+func toJSONFinding(f model.Finding) JSONFinding {
+	out := JSONFinding{
+		Rule:           f.Rule,
+		Line:           f.Line,
+		Column:         f.Column,
+		KeyPath:        f.KeyPath,
+		Message:        f.Message,
+		Suggestion:     f.Suggestion,
+		Suggestions:    f.Suggestions,
+		SuggestionLine: f.SuggestionLine,
+		SourceLine:     f.SourceLine,
+		DocURL:         f.DocURL,
+		File:           f.File,
+		KnownTo:        f.KnownTo,
+		LimitedSearch:  f.LimitedSearch,
+	}
+	if f.Suggestion != "" {
+		confidence := f.Confidence
+		out.Confidence = &confidence
+	}
+	return out
+}
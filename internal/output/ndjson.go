@@ -0,0 +1,44 @@
+package output
+
+import (
+	"encoding/json"
+	"io"
+	"strings"
+
+	"github.com/chrishham/helm-values-checker/internal/model"
+)
+
+// NDJSONFinding is one line of newline-delimited JSON output: a finding
+// together with the file it was found in.
+type NDJSONFinding struct {
+	ValuesFile string `json:"valuesFile"`
+	Severity   string `json:"severity"`
+	JSONFinding
+}
+
+// PrintNDJSON writes each finding of result to w as its own JSON object on
+// a single line, errors first, then warnings, then infos with ShowInfo.
+// Results are written once validation has finished, so each line stands
+// alone for log pipelines rather than arriving as findings are made; a
+// file without findings produces no output.
+func PrintNDJSON(result *model.ValidationResult, w io.Writer, opts Options) error {
+	errors, warnings, _ := limitFindings(result.Errors(), result.Warnings(), opts.MaxFindings)
+	findings := append(append([]model.Finding(nil), errors...), warnings...)
+	if opts.ShowInfo {
+		findings = append(findings, result.Infos()...)
+	}
+	sortFindings(findings, opts.Sort)
+
+	enc := json.NewEncoder(w)
+	for _, f := range findings {
+		line := NDJSONFinding{
+			ValuesFile:  result.ValuesFile,
+			Severity:    strings.ToLower(f.Severity.String()),
+			JSONFinding: toJSONFinding(f),
+		}
+		if err := enc.Encode(line); err != nil {
+			return err
+		}
+	}
+	return nil
+}
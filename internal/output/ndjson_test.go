@@ -0,0 +1,59 @@
+package output
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/chrishham/helm-values-checker/internal/model"
+)
+
+func TestPrintNDJSON(t *testing.T) {
+	var buf bytes.Buffer
+	if err := PrintNDJSON(tableResult(), &buf, Options{}); err != nil {
+		t.Fatalf("PrintNDJSON error: %v", err)
+	}
+
+	var lines []map[string]interface{}
+	scanner := bufio.NewScanner(&buf)
+	for scanner.Scan() {
+		var line map[string]interface{}
+		if err := json.Unmarshal(scanner.Bytes(), &line); err != nil {
+			t.Fatalf("invalid JSON line %q: %v", scanner.Text(), err)
+		}
+		lines = append(lines, line)
+	}
+	if len(lines) != 3 {
+		t.Fatalf("expected 3 lines, got %d", len(lines))
+	}
+
+	first := lines[0]
+	want := map[string]interface{}{
+		"valuesFile": "values.yaml",
+		"severity":   "error",
+		"rule":       model.RuleUnknownKey,
+		"line":       float64(5),
+		"keyPath":    "image.regsitry",
+		"message":    `Unknown key "image.regsitry"`,
+		"suggestion": "image.registry",
+	}
+	for key, value := range want {
+		if first[key] != value {
+			t.Errorf("field %s = %v, want %v", key, first[key], value)
+		}
+	}
+	if lines[2]["severity"] != "warning" || lines[2]["keyPath"] != "oldSetting" {
+		t.Errorf("expected the warning last, got %v", lines[2])
+	}
+}
+
+func TestPrintNDJSON_NoFindings(t *testing.T) {
+	var buf bytes.Buffer
+	if err := PrintNDJSON(&model.ValidationResult{ValuesFile: "values.yaml"}, &buf, Options{}); err != nil {
+		t.Fatalf("PrintNDJSON error: %v", err)
+	}
+	if buf.Len() != 0 {
+		t.Errorf("expected no output, got %q", buf.String())
+	}
+}
@@ -0,0 +1,65 @@
+package output
+
+import (
+	"cmp"
+	"slices"
+
+	"github.com/chrishham/helm-values-checker/internal/model"
+)
+
+// Sort orders accepted by Options.Sort.
+const (
+	SortLine     = "line"
+	SortSeverity = "severity"
+	SortKey      = "key"
+)
+
+// sortedResult returns result with its findings reordered by opts.Sort
+// (see sortFindings), for reports that list findings by severity section.
+// The findings are sorted on a copy, so result itself is left untouched.
+func sortedResult(result *model.ValidationResult, opts Options) *model.ValidationResult {
+	if opts.Sort == "" {
+		return result
+	}
+	sorted := *result
+	sorted.Findings = sortFindings(slices.Clone(result.Findings), opts.Sort)
+	return &sorted
+}
+
+// sortFindings sorts findings in place and returns them: "line" by file,
+// line, and column; "severity" by severity, then line; and "key" by key
+// path, then line. Findings without a line sort after those with one, and
+// ties keep their discovery order. Any other order leaves findings as is.
+func sortFindings(findings []model.Finding, order string) []model.Finding {
+	var compare func(a, b model.Finding) int
+	switch order {
+	case SortLine:
+		compare = func(a, b model.Finding) int {
+			return cmp.Or(cmp.Compare(a.File, b.File), compareLine(a, b))
+		}
+	case SortSeverity:
+		compare = func(a, b model.Finding) int {
+			return cmp.Or(cmp.Compare(a.Severity, b.Severity), compareLine(a, b))
+		}
+	case SortKey:
+		compare = func(a, b model.Finding) int {
+			return cmp.Or(cmp.Compare(a.KeyPath, b.KeyPath), compareLine(a, b))
+		}
+	default:
+		return findings
+	}
+	slices.SortStableFunc(findings, compare)
+	return findings
+}
+
+// compareLine orders findings by line, then column, placing findings
+// without a line (0) last.
+func compareLine(a, b model.Finding) int {
+	if (a.Line == 0) != (b.Line == 0) {
+		if a.Line == 0 {
+			return 1
+		}
+		return -1
+	}
+	return cmp.Or(cmp.Compare(a.Line, b.Line), cmp.Compare(a.Column, b.Column))
+}
@@ -0,0 +1,117 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/chrishham/helm-values-checker/internal/model"
+)
+
+func sortResult() *model.ValidationResult {
+	return &model.ValidationResult{ValuesFile: "values.yaml", ChartName: "test-chart", Findings: []model.Finding{
+		{Severity: model.SeverityError, Line: 9, KeyPath: "service.prot", Message: "a"},
+		{Severity: model.SeverityWarning, Line: 2, KeyPath: "image.tag", Message: "b"},
+		{Severity: model.SeverityError, Line: 0, KeyPath: "auth", Message: "c"},
+		{Severity: model.SeverityError, Line: 4, Column: 5, KeyPath: "image.repo", Message: "d"},
+		{Severity: model.SeverityWarning, Line: 4, Column: 3, KeyPath: "image.pull", Message: "e"},
+	}}
+}
+
+// ndjsonMessages renders result as NDJSON and returns the finding messages
+// in output order.
+func ndjsonMessages(t *testing.T, result *model.ValidationResult, opts Options) string {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := PrintNDJSON(result, &buf, opts); err != nil {
+		t.Fatalf("PrintNDJSON error: %v", err)
+	}
+	var messages []string
+	dec := json.NewDecoder(&buf)
+	for dec.More() {
+		var f NDJSONFinding
+		if err := dec.Decode(&f); err != nil {
+			t.Fatalf("decoding NDJSON: %v", err)
+		}
+		messages = append(messages, f.Message)
+	}
+	return strings.Join(messages, "")
+}
+
+func TestSort_Orders(t *testing.T) {
+	tests := []struct {
+		sort string
+		want string
+	}{
+		{"", "acdbe"},
+		{SortLine, "bedac"},
+		{SortSeverity, "dacbe"},
+		{SortKey, "cedba"},
+	}
+	for _, tt := range tests {
+		if got := ndjsonMessages(t, sortResult(), Options{Sort: tt.sort}); got != tt.want {
+			t.Errorf("--sort %q: expected order %s, got %s", tt.sort, tt.want, got)
+		}
+	}
+}
+
+func TestSort_WithinSeverity(t *testing.T) {
+	var buf bytes.Buffer
+	PrintText(sortResult(), &buf, Options{Sort: SortLine})
+	out := buf.String()
+	want := []string{"ERRORS (3)", "line 4:5", "line 9", "line 0", "WARNINGS (2)", "line 2", "line 4:3"}
+	pos := 0
+	for _, w := range want {
+		idx := strings.Index(out[pos:], w)
+		if idx < 0 {
+			t.Fatalf("expected %q after position %d in:\n%s", w, pos, out)
+		}
+		pos += idx + len(w)
+	}
+
+	data, err := ToJSON(sortResult(), Options{Sort: SortKey})
+	if err != nil {
+		t.Fatalf("ToJSON error: %v", err)
+	}
+	var parsed JSONOutput
+	if err := json.Unmarshal(data, &parsed); err != nil {
+		t.Fatal(err)
+	}
+	var keys []string
+	for _, f := range parsed.Errors {
+		keys = append(keys, f.KeyPath)
+	}
+	if want := []string{"auth", "image.repo", "service.prot"}; !reflect.DeepEqual(keys, want) {
+		t.Errorf("expected JSON errors sorted by key %v, got %v", want, keys)
+	}
+}
+
+func TestSort_Template(t *testing.T) {
+	tmpl, err := LoadTemplate("{{range .Findings}}{{.Message}}{{end}}", "")
+	if err != nil {
+		t.Fatalf("LoadTemplate error: %v", err)
+	}
+	var buf bytes.Buffer
+	if err := PrintTemplate(tmpl, []*model.ValidationResult{sortResult()}, &buf, Options{Sort: SortKey}); err != nil {
+		t.Fatalf("PrintTemplate error: %v", err)
+	}
+	if got := buf.String(); got != "cedba" {
+		t.Errorf("expected the template to see findings sorted by key, got %s", got)
+	}
+}
+
+func TestSort_LeavesResultUnchanged(t *testing.T) {
+	result := sortResult()
+	want := append([]model.Finding(nil), result.Findings...)
+	var buf bytes.Buffer
+	PrintText(result, &buf, Options{Sort: SortSeverity})
+	PrintTable(result, &buf, Options{Sort: SortLine})
+	if _, err := ToJSON(result, Options{Sort: SortKey}); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(result.Findings, want) {
+		t.Errorf("expected the result's findings to keep discovery order, got %+v", result.Findings)
+	}
+}
@@ -0,0 +1,151 @@
+package output
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"unicode/utf8"
+
+	"github.com/chrishham/helm-values-checker/internal/model"
+	"github.com/fatih/color"
+)
+
+// tableRow is one finding rendered as table cells.
+type tableRow struct {
+	severity model.Severity
+	cells    []string
+}
+
+// PrintTable writes a validation report to w as a table with aligned
+// LINE, SEVERITY, KEY, and MESSAGE columns (plus RULE with ShowRules, and
+// FILE when findings name the overlay that set their key).
+// When opts.Width is set, rows longer than it are cut with an ellipsis.
+func PrintTable(result *model.ValidationResult, w io.Writer, opts Options) {
+	printHeader(w, result)
+	fmt.Fprintln(w)
+
+	errors := result.Errors()
+	warnings := result.Warnings()
+	if len(errors) == 0 && len(warnings) == 0 {
+		color.New(color.FgGreen, color.Bold).Fprintf(w, "No issues found%s.\n", checkedKeys(result))
+		return
+	}
+
+	shownErrors, shownWarnings, _ := limitFindings(errors, warnings, opts.MaxFindings)
+	findings := append(append([]model.Finding(nil), shownErrors...), shownWarnings...)
+	if opts.ShowInfo {
+		findings = append(findings, result.Infos()...)
+	}
+	sortFindings(findings, opts.Sort)
+
+	// Merged overlays name the file that set each key
+	showFiles := false
+	for _, f := range findings {
+		showFiles = showFiles || f.File != ""
+	}
+
+	titles := []string{"LINE", "SEVERITY"}
+	if showFiles {
+		titles = []string{"FILE", "LINE", "SEVERITY"}
+	}
+	if opts.ShowRules {
+		titles = append(titles, "RULE")
+	}
+	titles = append(titles, "KEY", "MESSAGE")
+	rows := make([]tableRow, 0, len(findings))
+	for _, f := range findings {
+		row := tableRow{severity: f.Severity}
+		if showFiles {
+			row.cells = append(row.cells, sanitize(f.File))
+		}
+		row.cells = append(row.cells, tableLine(f), f.Severity.String())
+		if opts.ShowRules {
+			row.cells = append(row.cells, sanitize(f.Rule))
+		}
+		row.cells = append(row.cells, sanitize(f.KeyPath), tableMessage(f))
+		rows = append(rows, row)
+	}
+
+	printTableRows(w, titles, rows, opts.Width)
+	printOmitted(w, len(errors)+len(warnings)-len(shownErrors)-len(shownWarnings))
+	fmt.Fprintln(w)
+
+	color.New(color.Bold).Fprintf(w, "Summary: %d error(s), %d warning(s)%s\n", len(errors), len(warnings), checkedKeys(result))
+}
+
+// tableLine renders a finding's line for the LINE column, or "-" for
+// findings without one, such as notes about the file as a whole.
+func tableLine(f model.Finding) string {
+	if f.Line == 0 {
+		return "-"
+	}
+	return strconv.Itoa(f.Line)
+}
+
+// tableMessage renders a finding's message on a single line, with its
+// "did you mean?" hint.
+func tableMessage(f model.Finding) string {
+	msg := sanitize(f.DisplayMessage())
+	if f.Suggestion != "" {
+		msg += fmt.Sprintf(" (did you mean %s?)", suggestionTarget(f))
+	}
+	return strings.NewReplacer("\n", " ", "\t", " ").Replace(msg)
+}
+
+// printTableRows aligns the cells with a tabwriter, then colors each row's
+// severity cell. Coloring afterwards keeps escape sequences from skewing
+// the column widths.
+func printTableRows(w io.Writer, titles []string, rows []tableRow, width int) {
+	var buf bytes.Buffer
+	tw := tabwriter.NewWriter(&buf, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, strings.Join(titles, "\t"))
+	for _, row := range rows {
+		fmt.Fprintln(tw, strings.Join(row.cells, "\t"))
+	}
+	tw.Flush()
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	sevCol := strings.Index(lines[0], "SEVERITY")
+	for i, line := range lines {
+		line = truncateLine(line, width)
+		if i == 0 {
+			color.New(color.Bold).Fprintln(w, line)
+			continue
+		}
+
+		row := rows[i-1]
+		sev := row.severity.String()
+		if len(line) < sevCol+len(sev) || !strings.HasPrefix(line[sevCol:], sev) {
+			fmt.Fprintln(w, line)
+			continue
+		}
+		fmt.Fprint(w, line[:sevCol])
+		severityColor(row.severity).Fprint(w, sev)
+		fmt.Fprintln(w, line[sevCol+len(sev):])
+	}
+}
+
+// truncateLine cuts line to width runes, ending it with an ellipsis.
+// A width of 0 or less leaves the line unchanged.
+func truncateLine(line string, width int) string {
+	if width <= 0 || utf8.RuneCountInString(line) <= width {
+		return line
+	}
+	runes := []rune(line)
+	return string(runes[:width-1]) + "…"
+}
+
+// severityColor returns the color used for a severity in text output.
+func severityColor(s model.Severity) *color.Color {
+	switch s {
+	case model.SeverityError:
+		return color.New(color.FgRed)
+	case model.SeverityWarning:
+		return color.New(color.FgYellow)
+	default:
+		return color.New(color.FgCyan)
+	}
+}
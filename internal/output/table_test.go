@@ -0,0 +1,139 @@
+package output
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/chrishham/helm-values-checker/internal/model"
+)
+
+func tableResult() *model.ValidationResult {
+	return &model.ValidationResult{
+		ValuesFile: "values.yaml",
+		ChartName:  "test-chart",
+		Findings: []model.Finding{
+			{Severity: model.SeverityError, Rule: model.RuleUnknownKey, Line: 5, KeyPath: "image.regsitry", Message: `Unknown key "image.regsitry"`, Suggestion: "image.registry"},
+			{Severity: model.SeverityError, Rule: model.RuleTypeMismatch, Line: 112, KeyPath: "replicaCount", Message: `Type mismatch at "replicaCount": expected int, got string ("three")`},
+			{Severity: model.SeverityWarning, Rule: model.RuleSchemaDeprecated, Line: 9, KeyPath: "oldSetting", Message: `Deprecated key "oldSetting"`},
+		},
+	}
+}
+
+// tableLines returns the header and body rows of a rendered table.
+func tableLines(t *testing.T, out string) []string {
+	t.Helper()
+	var lines []string
+	for _, line := range strings.Split(out, "\n") {
+		if strings.HasPrefix(line, "LINE") || (len(lines) > 0 && line != "") {
+			lines = append(lines, line)
+		} else if len(lines) > 0 {
+			break
+		}
+	}
+	if len(lines) == 0 {
+		t.Fatalf("no table found in output:\n%s", out)
+	}
+	return lines
+}
+
+func TestPrintTable_ColumnsAligned(t *testing.T) {
+	var buf bytes.Buffer
+	PrintTable(tableResult(), &buf, Options{})
+	lines := tableLines(t, buf.String())
+	if len(lines) != 4 {
+		t.Fatalf("expected header and 3 rows, got %d:\n%s", len(lines), buf.String())
+	}
+
+	header := lines[0]
+	for _, col := range []string{"SEVERITY", "KEY", "MESSAGE"} {
+		idx := strings.Index(header, col)
+		if idx < 0 {
+			t.Fatalf("header %q is missing column %s", header, col)
+		}
+		for _, row := range lines[1:] {
+			if idx >= len(row) || row[idx-1] != ' ' || row[idx] == ' ' {
+				t.Errorf("column %s does not start at offset %d in row %q", col, idx, row)
+			}
+		}
+	}
+
+	if !strings.Contains(lines[1], `(did you mean "image.registry"?)`) {
+		t.Errorf("expected suggestion in message column, got %q", lines[1])
+	}
+	if !strings.Contains(buf.String(), "Summary: 2 error(s), 1 warning(s)") {
+		t.Errorf("expected summary line, got:\n%s", buf.String())
+	}
+}
+
+func TestPrintTable_ShowRules(t *testing.T) {
+	var buf bytes.Buffer
+	PrintTable(tableResult(), &buf, Options{ShowRules: true})
+	lines := tableLines(t, buf.String())
+
+	idx := strings.Index(lines[0], "RULE")
+	if idx < 0 || !strings.HasPrefix(lines[2][idx:], model.RuleTypeMismatch) {
+		t.Errorf("expected RULE column holding the rule ID, got:\n%s", buf.String())
+	}
+}
+
+func TestPrintTable_TruncatesToWidth(t *testing.T) {
+	var buf bytes.Buffer
+	PrintTable(tableResult(), &buf, Options{Width: 50})
+	for _, line := range tableLines(t, buf.String()) {
+		if n := utf8.RuneCountInString(line); n > 50 {
+			t.Errorf("line exceeds width 50 (%d): %q", n, line)
+		}
+	}
+	if !strings.Contains(buf.String(), "…") {
+		t.Errorf("expected an ellipsis on truncated rows, got:\n%s", buf.String())
+	}
+}
+
+func TestPrintTable_NoIssues(t *testing.T) {
+	var buf bytes.Buffer
+	PrintTable(&model.ValidationResult{ValuesFile: "values.yaml", ChartName: "test-chart"}, &buf, Options{})
+	if !strings.Contains(buf.String(), "No issues found") || strings.Contains(buf.String(), "LINE") {
+		t.Errorf("expected no table for a clean file, got:\n%s", buf.String())
+	}
+}
+
+func TestPrintTable_FileColumn(t *testing.T) {
+	result := tableResult()
+	result.ValuesFile = "base.yaml + patch.yaml"
+	result.Findings[0].File = "patch.yaml"
+	result.Findings[1].File = "base.yaml"
+
+	var buf bytes.Buffer
+	PrintTable(result, &buf, Options{})
+	var lines []string
+	for _, line := range strings.Split(buf.String(), "\n") {
+		if strings.HasPrefix(line, "FILE") || (len(lines) > 0 && line != "") {
+			lines = append(lines, line)
+		} else if len(lines) > 0 {
+			break
+		}
+	}
+	if len(lines) != 4 || !strings.HasPrefix(lines[0], "FILE") || !strings.HasPrefix(lines[1], "patch.yaml") || !strings.HasPrefix(lines[2], "base.yaml") {
+		t.Errorf("expected a FILE column naming the overlay of each finding, got:\n%s", buf.String())
+	}
+}
+
+func TestPrintTable_NoLine(t *testing.T) {
+	result := tableResult()
+	result.Findings = append(result.Findings, model.Finding{Severity: model.SeverityInfo, Rule: model.RuleReleaseSubchart, Message: "note"})
+	result.Findings[1].Line = 0
+
+	var buf bytes.Buffer
+	PrintTable(result, &buf, Options{ShowInfo: true})
+	lines := tableLines(t, buf.String())
+	if len(lines) != 5 {
+		t.Fatalf("expected header and 4 rows, got %d:\n%s", len(lines), buf.String())
+	}
+	for _, i := range []int{2, 4} {
+		if !strings.HasPrefix(lines[i], "-") {
+			t.Errorf("expected a finding without a line to show -, got %q", lines[i])
+		}
+	}
+}
@@ -0,0 +1,120 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/chrishham/helm-values-checker/internal/model"
+	"gopkg.in/yaml.v3"
+)
+
+// tapDiagnostic is the YAML diagnostic block attached to a TAP test line.
+type tapDiagnostic struct {
+	Message    string `yaml:"message"`
+	Severity   string `yaml:"severity"`
+	Rule       string `yaml:"rule,omitempty"`
+	File       string `yaml:"file"`
+	Line       int    `yaml:"line"`
+	KeyPath    string `yaml:"keyPath,omitempty"`
+	Suggestion string `yaml:"suggestion,omitempty"`
+}
+
+// tapTest is a single TAP test line with its optional diagnostic.
+type tapTest struct {
+	ok          bool
+	description string
+	directive   string
+	diag        *tapDiagnostic
+}
+
+// PrintTAP writes the results of all validated files as a single Test
+// Anything Protocol (version 13) stream. Each error is a failing test and
+// each warning a failing TODO test, so warnings are reported without failing
+// the harness; files without findings produce one passing test. The plan
+// line needs the total count, so all results must be collected first.
+func PrintTAP(results []*model.ValidationResult, w io.Writer, opts Options) {
+	var tests []tapTest
+	for _, result := range results {
+		tests = append(tests, tapTests(result, opts)...)
+	}
+
+	fmt.Fprintln(w, "TAP version 13")
+	fmt.Fprintf(w, "1..%d\n", len(tests))
+	for i, t := range tests {
+		status := "ok"
+		if !t.ok {
+			status = "not ok"
+		}
+		fmt.Fprintf(w, "%s %d - %s", status, i+1, t.description)
+		if t.directive != "" {
+			fmt.Fprintf(w, " # %s", t.directive)
+		}
+		fmt.Fprintln(w)
+		if t.diag != nil {
+			printTAPDiagnostic(w, t.diag)
+		}
+	}
+}
+
+func tapTests(result *model.ValidationResult, opts Options) []tapTest {
+	result = sortedResult(result, opts)
+	file := sanitize(result.ValuesFile)
+	errors, warnings, truncated := limitFindings(result.Errors(), result.Warnings(), opts.MaxFindings)
+
+	var tests []tapTest
+	for _, f := range errors {
+		tests = append(tests, tapTest{description: tapDescription(sanitize(findingFile(result, f)), f), diag: newTAPDiagnostic(result, f)})
+	}
+	for _, f := range warnings {
+		tests = append(tests, tapTest{description: tapDescription(sanitize(findingFile(result, f)), f), directive: "TODO warning", diag: newTAPDiagnostic(result, f)})
+	}
+	if opts.ShowInfo {
+		for _, f := range result.Infos() {
+			tests = append(tests, tapTest{ok: true, description: tapDescription(sanitize(findingFile(result, f)), f), directive: "SKIP info", diag: newTAPDiagnostic(result, f)})
+		}
+	}
+	if truncated {
+		omitted := len(result.Errors()) + len(result.Warnings()) - len(errors) - len(warnings)
+		tests = append(tests, tapTest{description: fmt.Sprintf("%s: %d more finding(s) not listed", file, omitted)})
+	}
+
+	if len(tests) == 0 {
+		tests = append(tests, tapTest{ok: true, description: file})
+	}
+	return tests
+}
+
+// tapDescription formats a finding as a one-line TAP test description.
+// "#" would start a directive, so it is escaped.
+func tapDescription(file string, f model.Finding) string {
+	desc := fmt.Sprintf("%s:%d %s", file, f.Line, sanitize(f.DisplayMessage()))
+	desc = strings.ReplaceAll(desc, "\n", " ")
+	return strings.ReplaceAll(desc, "#", `\#`)
+}
+
+func newTAPDiagnostic(result *model.ValidationResult, f model.Finding) *tapDiagnostic {
+	return &tapDiagnostic{
+		Message:    sanitize(f.DisplayMessage()),
+		Severity:   strings.ToLower(f.Severity.String()),
+		Rule:       f.Rule,
+		File:       sanitize(findingFile(result, f)),
+		Line:       f.Line,
+		KeyPath:    sanitize(f.KeyPath),
+		Suggestion: sanitize(f.Suggestion),
+	}
+}
+
+// printTAPDiagnostic writes a diagnostic as an indented YAML block
+// delimited by "---" and "...".
+func printTAPDiagnostic(w io.Writer, diag *tapDiagnostic) {
+	data, err := yaml.Marshal(diag)
+	if err != nil {
+		return
+	}
+	fmt.Fprintln(w, "  ---")
+	for _, line := range strings.Split(strings.TrimRight(string(data), "\n"), "\n") {
+		fmt.Fprintf(w, "  %s\n", line)
+	}
+	fmt.Fprintln(w, "  ...")
+}
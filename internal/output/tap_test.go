@@ -0,0 +1,60 @@
+package output
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/chrishham/helm-values-checker/internal/model"
+)
+
+func TestPrintTAP(t *testing.T) {
+	results := []*model.ValidationResult{
+		{
+			ValuesFile: "bad.yaml",
+			ChartName:  "test-chart",
+			Findings: []model.Finding{
+				{Severity: model.SeverityError, Rule: model.RuleUnknownKey, Line: 4, KeyPath: "image.regsitry", Message: `Unknown key "image.regsitry"`, Suggestion: "image.registry"},
+				{Severity: model.SeverityWarning, Rule: model.RuleSchemaDeprecated, Line: 8, KeyPath: "old", Message: `Deprecated key "old" - see #123`},
+			},
+		},
+		{ValuesFile: "good.yaml", ChartName: "test-chart"},
+	}
+
+	var buf bytes.Buffer
+	PrintTAP(results, &buf, Options{})
+	out := buf.String()
+
+	for _, want := range []string{
+		"TAP version 13\n1..3\n",
+		`not ok 1 - bad.yaml:4 Unknown key "image.regsitry"` + "\n  ---\n",
+		"  line: 4\n",
+		"  suggestion: image.registry\n  ...\n",
+		`not ok 2 - bad.yaml:8 Deprecated key "old" - see \#123 # TODO warning`,
+		"ok 3 - good.yaml\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in TAP output, got:\n%s", want, out)
+		}
+	}
+}
+
+func TestPrintTAP_MaxFindings(t *testing.T) {
+	results := []*model.ValidationResult{
+		{
+			ValuesFile: "values.yaml",
+			Findings: []model.Finding{
+				{Severity: model.SeverityError, Line: 1, Message: "first"},
+				{Severity: model.SeverityError, Line: 2, Message: "second"},
+				{Severity: model.SeverityError, Line: 3, Message: "third"},
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	PrintTAP(results, &buf, Options{MaxFindings: 1})
+	out := buf.String()
+	if !strings.Contains(out, "1..2\n") || !strings.Contains(out, "not ok 2 - values.yaml: 2 more finding(s) not listed") {
+		t.Errorf("expected truncation test line, got:\n%s", out)
+	}
+}
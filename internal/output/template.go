@@ -0,0 +1,53 @@
+package output
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"text/template"
+
+	"github.com/chrishham/helm-values-checker/internal/model"
+)
+
+// LoadTemplate parses a report template given either inline (text) or as a
+// file path. Exactly one of the two must be set.
+func LoadTemplate(text, file string) (*template.Template, error) {
+	switch {
+	case text != "" && file != "":
+		return nil, errors.New("--template and --template-file are mutually exclusive")
+	case file != "":
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("reading template: %w", err)
+		}
+		text = string(data)
+	case text == "":
+		return nil, errors.New("--output template requires --template or --template-file")
+	}
+
+	tmpl, err := template.New("report").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("parsing template: %w", err)
+	}
+	return tmpl, nil
+}
+
+// PrintTemplate executes tmpl once per result, with the *ValidationResult
+// as its data: fields such as .ValuesFile, .ChartName, .ChartVersion,
+// .AppVersion, and .Findings, and methods such as .Errors and .Warnings (use
+// len for counts). Findings are in opts.Sort order; the other options do
+// not apply, as the template decides what to show.
+// Output is only written once every result has rendered, so a failing
+// template leaves no partial report behind.
+func PrintTemplate(tmpl *template.Template, results []*model.ValidationResult, w io.Writer, opts Options) error {
+	var buf bytes.Buffer
+	for _, result := range results {
+		if err := tmpl.Execute(&buf, sortedResult(result, opts)); err != nil {
+			return fmt.Errorf("executing template: %w", err)
+		}
+	}
+	_, err := buf.WriteTo(w)
+	return err
+}
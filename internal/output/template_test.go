@@ -0,0 +1,81 @@
+package output
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/chrishham/helm-values-checker/internal/model"
+)
+
+func TestPrintTemplate(t *testing.T) {
+	tmpl, err := LoadTemplate(`{{.ValuesFile}} vs {{.ChartName}}: {{len .Errors}} error(s), {{len .Warnings}} warning(s)
+{{range .Findings}}{{.Severity}} {{.Rule}} {{.KeyPath}}:{{.Line}}
+{{end}}`, "")
+	if err != nil {
+		t.Fatalf("LoadTemplate error: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := PrintTemplate(tmpl, []*model.ValidationResult{tableResult()}, &buf, Options{}); err != nil {
+		t.Fatalf("PrintTemplate error: %v", err)
+	}
+	want := `values.yaml vs test-chart: 2 error(s), 1 warning(s)
+ERROR unknown-key image.regsitry:5
+ERROR type-mismatch replicaCount:112
+WARNING schema-deprecated oldSetting:9
+`
+	if buf.String() != want {
+		t.Errorf("unexpected output:\n%s\nwant:\n%s", buf.String(), want)
+	}
+}
+
+func TestLoadTemplate_FromFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "report.tmpl")
+	if err := os.WriteFile(path, []byte("{{.ValuesFile}}\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	tmpl, err := LoadTemplate("", path)
+	if err != nil {
+		t.Fatalf("LoadTemplate error: %v", err)
+	}
+
+	var buf bytes.Buffer
+	results := []*model.ValidationResult{{ValuesFile: "a.yaml"}, {ValuesFile: "b.yaml"}}
+	if err := PrintTemplate(tmpl, results, &buf, Options{}); err != nil {
+		t.Fatalf("PrintTemplate error: %v", err)
+	}
+	if buf.String() != "a.yaml\nb.yaml\n" {
+		t.Errorf("expected one rendering per result, got %q", buf.String())
+	}
+}
+
+func TestLoadTemplate_Errors(t *testing.T) {
+	if _, err := LoadTemplate("", ""); err == nil {
+		t.Error("expected error when no template is given")
+	}
+	if _, err := LoadTemplate("{{.ValuesFile}}", "report.tmpl"); err == nil {
+		t.Error("expected error when both template sources are given")
+	}
+	if _, err := LoadTemplate("{{.ValuesFile", ""); err == nil || !strings.Contains(err.Error(), "parsing template") {
+		t.Errorf("expected a parse error, got %v", err)
+	}
+}
+
+func TestPrintTemplate_ExecutionError(t *testing.T) {
+	tmpl, err := LoadTemplate("before {{.NoSuchField}}", "")
+	if err != nil {
+		t.Fatalf("LoadTemplate error: %v", err)
+	}
+
+	var buf bytes.Buffer
+	err = PrintTemplate(tmpl, []*model.ValidationResult{tableResult()}, &buf, Options{})
+	if err == nil || !strings.Contains(err.Error(), "executing template") {
+		t.Errorf("expected an execution error, got %v", err)
+	}
+	if buf.Len() != 0 {
+		t.Errorf("expected no partial output, got %q", buf.String())
+	}
+}
@@ -0,0 +1,76 @@
+package validator
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/chrishham/helm-values-checker/internal/model"
+	"gopkg.in/yaml.v3"
+)
+
+// detectDisallowedKeys reports user keys that match none of the allowKeys
+// patterns, whether or not the chart knows them. A key matching a pattern
+// allows its whole subtree; other mappings are descended into, so a pattern
+// like "image.tag" permits setting image.tag without permitting image.pullPolicy.
+// Sequences and scalars are checked as a whole.
+func detectDisallowedKeys(userNode *yaml.Node, allowKeys, ignoreKeys []string, path string) []model.Finding {
+	var findings []model.Finding
+
+	if userNode == nil {
+		return findings
+	}
+	userNode = resolveAlias(userNode)
+	if userNode.Kind != yaml.MappingNode {
+		return findings
+	}
+
+	for i := 0; i+1 < len(userNode.Content); i += 2 {
+		childPath := joinPath(path, userNode.Content[i].Value)
+		if matchesIgnore(childPath, ignoreKeys) || matchesIgnore(childPath, allowKeys) {
+			continue
+		}
+
+		valNode := resolveAlias(userNode.Content[i+1])
+		if valNode.Kind == yaml.MappingNode && len(valNode.Content) > 0 {
+			findings = append(findings, detectDisallowedKeys(valNode, allowKeys, ignoreKeys, childPath)...)
+			continue
+		}
+
+		findings = append(findings, model.Finding{
+			Severity: model.SeverityError,
+			Rule:     model.RuleDisallowedKey,
+			Line:     userNode.Content[i].Line,
+			Column:   userNode.Content[i].Column,
+			KeyPath:  childPath,
+			Message:  fmt.Sprintf("Key %q is not in the allowed keys (--allow-keys)", childPath),
+		})
+	}
+
+	return findings
+}
+
+// withoutUnknownKeys drops the disallowed-key findings that an unknown-key
+// finding already reports, at the same key or an ancestor of it, so a key
+// the chart does not know is flagged once.
+func withoutUnknownKeys(disallowed, findings []model.Finding) []model.Finding {
+	var unknown []string
+	for _, f := range findings {
+		if f.Rule == model.RuleUnknownKey {
+			unknown = append(unknown, f.KeyPath)
+		}
+	}
+	kept := disallowed[:0]
+	for _, f := range disallowed {
+		covered := false
+		for _, path := range unknown {
+			if f.KeyPath == path || strings.HasPrefix(f.KeyPath, path+".") || strings.HasPrefix(f.KeyPath, path+"[") {
+				covered = true
+				break
+			}
+		}
+		if !covered {
+			kept = append(kept, f)
+		}
+	}
+	return kept
+}
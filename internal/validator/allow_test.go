@@ -0,0 +1,76 @@
+package validator
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/chrishham/helm-values-checker/internal/chart"
+	"github.com/chrishham/helm-values-checker/internal/model"
+)
+
+func TestDetectDisallowedKeys(t *testing.T) {
+	user := parseYAML(t, `
+image:
+  tag: "1.2"
+  pullPolicy: Always
+resources:
+  limits:
+    cpu: 500m
+replicaCount: 3
+`)
+
+	findings := detectDisallowedKeys(user, []string{"image.tag", "resources"}, nil, "")
+	if len(findings) != 2 {
+		t.Fatalf("expected 2 findings, got %d: %+v", len(findings), findings)
+	}
+	if findings[0].KeyPath != "image.pullPolicy" || findings[0].Rule != model.RuleDisallowedKey || findings[0].Line != 4 {
+		t.Errorf("expected image.pullPolicy at line 4, got %+v", findings[0])
+	}
+	if findings[1].KeyPath != "replicaCount" || findings[1].Severity != model.SeverityError {
+		t.Errorf("expected replicaCount error, got %+v", findings[1])
+	}
+
+	if findings := detectDisallowedKeys(user, []string{"image.*", "resources.**"}, []string{"replicaCount"}, ""); len(findings) != 0 {
+		t.Errorf("expected globs and ignored keys to pass, got %+v", findings)
+	}
+}
+
+func TestValidate_AllowKeys(t *testing.T) {
+	resolved, err := chart.Resolve(filepath.Join(testdataDir(), "test-chart"), "")
+	if err != nil {
+		t.Fatalf("failed to resolve chart: %v", err)
+	}
+	defer resolved.Cleanup()
+
+	result, err := ValidateBytesWithOptions("values.yaml", []byte("replicaCount: 2\nimage:\n  tag: latest\n"), resolved, Options{AllowKeys: []string{"image.tag"}})
+	if err != nil {
+		t.Fatalf("validation error: %v", err)
+	}
+	errs := result.Errors()
+	if len(errs) != 1 || errs[0].Rule != model.RuleDisallowedKey || errs[0].KeyPath != "replicaCount" {
+		t.Errorf("expected only the known but disallowed replicaCount to be flagged, got %+v", result.Findings)
+	}
+}
+
+func TestValidate_AllowKeysUnknownReportedOnce(t *testing.T) {
+	resolved, err := chart.Resolve(filepath.Join(testdataDir(), "test-chart"), "")
+	if err != nil {
+		t.Fatalf("failed to resolve chart: %v", err)
+	}
+	defer resolved.Cleanup()
+
+	data := []byte("replicaCount: 2\nimage:\n  tag: latest\nextra:\n  debug: true\n")
+	result, err := ValidateBytesWithOptions("values.yaml", data, resolved, Options{AllowKeys: []string{"image.tag"}})
+	if err != nil {
+		t.Fatalf("validation error: %v", err)
+	}
+	var rules []string
+	for _, f := range result.Errors() {
+		rules = append(rules, f.Rule+" "+f.KeyPath)
+	}
+	want := []string{"unknown-key extra", "disallowed-key replicaCount"}
+	if !reflect.DeepEqual(rules, want) {
+		t.Errorf("expected %v, got %v", want, rules)
+	}
+}
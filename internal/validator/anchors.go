@@ -0,0 +1,250 @@
+package validator
+
+import (
+	"fmt"
+
+	"github.com/chrishham/helm-values-checker/internal/model"
+	"gopkg.in/yaml.v3"
+)
+
+// checkAliases walks the user values tree and reports aliases that point to
+// no anchor (dangling) or that loop back on themselves, either through a
+// chain of aliases or by leading back to one of their own ancestors. The second
+// return value is true when a cycle was found; the other checks follow
+// aliases and must not run on such a tree.
+func checkAliases(node *yaml.Node, ignoreKeys []string) ([]model.Finding, bool) {
+	w := &aliasWalker{ignoreKeys: ignoreKeys, ancestors: make(map[*yaml.Node]bool)}
+	w.walk(node, "")
+	return w.findings, w.cyclic
+}
+
+type aliasWalker struct {
+	ignoreKeys []string
+	ancestors  map[*yaml.Node]bool
+	findings   []model.Finding
+	cyclic     bool
+}
+
+func (w *aliasWalker) walk(node *yaml.Node, path string) {
+	if node == nil {
+		return
+	}
+
+	if node.Kind == yaml.AliasNode {
+		w.checkAlias(node, path)
+		// Anchored nodes are walked where they are defined.
+		return
+	}
+
+	w.ancestors[node] = true
+	defer delete(w.ancestors, node)
+
+	switch node.Kind {
+	case yaml.DocumentNode:
+		for _, child := range node.Content {
+			w.walk(child, path)
+		}
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			w.walk(node.Content[i+1], joinPath(path, node.Content[i].Value))
+		}
+	case yaml.SequenceNode:
+		for idx, elem := range node.Content {
+			w.walk(elem, fmt.Sprintf("%s[%d]", path, idx))
+		}
+	}
+}
+
+// checkAlias follows an alias chain to its target and records a finding if
+// the chain ends at nothing or revisits a node.
+func (w *aliasWalker) checkAlias(node *yaml.Node, path string) {
+	label := path
+	if label == "" {
+		label = "(root)"
+	}
+
+	seen := make(map[*yaml.Node]bool)
+	target := node
+	for target != nil && target.Kind == yaml.AliasNode {
+		if seen[target] {
+			w.addCycle(node, path, label)
+			return
+		}
+		seen[target] = true
+		target = target.Alias
+	}
+
+	if target == nil {
+		w.add(node, path, fmt.Sprintf("Alias *%s at %q references an undefined anchor", node.Value, label))
+		return
+	}
+
+	// Following the target may lead back to this alias's ancestors, either
+	// directly or through other aliases.
+	stack := make(map[*yaml.Node]bool, len(w.ancestors))
+	for n := range w.ancestors {
+		stack[n] = true
+	}
+	if reachesStack(target, stack, make(map[*yaml.Node]bool)) {
+		w.addCycle(node, path, label)
+	}
+}
+
+// reachesStack reports whether a depth-first walk from node, following
+// aliases, reaches a node already on stack. done records nodes whose subtree
+// has been fully explored without reaching the stack.
+func reachesStack(node *yaml.Node, stack, done map[*yaml.Node]bool) bool {
+	if node == nil || done[node] {
+		return false
+	}
+	if stack[node] {
+		return true
+	}
+
+	stack[node] = true
+	children := node.Content
+	if node.Kind == yaml.AliasNode {
+		children = []*yaml.Node{node.Alias}
+	}
+	for _, child := range children {
+		if reachesStack(child, stack, done) {
+			return true
+		}
+	}
+	delete(stack, node)
+	done[node] = true
+	return false
+}
+
+// addCycle marks the tree as cyclic even when the path is ignored, since the
+// remaining checks would still loop on it.
+func (w *aliasWalker) addCycle(node *yaml.Node, path, label string) {
+	w.cyclic = true
+	w.add(node, path, fmt.Sprintf("Alias *%s at %q forms a cycle", node.Value, label))
+}
+
+func (w *aliasWalker) add(node *yaml.Node, path, message string) {
+	if matchesIgnore(path, w.ignoreKeys) {
+		return
+	}
+	w.findings = append(w.findings, model.Finding{
+		Severity: model.SeverityError,
+		Rule:     model.RuleYAMLAlias,
+		Line:     node.Line,
+		Column:   node.Column,
+		KeyPath:  path,
+		Message:  message,
+	})
+}
+
+// expandMergeKeys returns a copy of node in which every YAML merge key
+// ("<<: *base" or "<<: [*a, *b]") is replaced by the pairs of the mappings
+// it merges in, so that the other checks see merged keys as ordinary ones.
+// As in YAML, keys set explicitly win over merged keys, and earlier merged
+// mappings win over later ones. A merge key whose value is not a mapping, or
+// a sequence of them, is left in place. node itself is never modified.
+func expandMergeKeys(node *yaml.Node) *yaml.Node {
+	e := &mergeExpander{done: make(map[*yaml.Node]*yaml.Node)}
+	return e.expand(node)
+}
+
+type mergeExpander struct {
+	// done maps each visited node to its copy; registering a copy before
+	// its children are expanded also stops cycles.
+	done map[*yaml.Node]*yaml.Node
+}
+
+func (e *mergeExpander) expand(node *yaml.Node) *yaml.Node {
+	if node == nil {
+		return nil
+	}
+	if out, ok := e.done[node]; ok {
+		return out
+	}
+
+	out := *node
+	e.done[node] = &out
+	switch node.Kind {
+	case yaml.AliasNode:
+		out.Alias = e.expand(node.Alias)
+	case yaml.DocumentNode, yaml.SequenceNode:
+		out.Content = make([]*yaml.Node, len(node.Content))
+		for i, child := range node.Content {
+			out.Content[i] = e.expand(child)
+		}
+	case yaml.MappingNode:
+		out.Content = e.expandMapping(node)
+	}
+	return &out
+}
+
+// expandMapping returns the content pairs of a mapping node with its merge
+// keys resolved.
+func (e *mergeExpander) expandMapping(node *yaml.Node) []*yaml.Node {
+	var content, merged []*yaml.Node
+	explicit := make(map[string]bool)
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, val := node.Content[i], node.Content[i+1]
+		if sources, ok := mergeSources(key, val); ok {
+			for _, src := range sources {
+				merged = append(merged, e.expand(src).Content...)
+			}
+			continue
+		}
+		content = append(content, key, e.expand(val))
+		explicit[key.Value] = true
+	}
+
+	for i := 0; i+1 < len(merged); i += 2 {
+		if key := merged[i].Value; !explicit[key] {
+			explicit[key] = true
+			content = append(content, merged[i], merged[i+1])
+		}
+	}
+	return content
+}
+
+// mergeSources returns the mappings merged in by a merge key pair, or false
+// if key is not a merge key or val holds something other than mappings.
+func mergeSources(key, val *yaml.Node) ([]*yaml.Node, bool) {
+	if key.Kind != yaml.ScalarNode || key.Tag != "!!merge" {
+		return nil, false
+	}
+	candidates := []*yaml.Node{val}
+	if resolveAlias(val).Kind == yaml.SequenceNode {
+		candidates = resolveAlias(val).Content
+	}
+
+	var sources []*yaml.Node
+	for _, c := range candidates {
+		c = resolveAlias(c)
+		if c.Kind != yaml.MappingNode {
+			return nil, false
+		}
+		sources = append(sources, c)
+	}
+	return sources, true
+}
+
+// expandSubchartMergeKeys applies expandMergeKeys to each subchart's defaults.
+func expandSubchartMergeKeys(defaults map[string]*yaml.Node) map[string]*yaml.Node {
+	expanded := make(map[string]*yaml.Node, len(defaults))
+	for name, node := range defaults {
+		expanded[name] = expandMergeKeys(node)
+	}
+	return expanded
+}
+
+// maxAliasChain bounds how many aliases resolveAlias follows in a row, so a
+// chain that loops back on itself cannot hang the checks.
+const maxAliasChain = 64
+
+// resolveAlias returns the node an alias (or chain of aliases) points to, or
+// node itself. A chain that ends at no anchor, or is longer than
+// maxAliasChain, resolves to its last alias.
+func resolveAlias(node *yaml.Node) *yaml.Node {
+	for i := 0; i < maxAliasChain && node.Kind == yaml.AliasNode && node.Alias != nil; i++ {
+		node = node.Alias
+	}
+	return node
+}
@@ -0,0 +1,248 @@
+package validator
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/chrishham/helm-values-checker/internal/chart"
+	"github.com/chrishham/helm-values-checker/internal/model"
+	"gopkg.in/yaml.v3"
+)
+
+func scalarNode(value string) *yaml.Node {
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: value}
+}
+
+func mappingNode(pairs ...*yaml.Node) *yaml.Node {
+	return &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map", Content: pairs}
+}
+
+func TestCheckAliases_ParsedAliasesAreValid(t *testing.T) {
+	user := parseYAML(t, `
+base: &base
+  cpu: 100m
+primary:
+  resources: *base
+replicas:
+  - *base
+`)
+	findings, cyclic := checkAliases(user, nil)
+	if len(findings) != 0 || cyclic {
+		t.Errorf("expected no findings, got %v (cyclic=%v)", findings, cyclic)
+	}
+}
+
+func TestCheckAliases_Dangling(t *testing.T) {
+	alias := &yaml.Node{Kind: yaml.AliasNode, Value: "missing", Line: 3}
+	user := mappingNode(scalarNode("image"), mappingNode(scalarNode("tag"), alias))
+
+	findings, cyclic := checkAliases(user, nil)
+	if cyclic {
+		t.Error("expected a dangling alias not to be reported as cyclic")
+	}
+	if len(findings) != 1 {
+		t.Fatalf("expected 1 finding, got %d: %v", len(findings), findings)
+	}
+	if findings[0].KeyPath != "image.tag" || findings[0].Line != 3 {
+		t.Errorf("expected finding at image.tag line 3, got %q line %d", findings[0].KeyPath, findings[0].Line)
+	}
+	if !strings.Contains(findings[0].Message, "undefined anchor") {
+		t.Errorf("expected undefined-anchor message, got %q", findings[0].Message)
+	}
+}
+
+func TestCheckAliases_AliasChainCycle(t *testing.T) {
+	a := &yaml.Node{Kind: yaml.AliasNode, Value: "a"}
+	b := &yaml.Node{Kind: yaml.AliasNode, Value: "b", Alias: a}
+	a.Alias = b
+	user := mappingNode(scalarNode("first"), a)
+
+	findings, cyclic := checkAliases(user, nil)
+	if !cyclic || len(findings) != 1 {
+		t.Fatalf("expected 1 cyclic finding, got %v (cyclic=%v)", findings, cyclic)
+	}
+	if !strings.Contains(findings[0].Message, "forms a cycle") {
+		t.Errorf("expected cycle message, got %q", findings[0].Message)
+	}
+}
+
+func TestCheckAliases_SelfReferencingAncestor(t *testing.T) {
+	config := mappingNode()
+	config.Content = append(config.Content, scalarNode("self"), &yaml.Node{Kind: yaml.AliasNode, Value: "config", Alias: config})
+	user := mappingNode(scalarNode("config"), config)
+
+	findings, cyclic := checkAliases(user, nil)
+	if !cyclic || len(findings) != 1 || findings[0].KeyPath != "config.self" {
+		t.Fatalf("expected cycle at config.self, got %v (cyclic=%v)", findings, cyclic)
+	}
+}
+
+func TestCheckAliases_IndirectCycle(t *testing.T) {
+	// a: &A {b: *B}, c: &B {d: *A}
+	a := mappingNode()
+	c := mappingNode()
+	a.Content = append(a.Content, scalarNode("b"), &yaml.Node{Kind: yaml.AliasNode, Value: "B", Alias: c})
+	c.Content = append(c.Content, scalarNode("d"), &yaml.Node{Kind: yaml.AliasNode, Value: "A", Alias: a})
+	user := mappingNode(scalarNode("a"), a, scalarNode("c"), c)
+
+	_, cyclic := checkAliases(user, nil)
+	if !cyclic {
+		t.Error("expected an indirect cycle to be detected")
+	}
+}
+
+func TestCheckAliases_IgnoredCycleStillStopsValidation(t *testing.T) {
+	config := mappingNode()
+	config.Content = append(config.Content, scalarNode("self"), &yaml.Node{Kind: yaml.AliasNode, Value: "config", Alias: config})
+	user := mappingNode(scalarNode("config"), config)
+
+	findings, cyclic := checkAliases(user, []string{"config.*"})
+	if len(findings) != 0 {
+		t.Errorf("expected ignored finding to be suppressed, got %v", findings)
+	}
+	if !cyclic {
+		t.Error("expected cyclic to be reported even when the path is ignored")
+	}
+}
+
+func TestExpandMergeKeys_Precedence(t *testing.T) {
+	node := parseYAML(t, `
+a: &a
+  x: 1
+b: &b
+  x: 2
+  y: 2
+c:
+  <<: [*a, *b]
+  y: 3
+`)
+	c := getValueForKey(expandMergeKeys(node), "c")
+	if c == nil {
+		t.Fatal("expected key c")
+	}
+	got := make(map[string]string)
+	for i := 0; i+1 < len(c.Content); i += 2 {
+		got[c.Content[i].Value] = c.Content[i+1].Value
+	}
+	if len(got) != 2 || got["x"] != "1" || got["y"] != "3" {
+		t.Errorf("expected explicit keys and earlier merges to win, got %v", got)
+	}
+
+	// The input tree is left untouched
+	if orig := getValueForKey(node, "c"); orig.Content[0].Value != "<<" {
+		t.Errorf("expected the original tree to keep its merge key, got %q", orig.Content[0].Value)
+	}
+}
+
+func TestDetectUnknownKeys_MergeKeysInDefaults(t *testing.T) {
+	defaults := parseYAML(t, `
+base: &base
+  cpu: 100m
+worker:
+  <<: *base
+  replicas: 1
+`)
+	user := parseYAML(t, `
+worker:
+  cpu: 200m
+  replicas: 2
+`)
+	findings := detectUnknownKeys(user, expandMergeKeys(defaults), nil, nil, nil, nil, "", nil, nil, false, true)
+	if len(findings) != 0 {
+		t.Errorf("expected merged default keys to be known, got %v", findings)
+	}
+}
+
+func TestValidate_MergeKeysInValues(t *testing.T) {
+	resolved, err := chart.Resolve(filepath.Join(testdataDir(), "test-chart"), "")
+	if err != nil {
+		t.Fatalf("failed to resolve chart: %v", err)
+	}
+	defer resolved.Cleanup()
+
+	data := []byte(`
+resources:
+  limits: &limits
+    cpu: 200m
+    memory: 256Mi
+  requests:
+    <<: *limits
+    cpu: 100m
+service:
+  <<:
+    port: eighty
+image:
+  <<:
+    tga: "1.25"
+`)
+	result, err := ValidateBytesWithOptions("values.yaml", data, resolved, Options{})
+	if err != nil {
+		t.Fatalf("validation error: %v", err)
+	}
+
+	got := make(map[string]string)
+	for _, f := range result.Findings {
+		got[f.KeyPath] = f.Rule
+	}
+	if len(got) != 2 || got["service.port"] != model.RuleTypeMismatch || got["image.tga"] != model.RuleUnknownKey {
+		t.Errorf("expected a type mismatch at service.port and an unknown image.tga, got %v", result.Findings)
+	}
+}
+
+func TestDetectTypeMismatches_SharedBlockAliasedTwice(t *testing.T) {
+	defaults := parseYAML(t, `
+shared: &shared
+  image: &image
+    repository: nginx
+    tag: "1.25"
+  ports:
+    - name: http
+      containerPort: 80
+web: *shared
+worker:
+  base: *shared
+  sidecarImage: *image
+`)
+	user := parseYAML(t, `
+web:
+  image:
+    tag: 1.26
+worker:
+  base:
+    ports:
+      - containerPort: eighty
+  sidecarImage:
+    repository: 5
+`)
+	findings := detectTypeMismatches(user, defaults, nil, nil, "", nil)
+	got := map[string]bool{}
+	for _, f := range findings {
+		got[f.KeyPath] = true
+	}
+	for _, path := range []string{"web.image.tag", "worker.base.ports[0].containerPort", "worker.sidecarImage.repository"} {
+		if !got[path] {
+			t.Errorf("expected a type mismatch at %s, got %v", path, findings)
+		}
+	}
+
+	paths := collectAllPaths(defaults, "")
+	if paths["worker.base.image.tag"] != "tag" {
+		t.Errorf("expected paths under aliased blocks to be collected, got %v", paths)
+	}
+}
+
+func TestResolveAlias_Chain(t *testing.T) {
+	target := &yaml.Node{Kind: yaml.ScalarNode, Value: "x"}
+	inner := &yaml.Node{Kind: yaml.AliasNode, Alias: target}
+	outer := &yaml.Node{Kind: yaml.AliasNode, Alias: inner}
+	if got := resolveAlias(outer); got != target {
+		t.Errorf("expected the chain to resolve to its target, got %+v", got)
+	}
+
+	loop := &yaml.Node{Kind: yaml.AliasNode, Value: "loop"}
+	loop.Alias = loop
+	if got := resolveAlias(loop); got.Kind != yaml.AliasNode {
+		t.Errorf("expected a looping chain to stop at an alias, got %+v", got)
+	}
+}
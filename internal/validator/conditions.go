@@ -0,0 +1,169 @@
+package validator
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/chrishham/helm-values-checker/internal/chart"
+	"github.com/chrishham/helm-values-checker/internal/model"
+	"gopkg.in/yaml.v3"
+)
+
+// detectDisabledSubcharts checks the values set for subcharts that Chart.yaml
+// toggles with a condition or tags. Values under a subchart that the user
+// values (or, failing those, the chart defaults) turn off have no effect,
+// and setting the condition of a subchart that is not bundled enables
+// nothing; both are warnings.
+func detectDisabledSubcharts(userNode, defaultsNode *yaml.Node, toggles []chart.SubchartToggle, ignoreKeys []string) []model.Finding {
+	var findings []model.Finding
+
+	if userNode == nil || userNode.Kind != yaml.MappingNode {
+		return findings
+	}
+
+	for _, t := range toggles {
+		if matchesIgnore(t.Key, ignoreKeys) {
+			continue
+		}
+
+		if !t.Bundled {
+			for _, c := range t.Conditions {
+				if findNodeForPath(userNode, c) == nil || matchesIgnore(c, ignoreKeys) {
+					continue
+				}
+				line, column := findPositionForPath(userNode, c)
+				findings = append(findings, model.Finding{
+					Severity: model.SeverityWarning,
+					Rule:     model.RuleMissingSubchart,
+					Line:     line,
+					Column:   column,
+					KeyPath:  c,
+					Message:  fmt.Sprintf("%s toggles subchart %q, which is not bundled with the chart (run helm dependency build)", c, t.Key),
+				})
+			}
+			continue
+		}
+
+		enabled, reason := subchartEnabled(t, userNode, defaultsNode)
+		if enabled {
+			continue
+		}
+		if key := subchartValuesKey(userNode, t); key != nil {
+			findings = append(findings, model.Finding{
+				Severity: model.SeverityWarning,
+				Rule:     model.RuleDisabledSubchart,
+				Line:     key.Line,
+				Column:   key.Column,
+				KeyPath:  t.Key,
+				Message:  fmt.Sprintf("Values under %q have no effect: subchart %q is disabled by %s", t.Key, t.Key, reason),
+			})
+		}
+	}
+
+	return findings
+}
+
+// detectUnvendoredSubcharts warns about values set under the keys of
+// dependencies that Chart.yaml declares but the chart does not bundle
+// (unvendored), which cannot be checked without the subchart's defaults.
+// Setting only their condition paths is covered by detectDisabledSubcharts.
+// With fromRelease the subcharts were installed but not stored with the
+// release, so each gets an info note rather than a warning.
+func detectUnvendoredSubcharts(userNode *yaml.Node, unvendored []string, toggles []chart.SubchartToggle, ignoreKeys []string, fromRelease bool) []model.Finding {
+	var findings []model.Finding
+
+	for _, key := range unvendored {
+		if matchesIgnore(key, ignoreKeys) {
+			continue
+		}
+		t := chart.SubchartToggle{Key: key}
+		for _, toggle := range toggles {
+			if toggle.Key == key {
+				t = toggle
+			}
+		}
+		k := subchartValuesKey(userNode, t)
+		if k == nil {
+			continue
+		}
+		f := model.Finding{
+			Severity: model.SeverityWarning,
+			Rule:     model.RuleMissingSubchart,
+			Line:     k.Line,
+			Column:   k.Column,
+			KeyPath:  key,
+			Message:  fmt.Sprintf("Values under %q cannot be validated: subchart %q is declared in Chart.yaml but not bundled with the chart (run helm dependency build)", key, key),
+		}
+		if fromRelease {
+			f.Severity, f.Rule = model.SeverityInfo, model.RuleReleaseSubchart
+			f.Message = fmt.Sprintf("Values under %q are not checked: subchart defaults are not stored with the release", key)
+		}
+		findings = append(findings, f)
+	}
+
+	return findings
+}
+
+// subchartEnabled resolves t the way Helm does: the first condition path
+// holding a bool decides, then any tag set to true enables and any set to
+// false disables; a subchart is enabled by default. The reason names the
+// deciding value, as in "redis.enabled: false".
+func subchartEnabled(t chart.SubchartToggle, userNode, defaultsNode *yaml.Node) (bool, string) {
+	for _, c := range t.Conditions {
+		if v, ok := boolAt(c, userNode, defaultsNode); ok {
+			return v, fmt.Sprintf("%s: %t", c, v)
+		}
+	}
+
+	var disabledBy []string
+	for _, tag := range t.Tags {
+		v, ok := boolAt("tags."+tag, userNode, defaultsNode)
+		if !ok {
+			continue
+		}
+		if v {
+			return true, ""
+		}
+		disabledBy = append(disabledBy, "tags."+tag+": false")
+	}
+	if len(disabledBy) > 0 {
+		return false, strings.Join(disabledBy, ", ")
+	}
+	return true, ""
+}
+
+// boolAt returns the bool at path in the first of trees that sets it.
+func boolAt(path string, trees ...*yaml.Node) (bool, bool) {
+	for _, tree := range trees {
+		node := findNodeForPath(tree, path)
+		if node == nil {
+			continue
+		}
+		var v bool
+		if node.Kind != yaml.ScalarNode || node.ShortTag() != "!!bool" || node.Decode(&v) != nil {
+			return false, false
+		}
+		return v, true
+	}
+	return false, false
+}
+
+// subchartValuesKey returns the first key node the user sets under the
+// subchart key, other than its own condition paths, or nil if none.
+func subchartValuesKey(userNode *yaml.Node, t chart.SubchartToggle) *yaml.Node {
+	valNode := findNodeForPath(userNode, t.Key)
+	if valNode == nil || valNode.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(valNode.Content); i += 2 {
+		path := joinPath(t.Key, valNode.Content[i].Value)
+		isCondition := false
+		for _, c := range t.Conditions {
+			isCondition = isCondition || c == path
+		}
+		if !isCondition {
+			return valNode.Content[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,124 @@
+package validator
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/chrishham/helm-values-checker/internal/chart"
+	"github.com/chrishham/helm-values-checker/internal/model"
+)
+
+func TestValidate_DisabledSubchartValues(t *testing.T) {
+	resolved, err := chart.Resolve(filepath.Join(testdataDir(), "test-chart-with-conditions"), "")
+	if err != nil {
+		t.Fatalf("failed to resolve chart: %v", err)
+	}
+	defer resolved.Cleanup()
+
+	tests := []struct {
+		name    string
+		values  string
+		keyPath string // expected warning key path, "" for none
+		message string
+	}{
+		{
+			name:    "condition false",
+			values:  "redis:\n  enabled: false\n  auth:\n    password: s3cret\n",
+			keyPath: "redis",
+			message: `Values under "redis" have no effect: subchart "redis" is disabled by redis.enabled: false`,
+		},
+		{
+			name:   "condition left on",
+			values: "redis:\n  architecture: replication\n",
+		},
+		{
+			name:   "only the condition set",
+			values: "redis:\n  enabled: false\n",
+		},
+		{
+			name:    "tag off by default",
+			values:  "exporter:\n  port: 9200\n",
+			keyPath: "exporter",
+			message: `Values under "exporter" have no effect: subchart "exporter" is disabled by tags.monitoring: false`,
+		},
+		{
+			name:   "tag turned on",
+			values: "tags:\n  monitoring: true\nexporter:\n  port: 9200\n",
+		},
+		{
+			name:    "condition of an unbundled subchart",
+			values:  "metrics:\n  enabled: true\n",
+			keyPath: "metrics.enabled",
+			message: `metrics.enabled toggles subchart "metrics", which is not bundled with the chart (run helm dependency build)`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ValidateBytesWithOptions("values.yaml", []byte(tt.values), resolved, Options{})
+			if err != nil {
+				t.Fatalf("validation error: %v", err)
+			}
+			var got []model.Finding
+			for _, f := range result.Findings {
+				if f.Rule == model.RuleDisabledSubchart || f.Rule == model.RuleMissingSubchart {
+					got = append(got, f)
+				}
+			}
+			if result.HasErrors() {
+				t.Errorf("expected no errors, got %v", result.Errors())
+			}
+			if tt.keyPath == "" {
+				if len(got) != 0 {
+					t.Errorf("expected no subchart warnings, got %v", got)
+				}
+				return
+			}
+			if len(got) != 1 || got[0].KeyPath != tt.keyPath || got[0].Message != tt.message || got[0].Severity != model.SeverityWarning {
+				t.Errorf("expected a warning at %s: %q, got %v", tt.keyPath, tt.message, got)
+			}
+		})
+	}
+}
+
+func TestValidate_UnvendoredSubchartValues(t *testing.T) {
+	resolved, err := chart.Resolve(filepath.Join(testdataDir(), "test-chart-with-unvendored-dependency"), "")
+	if err != nil {
+		t.Fatalf("failed to resolve chart: %v", err)
+	}
+	defer resolved.Cleanup()
+
+	values := "replicaCount: 2\ndb:\n  auth:\n    password: s3cret\n"
+	result, err := ValidateBytesWithOptions("values.yaml", []byte(values), resolved, Options{})
+	if err != nil {
+		t.Fatalf("validation error: %v", err)
+	}
+	if result.HasErrors() {
+		t.Errorf("expected no unknown-key errors under db, got %v", result.Errors())
+	}
+	want := `Values under "db" cannot be validated: subchart "db" is declared in Chart.yaml but not bundled with the chart (run helm dependency build)`
+	if len(result.Findings) != 1 || result.Findings[0].Rule != model.RuleMissingSubchart || result.Findings[0].Line != 3 || result.Findings[0].Message != want {
+		t.Errorf("expected one missing-subchart warning at line 3, got %v", result.Findings)
+	}
+}
+
+func TestValidate_ReleaseSubchartValues(t *testing.T) {
+	resolved, err := chart.Resolve(filepath.Join(testdataDir(), "test-chart-with-unvendored-dependency"), "")
+	if err != nil {
+		t.Fatalf("failed to resolve chart: %v", err)
+	}
+	defer resolved.Cleanup()
+	resolved.FromRelease = true
+
+	values := "replicaCount: 2\ndb:\n  auth:\n    password: s3cret\n"
+	result, err := ValidateBytesWithOptions("values.yaml", []byte(values), resolved, Options{})
+	if err != nil {
+		t.Fatalf("validation error: %v", err)
+	}
+	if result.HasErrors() || len(result.Warnings()) > 0 {
+		t.Errorf("expected no errors or warnings for a release's subchart, got %v", result.Findings)
+	}
+	want := `Values under "db" are not checked: subchart defaults are not stored with the release`
+	if infos := result.Infos(); len(infos) != 1 || infos[0].Rule != model.RuleReleaseSubchart || infos[0].Line != 3 || infos[0].Message != want {
+		t.Errorf("expected one release-subchart note at line 3, got %v", result.Findings)
+	}
+}
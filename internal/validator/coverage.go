@@ -0,0 +1,104 @@
+package validator
+
+import (
+	"github.com/chrishham/helm-values-checker/internal/model"
+	"gopkg.in/yaml.v3"
+)
+
+// computeCoverage counts the leaf keys of defaultsNode and reports which of
+// them userNode leaves unset. A key counts as set if the user sets it, or
+// sets one of its parents to something other than a mapping (replacing the
+// whole default subtree). Leaves are scalars, sequences, and empty
+// mappings; ignored keys are not counted.
+func computeCoverage(userNode, defaultsNode *yaml.Node, ignoreKeys []string) *model.Coverage {
+	c := &model.Coverage{Unset: []string{}}
+	walkCoverage(userNode, defaultsNode, ignoreKeys, "", c)
+	return c
+}
+
+func walkCoverage(userNode, defaultsNode *yaml.Node, ignoreKeys []string, path string, c *model.Coverage) {
+	if defaultsNode == nil {
+		return
+	}
+	defaultsNode = resolveAlias(defaultsNode)
+	if defaultsNode.Kind != yaml.MappingNode {
+		return
+	}
+
+	for i := 0; i+1 < len(defaultsNode.Content); i += 2 {
+		key := defaultsNode.Content[i].Value
+		childPath := joinPath(path, key)
+		if matchesIgnore(childPath, ignoreKeys) {
+			continue
+		}
+
+		userVal := getValueForKey(userNode, key)
+		defaultVal := resolveAlias(defaultsNode.Content[i+1])
+
+		if defaultVal.Kind == yaml.MappingNode && len(defaultVal.Content) > 0 {
+			if userVal != nil && userVal.Kind != yaml.MappingNode {
+				// The user replaced the whole subtree
+				countSet(defaultVal, c)
+				continue
+			}
+			walkCoverage(userVal, defaultVal, ignoreKeys, childPath, c)
+			continue
+		}
+
+		c.DefaultedKeys++
+		if userVal != nil {
+			c.SetKeys++
+		} else {
+			c.Unset = append(c.Unset, childPath)
+		}
+	}
+}
+
+// countSet counts every leaf under a default subtree as set.
+func countSet(node *yaml.Node, c *model.Coverage) {
+	node = resolveAlias(node)
+	if node.Kind != yaml.MappingNode || len(node.Content) == 0 {
+		c.DefaultedKeys++
+		c.SetKeys++
+		return
+	}
+	for i := 1; i < len(node.Content); i += 2 {
+		countSet(node.Content[i], c)
+	}
+}
+
+// countKeys counts the leaf keys of userNode that validation looked at,
+// skipping ignored keys and those ignored by comment, and how many of them
+// lie outside the unknown keys found. Leaves are as in computeCoverage.
+func countKeys(userNode *yaml.Node, ignoreKeys, commentIgnored []string, findings []model.Finding) (checked, known int) {
+	var unknown []string
+	for _, f := range findings {
+		if f.Rule == model.RuleUnknownKey {
+			unknown = append(unknown, f.KeyPath)
+		}
+	}
+	walkKeyCount(userNode, "", ignoreKeys, commentIgnored, unknown, &checked, &known)
+	return checked, known
+}
+
+func walkKeyCount(node *yaml.Node, path string, ignoreKeys, commentIgnored, unknown []string, checked, known *int) {
+	node = resolveAlias(node)
+	if node.Kind != yaml.MappingNode {
+		return
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		childPath := joinPath(path, node.Content[i].Value)
+		if matchesIgnore(childPath, ignoreKeys) || withinAny(childPath, commentIgnored) {
+			continue
+		}
+		val := resolveAlias(node.Content[i+1])
+		if val.Kind == yaml.MappingNode && len(val.Content) > 0 {
+			walkKeyCount(val, childPath, ignoreKeys, commentIgnored, unknown, checked, known)
+			continue
+		}
+		*checked++
+		if !withinAny(childPath, unknown) {
+			*known++
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package validator
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/chrishham/helm-values-checker/internal/chart"
+)
+
+func TestComputeCoverage(t *testing.T) {
+	defaults := parseYAML(t, `
+replicaCount: 1
+image:
+  repository: nginx
+  tag: latest
+resources:
+  limits:
+    cpu: 100m
+nodeSelector: {}
+secret: ""
+`)
+	user := parseYAML(t, `
+image:
+  tag: "1.25"
+resources: null
+`)
+
+	c := computeCoverage(user, defaults, []string{"secret"})
+	if c.DefaultedKeys != 5 || c.SetKeys != 2 {
+		t.Errorf("expected 2 of 5 defaulted keys set, got %d of %d", c.SetKeys, c.DefaultedKeys)
+	}
+	if got := strings.Join(c.Unset, ","); got != "replicaCount,image.repository,nodeSelector" {
+		t.Errorf("expected unset keys in chart order, got %s", got)
+	}
+}
+
+func TestValidateWithOptions_Coverage(t *testing.T) {
+	resolved, err := chart.Resolve(filepath.Join(testdataDir(), "test-chart"), "")
+	if err != nil {
+		t.Fatalf("failed to resolve chart: %v", err)
+	}
+	defer resolved.Cleanup()
+
+	result, err := ValidateBytesWithOptions("values.yaml", []byte("replicaCount: 2\n"), resolved, Options{})
+	if err != nil {
+		t.Fatalf("validation error: %v", err)
+	}
+	if result.Coverage != nil {
+		t.Error("expected no coverage unless requested")
+	}
+
+	result, err = ValidateBytesWithOptions("values.yaml", []byte("replicaCount: 2\n"), resolved, Options{Coverage: true})
+	if err != nil {
+		t.Fatalf("validation error: %v", err)
+	}
+	if result.Coverage == nil || result.Coverage.SetKeys != 1 || len(result.Coverage.Unset) != result.Coverage.DefaultedKeys-1 {
+		t.Errorf("expected one defaulted key set, got %+v", result.Coverage)
+	}
+}
+
+func TestValidateWithOptions_CountsKeys(t *testing.T) {
+	resolved, err := chart.Resolve(filepath.Join(testdataDir(), "test-chart"), "")
+	if err != nil {
+		t.Fatalf("failed to resolve chart: %v", err)
+	}
+	defer resolved.Cleanup()
+
+	data := []byte(`replicaCount: 2
+image:
+  tag: v2
+  regsitry: docker.io
+nodeSelector: {}
+tolerations:
+  - key: dedicated
+misspelled:
+  a: 1
+  b: 2
+internal:
+  note: x
+`)
+	result, err := ValidateBytesWithOptions("values.yaml", data, resolved, Options{IgnoreKeys: []string{"internal.*"}})
+	if err != nil {
+		t.Fatalf("validation error: %v", err)
+	}
+	if result.CheckedKeys != 7 || result.KnownKeys != 4 {
+		t.Errorf("expected 7 checked keys with 4 known, got %d checked and %d known", result.CheckedKeys, result.KnownKeys)
+	}
+}
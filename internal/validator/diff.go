@@ -0,0 +1,181 @@
+package validator
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/chrishham/helm-values-checker/internal/chart"
+	"github.com/chrishham/helm-values-checker/internal/model"
+	"gopkg.in/yaml.v3"
+)
+
+// Diff compares two values files and reports the leaf paths that were added,
+// removed, or changed between them. Added keys that the chart does not know
+// about (per its defaults, schema, and subcharts) are marked Unknown.
+func Diff(oldFile, newFile string, resolved *chart.ResolvedChart, ignoreKeys []string) (*model.DiffResult, error) {
+	oldData, err := readValuesFile(oldFile)
+	if err != nil {
+		return nil, err
+	}
+	newData, err := readValuesFile(newFile)
+	if err != nil {
+		return nil, err
+	}
+
+	oldNode, err := parseValues(oldFile, oldData)
+	if err != nil {
+		return nil, err
+	}
+	newNode, err := parseValues(newFile, newData)
+	if err != nil {
+		return nil, err
+	}
+	if _, cyclic := checkAliases(oldNode, nil); cyclic {
+		return nil, fmt.Errorf("values file %s: contains cyclic aliases", oldFile)
+	}
+	if _, cyclic := checkAliases(newNode, nil); cyclic {
+		return nil, fmt.Errorf("values file %s: contains cyclic aliases", newFile)
+	}
+	oldNode, newNode = expandMergeKeys(oldNode), expandMergeKeys(newNode)
+
+	result := &model.DiffResult{
+		OldFile:      oldFile,
+		NewFile:      newFile,
+		ChartName:    resolved.Chart.Metadata.Name,
+		ChartVersion: resolved.Chart.Metadata.Version,
+	}
+
+	d := &differ{ignoreKeys: ignoreKeys}
+	d.diff(oldNode, newNode, "")
+
+	// Mark added keys the chart does not know about
+	schemaBytes := mergeSubchartSchemas(resolved.SchemaBytes, resolved.SubchartSchemas)
+	unknown := detectUnknownKeys(newNode, expandMergeKeys(resolved.DefaultsNode), extractSchemaKeys(schemaBytes), extractSchemaPatterns(schemaBytes),
+		expandSubchartMergeKeys(resolved.SubchartDefaults), ignoreKeys, "", nil, nil, false, true)
+	for i := range d.entries {
+		if d.entries[i].Kind != model.DiffAdded {
+			continue
+		}
+		for _, f := range unknown {
+			if pathWithin(d.entries[i].KeyPath, f.KeyPath) {
+				d.entries[i].Unknown = true
+				break
+			}
+		}
+	}
+
+	result.Entries = d.entries
+	return result, nil
+}
+
+type differ struct {
+	ignoreKeys []string
+	entries    []model.DiffEntry
+}
+
+// diff compares oldNode and newNode at path, recursing into mappings and
+// sequences present on both sides.
+func (d *differ) diff(oldNode, newNode *yaml.Node, path string) {
+	if path != "" && matchesIgnore(path, d.ignoreKeys) {
+		return
+	}
+	oldNode, newNode = resolveAlias(oldNode), resolveAlias(newNode)
+
+	switch {
+	case oldNode.Kind == yaml.MappingNode && newNode.Kind == yaml.MappingNode:
+		for i := 0; i+1 < len(newNode.Content); i += 2 {
+			key := newNode.Content[i].Value
+			childPath := joinPath(path, key)
+			if oldVal := getValueForKey(oldNode, key); oldVal != nil {
+				d.diff(oldVal, newNode.Content[i+1], childPath)
+			} else {
+				d.leaves(model.DiffAdded, newNode.Content[i+1], childPath)
+			}
+		}
+		for i := 0; i+1 < len(oldNode.Content); i += 2 {
+			key := oldNode.Content[i].Value
+			if getValueForKey(newNode, key) == nil {
+				d.leaves(model.DiffRemoved, oldNode.Content[i+1], joinPath(path, key))
+			}
+		}
+
+	case oldNode.Kind == yaml.SequenceNode && newNode.Kind == yaml.SequenceNode:
+		for idx, elem := range newNode.Content {
+			elemPath := fmt.Sprintf("%s[%d]", path, idx)
+			if idx < len(oldNode.Content) {
+				d.diff(oldNode.Content[idx], elem, elemPath)
+			} else {
+				d.leaves(model.DiffAdded, elem, elemPath)
+			}
+		}
+		for idx := len(newNode.Content); idx < len(oldNode.Content); idx++ {
+			d.leaves(model.DiffRemoved, oldNode.Content[idx], fmt.Sprintf("%s[%d]", path, idx))
+		}
+
+	default:
+		if oldNode.Kind == newNode.Kind && oldNode.ShortTag() == newNode.ShortTag() && oldNode.Value == newNode.Value {
+			return
+		}
+		d.entries = append(d.entries, model.DiffEntry{
+			Kind:     model.DiffChanged,
+			Line:     newNode.Line,
+			KeyPath:  path,
+			OldValue: displayValue(oldNode),
+			NewValue: displayValue(newNode),
+			OldType:  friendlyType(oldNode.ShortTag()),
+			NewType:  friendlyType(newNode.ShortTag()),
+		})
+	}
+}
+
+// leaves records every leaf under node as added or removed. Ignored paths
+// are skipped along with everything beneath them, as in the other checks.
+func (d *differ) leaves(kind model.DiffKind, node *yaml.Node, path string) {
+	if matchesIgnore(path, d.ignoreKeys) {
+		return
+	}
+	node = resolveAlias(node)
+
+	switch {
+	case node.Kind == yaml.MappingNode && len(node.Content) > 0:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			d.leaves(kind, node.Content[i+1], joinPath(path, node.Content[i].Value))
+		}
+	case node.Kind == yaml.SequenceNode && len(node.Content) > 0:
+		for idx, elem := range node.Content {
+			d.leaves(kind, elem, fmt.Sprintf("%s[%d]", path, idx))
+		}
+	default:
+		entry := model.DiffEntry{Kind: kind, Line: node.Line, KeyPath: path}
+		if kind == model.DiffAdded {
+			entry.NewValue, entry.NewType = displayValue(node), friendlyType(node.ShortTag())
+		} else {
+			entry.OldValue, entry.OldType = displayValue(node), friendlyType(node.ShortTag())
+		}
+		d.entries = append(d.entries, entry)
+	}
+}
+
+// displayValue renders a leaf node for diff output. Collections are
+// abbreviated since their members are diffed individually.
+func displayValue(node *yaml.Node) string {
+	switch node.Kind {
+	case yaml.MappingNode:
+		if len(node.Content) == 0 {
+			return "{}"
+		}
+		return "{...}"
+	case yaml.SequenceNode:
+		if len(node.Content) == 0 {
+			return "[]"
+		}
+		return "[...]"
+	default:
+		return node.Value
+	}
+}
+
+// pathWithin reports whether path equals prefix or lies beneath it.
+func pathWithin(path, prefix string) bool {
+	return path == prefix || strings.HasPrefix(path, prefix+".") || strings.HasPrefix(path, prefix+"[")
+}
@@ -0,0 +1,93 @@
+package validator
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/chrishham/helm-values-checker/internal/chart"
+	"github.com/chrishham/helm-values-checker/internal/model"
+)
+
+func writeValues(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("failed to write %s: %v", name, err)
+	}
+	return path
+}
+
+func TestDiff(t *testing.T) {
+	resolved, err := chart.Resolve(filepath.Join(testdataDir(), "test-chart"), "")
+	if err != nil {
+		t.Fatalf("failed to resolve chart: %v", err)
+	}
+	defer resolved.Cleanup()
+
+	oldFile := writeValues(t, "old.yaml", `
+replicaCount: 1
+image:
+  repository: nginx
+  pullPolicy: Always
+`)
+	newFile := writeValues(t, "new.yaml", `
+replicaCount: "2"
+image:
+  repository: nginx
+  tag: "1.25"
+  regsitry: docker.io
+`)
+
+	result, err := Diff(oldFile, newFile, resolved, nil)
+	if err != nil {
+		t.Fatalf("diff error: %v", err)
+	}
+
+	entries := make(map[string]model.DiffEntry)
+	for _, e := range result.Entries {
+		entries[e.KeyPath] = e
+	}
+	if len(entries) != 4 {
+		t.Fatalf("expected 4 entries, got %d: %+v", len(result.Entries), result.Entries)
+	}
+
+	if e := entries["replicaCount"]; e.Kind != model.DiffChanged || e.OldType != "int" || e.NewType != "string" {
+		t.Errorf("expected replicaCount retyped int -> string, got %+v", e)
+	}
+	if e := entries["image.tag"]; e.Kind != model.DiffAdded || e.Unknown {
+		t.Errorf("expected image.tag added and known, got %+v", e)
+	}
+	if e := entries["image.regsitry"]; e.Kind != model.DiffAdded || !e.Unknown {
+		t.Errorf("expected image.regsitry added and unknown, got %+v", e)
+	}
+	if e := entries["image.pullPolicy"]; e.Kind != model.DiffRemoved || e.OldValue != "Always" {
+		t.Errorf("expected image.pullPolicy removed, got %+v", e)
+	}
+}
+
+func TestDiff_NestedAddedLeavesAndIgnore(t *testing.T) {
+	resolved, err := chart.Resolve(filepath.Join(testdataDir(), "test-chart"), "")
+	if err != nil {
+		t.Fatalf("failed to resolve chart: %v", err)
+	}
+	defer resolved.Cleanup()
+
+	oldFile := writeValues(t, "old.yaml", `
+replicaCount: 1
+`)
+	newFile := writeValues(t, "new.yaml", `
+replicaCount: 1
+extra:
+  a: 1
+  b: [x, y]
+`)
+
+	result, err := Diff(oldFile, newFile, resolved, []string{"extra.b"})
+	if err != nil {
+		t.Fatalf("diff error: %v", err)
+	}
+	if len(result.Entries) != 1 || result.Entries[0].KeyPath != "extra.a" || !result.Entries[0].Unknown {
+		t.Errorf("expected only unknown extra.a, got %+v", result.Entries)
+	}
+}
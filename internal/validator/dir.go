@@ -0,0 +1,118 @@
+package validator
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// IgnoreFileName is the file listing values files to skip when a directory
+// is validated, one pattern per line in the style of .helmignore.
+const IgnoreFileName = ".checkerignore"
+
+// ValuesFilesInDir returns the *.yaml and *.yml files under dir, in lexical
+// order, except those matched by the directory's .checkerignore.
+//
+// Patterns are matched against paths relative to dir, using the same glob
+// syntax as --ignore-keys with "/" in place of "." (e.g., "secrets/**", or
+// "*.yaml" for top-level files only), or as a shell pattern such as
+// "prod-*.yaml". A pattern matching a directory skips everything in it; one
+// ending in "/" matches directories only. Blank lines and lines starting
+// with "#" are skipped.
+func ValuesFilesInDir(dir string) ([]string, error) {
+	patterns, err := readIgnoreFile(filepath.Join(dir, IgnoreFileName))
+	if err != nil {
+		return nil, err
+	}
+
+	var files []string
+	err = filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil || rel == "." {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+
+		if d.IsDir() {
+			if isIgnoredPath(rel+"/", patterns) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		ext := strings.ToLower(filepath.Ext(rel))
+		if (ext == ".yaml" || ext == ".yml") && !isIgnoredPath(rel, patterns) {
+			files = append(files, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("reading values directory %s: %w", dir, err)
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no values files found in %s", dir)
+	}
+	return files, nil
+}
+
+// readIgnoreFile returns the patterns in an ignore file. A missing file
+// yields no patterns.
+func readIgnoreFile(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", path, err)
+	}
+	defer f.Close()
+
+	var patterns []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		patterns = append(patterns, strings.TrimPrefix(line, "/"))
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading %s: %w", path, err)
+	}
+	return patterns, nil
+}
+
+// isIgnoredPath reports whether a slash-separated path relative to the
+// values directory matches any ignore pattern. Directory paths end in "/",
+// and patterns ending in "/" match only those.
+func isIgnoredPath(rel string, patterns []string) bool {
+	isDir := strings.HasSuffix(rel, "/")
+	rel = strings.TrimSuffix(rel, "/")
+	for _, pattern := range patterns {
+		if strings.HasSuffix(pattern, "/") {
+			if !isDir {
+				continue
+			}
+			pattern = strings.TrimSuffix(pattern, "/")
+		}
+		if ok, _ := filepath.Match(pattern, rel); ok {
+			return true
+		}
+		if matchGlob(dotted(pattern), dotted(rel)) {
+			return true
+		}
+	}
+	return false
+}
+
+// dotted rewrites a file path or file pattern into key path form, so that
+// matchGlob treats each directory (and the extension) as a segment.
+func dotted(path string) string {
+	return strings.ReplaceAll(path, "/", ".")
+}
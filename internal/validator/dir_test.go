@@ -0,0 +1,97 @@
+package validator
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/chrishham/helm-values-checker/internal/chart"
+)
+
+func writeValuesDir(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestValuesFilesInDir_SkipsIgnoredFiles(t *testing.T) {
+	dir := writeValuesDir(t, map[string]string{
+		IgnoreFileName:       "# generated by CI\nsecret-values.yaml\n",
+		"values.yaml":        "replicaCount: 2\n",
+		"secret-values.yaml": "replicaCount: three\n",
+	})
+
+	files, err := ValuesFilesInDir(dir)
+	if err != nil {
+		t.Fatalf("ValuesFilesInDir error: %v", err)
+	}
+	if len(files) != 1 || filepath.Base(files[0]) != "values.yaml" {
+		t.Fatalf("expected only values.yaml, got %v", files)
+	}
+
+	resolved, err := chart.Resolve(filepath.Join(testdataDir(), "test-chart"), "")
+	if err != nil {
+		t.Fatalf("failed to resolve chart: %v", err)
+	}
+	defer resolved.Cleanup()
+
+	results, errs := ValidateFiles(files, resolved, Options{}, 1)
+	if errs[0] != nil {
+		t.Fatalf("validation error: %v", errs[0])
+	}
+	if results[0].ValuesFile != files[0] || results[0].HasErrors() {
+		t.Errorf("expected a clean result for %s, got %+v", files[0], results[0])
+	}
+}
+
+func TestValuesFilesInDir_Patterns(t *testing.T) {
+	dir := writeValuesDir(t, map[string]string{
+		IgnoreFileName:       "secrets/\nprod-*.yaml\nenv/**/local.yaml\n",
+		"values.yaml":        "",
+		"values.yml":         "",
+		"prod-eu.yaml":       "",
+		"README.md":          "",
+		"secrets/db.yaml":    "",
+		"env/dev/local.yaml": "",
+		"env/dev/app.yaml":   "",
+	})
+
+	files, err := ValuesFilesInDir(dir)
+	if err != nil {
+		t.Fatalf("ValuesFilesInDir error: %v", err)
+	}
+	var got []string
+	for _, f := range files {
+		rel, _ := filepath.Rel(dir, f)
+		got = append(got, filepath.ToSlash(rel))
+	}
+	want := []string{"env/dev/app.yaml", "values.yaml", "values.yml"}
+	if len(got) != len(want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("expected %v, got %v", want, got)
+			break
+		}
+	}
+}
+
+func TestValuesFilesInDir_NoValuesFiles(t *testing.T) {
+	dir := writeValuesDir(t, map[string]string{
+		IgnoreFileName: "*.yaml\n",
+		"values.yaml":  "",
+	})
+	if _, err := ValuesFilesInDir(dir); err == nil {
+		t.Error("expected error for a directory with no values files left")
+	}
+}
@@ -0,0 +1,83 @@
+package validator
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/chrishham/helm-values-checker/internal/model"
+	"gopkg.in/yaml.v3"
+)
+
+// IgnoreDirective is the comment that suppresses the findings on a key
+// (and everything beneath it) when written on the key's line or the line
+// above it, e.g. "legacyKey: true # values-checker:ignore". Text after the
+// directive, such as a reason, is allowed.
+const IgnoreDirective = "values-checker:ignore"
+
+// commentIgnoredPaths returns the key paths in the user values tree that
+// carry an IgnoreDirective comment, including list items (as "list[0]").
+// Aliases are not followed; an anchored node is annotated where it is
+// defined.
+func commentIgnoredPaths(node *yaml.Node, path string, paths []string) []string {
+	if node == nil {
+		return paths
+	}
+	switch node.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			keyNode, valNode := node.Content[i], node.Content[i+1]
+			fullPath := joinPath(path, keyNode.Value)
+			if hasIgnoreDirective(keyNode.HeadComment, keyNode.LineComment, valNode.LineComment) {
+				paths = append(paths, fullPath)
+				continue
+			}
+			paths = commentIgnoredPaths(valNode, fullPath, paths)
+		}
+	case yaml.SequenceNode:
+		for idx, elem := range node.Content {
+			elemPath := fmt.Sprintf("%s[%d]", path, idx)
+			if hasIgnoreDirective(elem.HeadComment, elem.LineComment) {
+				paths = append(paths, elemPath)
+				continue
+			}
+			paths = commentIgnoredPaths(elem, elemPath, paths)
+		}
+	}
+	return paths
+}
+
+// hasIgnoreDirective reports whether any line of the comments is an
+// IgnoreDirective.
+func hasIgnoreDirective(comments ...string) bool {
+	for _, comment := range comments {
+		for _, line := range strings.Split(comment, "\n") {
+			text := strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(line), "#"))
+			if text == IgnoreDirective || strings.HasPrefix(text, IgnoreDirective+" ") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// dropCommentIgnored returns findings without those at or beneath the
+// key paths ignored by comment.
+func dropCommentIgnored(findings []model.Finding, ignored []string) []model.Finding {
+	if len(ignored) == 0 {
+		return findings
+	}
+	kept := findings[:0]
+	for _, f := range findings {
+		suppressed := false
+		for _, p := range ignored {
+			if f.KeyPath == p || strings.HasPrefix(f.KeyPath, p+".") || strings.HasPrefix(f.KeyPath, p+"[") {
+				suppressed = true
+				break
+			}
+		}
+		if !suppressed {
+			kept = append(kept, f)
+		}
+	}
+	return kept
+}
@@ -0,0 +1,61 @@
+package validator
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/chrishham/helm-values-checker/internal/chart"
+	"github.com/chrishham/helm-values-checker/internal/model"
+)
+
+func TestValidate_CommentDirectives(t *testing.T) {
+	resolved, err := chart.Resolve(filepath.Join(testdataDir(), "test-chart"), "")
+	if err != nil {
+		t.Fatalf("failed to resolve chart: %v", err)
+	}
+	defer resolved.Cleanup()
+
+	data := []byte(`image:
+  registry: docker.io # values-checker:ignore consumed by our wrapper chart
+  regsitry: docker.io
+# values-checker:ignore
+legacy:
+  flag: true
+replicaCount: 2
+`)
+	result, err := ValidateBytesWithOptions("values.yaml", data, resolved, Options{CommentDirectives: true})
+	if err != nil {
+		t.Fatalf("validation error: %v", err)
+	}
+	if len(result.Findings) != 1 || result.Findings[0].Rule != model.RuleUnknownKey || result.Findings[0].KeyPath != "image.regsitry" {
+		t.Errorf("expected only the unannotated image.regsitry to be reported, got %v", result.Findings)
+	}
+
+	result, err = ValidateBytesWithOptions("values.yaml", data, resolved, Options{})
+	if err != nil {
+		t.Fatalf("validation error: %v", err)
+	}
+	if len(result.Findings) != 3 {
+		t.Errorf("expected directives to be ignored without CommentDirectives, got %v", result.Findings)
+	}
+}
+
+func TestHasIgnoreDirective(t *testing.T) {
+	tests := []struct {
+		comment string
+		want    bool
+	}{
+		{"# values-checker:ignore", true},
+		{"#values-checker:ignore", true},
+		{"# values-checker:ignore legacy key", true},
+		{"# owned by team A\n# values-checker:ignore", true},
+		{"# values-checker:ignored", false},
+		{"# see values-checker:ignore", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		if got := hasIgnoreDirective(tt.comment); got != tt.want {
+			t.Errorf("hasIgnoreDirective(%q) = %t, want %t", tt.comment, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,52 @@
+package validator
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/chrishham/helm-values-checker/internal/model"
+)
+
+// Escalation sets the severity of the findings whose key path matches
+// Pattern, a glob as in IgnoreKeys.
+type Escalation struct {
+	Pattern  string
+	Severity model.Severity
+}
+
+// ParseEscalations parses rules of the form "<glob>=<severity>", e.g.
+// "security.**=error", where the severity is error, warning, or info.
+func ParseEscalations(rules []string) ([]Escalation, error) {
+	var escalations []Escalation
+	for _, rule := range rules {
+		pattern, level, ok := strings.Cut(rule, "=")
+		if !ok || pattern == "" {
+			return nil, fmt.Errorf("invalid escalation %q: expected <key path>=<severity>", rule)
+		}
+		var severity model.Severity
+		switch level {
+		case "error":
+			severity = model.SeverityError
+		case "warning":
+			severity = model.SeverityWarning
+		case "info":
+			severity = model.SeverityInfo
+		default:
+			return nil, fmt.Errorf("invalid escalation %q: severity must be error, warning, or info", rule)
+		}
+		escalations = append(escalations, Escalation{Pattern: pattern, Severity: severity})
+	}
+	return escalations, nil
+}
+
+// escalate sets the severity of findings in place from the escalations
+// matching their key paths. When several match, the last one wins.
+func escalate(findings []model.Finding, escalations []Escalation) {
+	for i := range findings {
+		for _, e := range escalations {
+			if matchGlob(e.Pattern, findings[i].KeyPath) {
+				findings[i].Severity = e.Severity
+			}
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package validator
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/chrishham/helm-values-checker/internal/chart"
+	"github.com/chrishham/helm-values-checker/internal/model"
+)
+
+func TestParseEscalations(t *testing.T) {
+	escalations, err := ParseEscalations([]string{"security.**=error", "debug=info"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(escalations) != 2 || escalations[0] != (Escalation{"security.**", model.SeverityError}) || escalations[1].Severity != model.SeverityInfo {
+		t.Errorf("unexpected escalations: %+v", escalations)
+	}
+
+	for _, rule := range []string{"security.**", "=error", "security.**=fatal"} {
+		if _, err := ParseEscalations([]string{rule}); err == nil || !strings.Contains(err.Error(), "invalid escalation") {
+			t.Errorf("expected %q to be rejected, got %v", rule, err)
+		}
+	}
+}
+
+func TestValidateBytesWithOptions_Escalations(t *testing.T) {
+	resolved, err := chart.Resolve(filepath.Join(testdataDir(), "test-chart"), "")
+	if err != nil {
+		t.Fatalf("failed to resolve chart: %v", err)
+	}
+	defer resolved.Cleanup()
+	data := []byte("replicaCount: \"${REPLICAS}\"\nservice:\n  port: \"${PORT}\"\n  tyep: LoadBalancer\n")
+
+	escalations, err := ParseEscalations([]string{"service.**=error", "service.tyep=info"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	result, err := ValidateBytesWithOptions("values.yaml", data, resolved, Options{Escalations: escalations})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	severities := map[string]model.Severity{}
+	for _, f := range result.Findings {
+		severities[f.KeyPath] = f.Severity
+	}
+	if severities["replicaCount"] != model.SeverityWarning {
+		t.Errorf("expected findings outside the escalated paths to keep their severity, got %v", result.Findings)
+	}
+	if severities["service.port"] != model.SeverityError {
+		t.Errorf("expected the warning under service to be escalated, got %v", result.Findings)
+	}
+	if severities["service.tyep"] != model.SeverityInfo {
+		t.Errorf("expected the last matching escalation to lower the unknown key, got %v", result.Findings)
+	}
+}
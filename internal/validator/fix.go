@@ -0,0 +1,276 @@
+package validator
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/agnivade/levenshtein"
+	"github.com/chrishham/helm-values-checker/internal/model"
+	"gopkg.in/yaml.v3"
+)
+
+// Fix renames an unknown key to the path suggested for it.
+type Fix struct {
+	Line int    // line of the key in the values file
+	From string // dot-separated path of the unknown key
+	To   string // dot-separated path the key is renamed or moved to
+}
+
+// Fixes returns the unknown-key findings of result whose suggestion is
+// certain enough to apply without review: a sibling key at Levenshtein
+// distance 1, or a key of the same name at another path (a relocated leaf,
+// including one that belongs under a subchart). Keys without a line, such
+// as those from --set, are not in the file and are left out.
+func Fixes(result *model.ValidationResult) []Fix {
+	var fixes []Fix
+	for _, f := range result.Findings {
+		if f.Rule != model.RuleUnknownKey || f.Suggestion == "" || f.Line == 0 {
+			continue
+		}
+		fromParent, fromLeaf := splitPath(f.KeyPath)
+		toParent, toLeaf := splitPath(f.Suggestion)
+		sameParent := fromParent == toParent
+		if (sameParent && levenshtein.ComputeDistance(fromLeaf, toLeaf) == 1) || (!sameParent && fromLeaf == toLeaf) {
+			fixes = append(fixes, Fix{Line: f.Line, From: f.KeyPath, To: f.Suggestion})
+		}
+	}
+	return fixes
+}
+
+// ApplyFixes rewrites the values file at path with each fix applied, and
+// returns the fixes that were. When every fix renames a key within its
+// mapping, only the key's text is rewritten where it is written, so the
+// rest of the file is kept byte for byte. A fix that moves a key to
+// another mapping re-encodes the file from its yaml.Node tree instead,
+// which keeps comments and key order but drops blank lines and normalizes
+// indentation to two spaces. A fix is skipped if its key cannot be found
+// as written (e.g., it comes from a merge key or an alias) or if the
+// target key already exists. The file is left untouched when no fix
+// applies.
+func ApplyFixes(path string, fixes []Fix) ([]Fix, error) {
+	if isValuesURL(path) || isJSONValuesFile(path) {
+		return nil, fmt.Errorf("cannot fix %s: only local YAML files can be rewritten", path)
+	}
+	fi, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading values file %s: %w", path, err)
+	}
+	data, err := readValuesFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	doc := &yaml.Node{}
+	if err := dec.Decode(doc); err != nil {
+		return nil, fmt.Errorf("parsing values file %s: %w", path, err)
+	}
+	if err := dec.Decode(&yaml.Node{}); !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("cannot fix %s: only single-document files can be rewritten", path)
+	}
+	root, err := topLevelMapping(doc)
+	if err != nil {
+		return nil, fmt.Errorf("values file %s: %w", path, err)
+	}
+
+	var applied []Fix
+	var renames []keyRename
+	inPlace := true
+	for _, fix := range fixes {
+		fromParent, fromLeaf := splitPath(fix.From)
+		toParent, toLeaf := splitPath(fix.To)
+		var rename keyRename
+		src := mappingAt(root, fromParent, false)
+		if idx := mappingKeyIndex(src, fromLeaf); idx >= 0 {
+			key := src.Content[idx]
+			rename = keyRename{line: key.Line, column: key.Column, style: key.Style, from: key.Value, to: toLeaf}
+		}
+		if !moveKey(root, fix.From, fix.To) {
+			continue
+		}
+		applied = append(applied, fix)
+		renames = append(renames, rename)
+		inPlace = inPlace && fromParent == toParent
+	}
+	if len(applied) == 0 {
+		return nil, nil
+	}
+
+	out, ok := []byte(nil), false
+	if inPlace {
+		out, ok = renameKeys(data, renames)
+	}
+	if !ok {
+		var buf bytes.Buffer
+		enc := yaml.NewEncoder(&buf)
+		enc.SetIndent(2)
+		if err := enc.Encode(doc); err != nil {
+			return nil, fmt.Errorf("encoding values file %s: %w", path, err)
+		}
+		if err := enc.Close(); err != nil {
+			return nil, fmt.Errorf("encoding values file %s: %w", path, err)
+		}
+		out = buf.Bytes()
+	}
+	if err := os.WriteFile(path, out, fi.Mode().Perm()); err != nil {
+		return nil, fmt.Errorf("writing values file %s: %w", path, err)
+	}
+	return applied, nil
+}
+
+// keyRename is a key to rename where it is written in a values file.
+type keyRename struct {
+	line, column int // 1-based position of the key
+	style        yaml.Style
+	from, to     string
+}
+
+// renameKeys rewrites the text of each renamed key in data, keeping its
+// quoting. It reports false, with data unchanged, if a key is not written
+// as expected at its position (e.g., it uses escapes) or if the new name
+// would need a different quoting style.
+func renameKeys(data []byte, renames []keyRename) ([]byte, bool) {
+	lines := strings.SplitAfter(string(data), "\n")
+
+	// Rename from the end of each line, so earlier columns stay valid
+	sort.Slice(renames, func(i, j int) bool {
+		if renames[i].line != renames[j].line {
+			return renames[i].line < renames[j].line
+		}
+		return renames[i].column > renames[j].column
+	})
+	for _, r := range renames {
+		if r.line < 1 || r.line > len(lines) || r.column < 1 {
+			return data, false
+		}
+		var quote string
+		switch r.style {
+		case 0:
+			if plain, err := yaml.Marshal(r.to); err != nil || string(plain) != r.to+"\n" {
+				return data, false
+			}
+		case yaml.DoubleQuotedStyle:
+			quote = `"`
+		case yaml.SingleQuotedStyle:
+			quote = "'"
+		default:
+			return data, false
+		}
+		if quote != "" && strings.ContainsAny(r.to, quote+`\`) {
+			return data, false
+		}
+
+		line := []rune(lines[r.line-1])
+		start := r.column - 1
+		written := []rune(quote + r.from + quote)
+		if start+len(written) > len(line) || string(line[start:start+len(written)]) != string(written) {
+			return data, false
+		}
+		renamed := append(append(append([]rune(nil), line[:start]...), []rune(quote+r.to+quote)...), line[start+len(written):]...)
+		lines[r.line-1] = string(renamed)
+	}
+	return []byte(strings.Join(lines, "")), true
+}
+
+// moveKey renames the key at path from to the path to, creating missing
+// parent mappings of to. It reports whether the tree was changed.
+func moveKey(root *yaml.Node, from, to string) bool {
+	fromParent, fromLeaf := splitPath(from)
+	toParent, toLeaf := splitPath(to)
+
+	src := mappingAt(root, fromParent, false)
+	idx := mappingKeyIndex(src, fromLeaf)
+	if idx < 0 {
+		return false
+	}
+	dst := mappingAt(root, toParent, false)
+	if dst != nil && mappingKeyIndex(dst, toLeaf) >= 0 {
+		return false
+	}
+
+	if fromParent == toParent {
+		src.Content[idx].Value = toLeaf
+		return true
+	}
+	if dst == nil {
+		// Refuse before creating parents, so a failure leaves no trace
+		if !canCreateMapping(root, toParent) {
+			return false
+		}
+		dst = mappingAt(root, toParent, true)
+	}
+
+	keyNode, valNode := src.Content[idx], src.Content[idx+1]
+	src.Content = append(src.Content[:idx:idx], src.Content[idx+2:]...)
+	keyNode.Value = toLeaf
+	dst.Content = append(dst.Content, keyNode, valNode)
+	return true
+}
+
+// mappingAt returns the mapping node at a dot-separated path, or nil if a
+// segment is missing or not a mapping. With create, missing segments are
+// added as empty mappings.
+func mappingAt(node *yaml.Node, path string, create bool) *yaml.Node {
+	if path == "" {
+		return node
+	}
+	for _, key := range strings.Split(path, ".") {
+		idx := mappingKeyIndex(node, key)
+		if idx < 0 {
+			if !create {
+				return nil
+			}
+			child := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+			node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key}, child)
+			node = child
+			continue
+		}
+		node = node.Content[idx+1]
+		if node.Kind != yaml.MappingNode {
+			return nil
+		}
+	}
+	return node
+}
+
+// canCreateMapping reports whether mappingAt with create would succeed,
+// i.e. no existing segment of path holds something other than a mapping.
+func canCreateMapping(node *yaml.Node, path string) bool {
+	for _, key := range strings.Split(path, ".") {
+		idx := mappingKeyIndex(node, key)
+		if idx < 0 {
+			return true
+		}
+		node = node.Content[idx+1]
+		if node.Kind != yaml.MappingNode {
+			return false
+		}
+	}
+	return true
+}
+
+// mappingKeyIndex returns the Content index of key in a mapping node, or -1.
+func mappingKeyIndex(node *yaml.Node, key string) int {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return -1
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return i
+		}
+	}
+	return -1
+}
+
+// splitPath splits a dot-separated path into its parent path and leaf key.
+func splitPath(path string) (string, string) {
+	if i := strings.LastIndex(path, "."); i >= 0 {
+		return path[:i], path[i+1:]
+	}
+	return "", path
+}
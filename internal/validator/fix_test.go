@@ -0,0 +1,118 @@
+package validator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/chrishham/helm-values-checker/internal/chart"
+)
+
+func TestApplyFixes_DistanceOneTypo(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "values.yaml")
+	content := `# Production overrides
+replicaCount: 2
+image:
+  # pinned for the release
+  tagg: "1.25"
+pullPolicy: Always
+`
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	resolved, err := chart.Resolve(filepath.Join(testdataDir(), "test-chart"), "")
+	if err != nil {
+		t.Fatalf("failed to resolve chart: %v", err)
+	}
+	defer resolved.Cleanup()
+
+	result, err := Validate(path, resolved, nil)
+	if err != nil {
+		t.Fatalf("validation error: %v", err)
+	}
+	fixes := Fixes(result)
+	if len(fixes) != 2 || fixes[0].From != "image.tagg" || fixes[0].To != "image.tag" || fixes[1].From != "pullPolicy" || fixes[1].To != "image.pullPolicy" {
+		t.Fatalf("expected a rename to image.tag and a move to image.pullPolicy, got %+v", fixes)
+	}
+
+	applied, err := ApplyFixes(path, fixes)
+	if err != nil {
+		t.Fatalf("ApplyFixes error: %v", err)
+	}
+	if len(applied) != 2 {
+		t.Errorf("expected both fixes to apply, got %+v", applied)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := string(data)
+	for _, want := range []string{"# Production overrides", "# pinned for the release", `tag: "1.25"`, "  pullPolicy: Always"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("expected %q in the fixed file, got:\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, "tagg") {
+		t.Errorf("expected the typo to be gone, got:\n%s", got)
+	}
+
+	result, err = Validate(path, resolved, nil)
+	if err != nil {
+		t.Fatalf("validation error: %v", err)
+	}
+	if result.HasErrors() {
+		t.Errorf("expected the fixed file to be clean, got %+v", result.Findings)
+	}
+}
+
+func TestMoveKey_CreatesParents(t *testing.T) {
+	root := parseYAML(t, "regsitry: docker.io\nservice:\n  port: 80\n")
+	if !moveKey(root, "regsitry", "global.imageRegistry") {
+		t.Fatal("expected the key to move")
+	}
+	if findLineForPath(root, "regsitry") != 0 || findNodeForPath(root, "global.imageRegistry") == nil {
+		t.Errorf("expected the key under a new global mapping")
+	}
+	if moveKey(root, "global.imageRegistry", "service.port") {
+		t.Error("expected a move onto an existing key to be refused")
+	}
+	if moveKey(root, "service.port", "service.port.number") {
+		t.Error("expected a move under a scalar to be refused")
+	}
+}
+
+func TestApplyFixes_RenameKeepsFormatting(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "values.yaml")
+	content := `# Production overrides
+replicaCount: 2
+
+image:
+    # pinned for the release
+    tagg: "1.25"   # keep in sync with CI
+
+service: {prot: 80}
+`
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	applied, err := ApplyFixes(path, []Fix{{Line: 6, From: "image.tagg", To: "image.tag"}, {Line: 8, From: "service.prot", To: "service.port"}})
+	if err != nil {
+		t.Fatalf("ApplyFixes error: %v", err)
+	}
+	if len(applied) != 2 {
+		t.Errorf("expected both renames to apply, got %+v", applied)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := strings.Replace(strings.Replace(content, "tagg:", "tag:", 1), "prot:", "port:", 1)
+	if string(data) != want {
+		t.Errorf("expected only the keys to change, got:\n%s", data)
+	}
+}
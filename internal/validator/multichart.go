@@ -0,0 +1,73 @@
+package validator
+
+import (
+	"strings"
+
+	"github.com/chrishham/helm-values-checker/internal/model"
+)
+
+// AttributeUnknownKeys annotates unknown-key findings when the same values
+// file was validated against several charts: a key unknown to one chart
+// but known to another lists the charts that define it in KnownTo, so
+// that cross-chart values files show which chart each key belongs to.
+// A key counts as known to a chart if neither it nor any of its parents
+// was reported as unknown for that chart. Results for a single chart are
+// left as is.
+func AttributeUnknownKeys(results []*model.ValidationResult) {
+	byFile := map[string][]*model.ValidationResult{}
+	var order []string
+	for _, r := range results {
+		if _, ok := byFile[r.ValuesFile]; !ok {
+			order = append(order, r.ValuesFile)
+		}
+		byFile[r.ValuesFile] = append(byFile[r.ValuesFile], r)
+	}
+
+	for _, file := range order {
+		group := byFile[file]
+		if len(group) < 2 {
+			continue
+		}
+		unknown := make([][]string, len(group))
+		for i, r := range group {
+			for _, f := range r.Findings {
+				if f.Rule == model.RuleUnknownKey {
+					unknown[i] = append(unknown[i], f.KeyPath)
+				}
+			}
+		}
+
+		for i, r := range group {
+			for j := range r.Findings {
+				f := &r.Findings[j]
+				if f.Rule != model.RuleUnknownKey {
+					continue
+				}
+				for k, other := range group {
+					if k != i && !withinAny(f.KeyPath, unknown[k]) {
+						f.KnownTo = append(f.KnownTo, chartLabel(other))
+					}
+				}
+			}
+		}
+	}
+}
+
+// withinAny reports whether path equals or lies under one of paths.
+func withinAny(path string, paths []string) bool {
+	for _, p := range paths {
+		if path == p || strings.HasPrefix(path, p+".") || strings.HasPrefix(path, p+"[") {
+			return true
+		}
+	}
+	return false
+}
+
+// chartLabel names the chart a result was validated against, with its
+// version when known.
+func chartLabel(r *model.ValidationResult) string {
+	if r.ChartVersion == "" {
+		return r.ChartName
+	}
+	return r.ChartName + " " + r.ChartVersion
+}
@@ -0,0 +1,62 @@
+package validator
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/chrishham/helm-values-checker/internal/chart"
+	"github.com/chrishham/helm-values-checker/internal/model"
+)
+
+func TestAttributeUnknownKeys_TwoCharts(t *testing.T) {
+	data := []byte(`
+replicaCount: 2
+service:
+  port: 8080
+auth:
+  username: admin
+bogus: true
+`)
+
+	var results []*model.ValidationResult
+	for _, name := range []string{"test-chart", "test-chart-with-schema"} {
+		resolved, err := chart.Resolve(filepath.Join(testdataDir(), name), "")
+		if err != nil {
+			t.Fatalf("failed to resolve %s: %v", name, err)
+		}
+		defer resolved.Cleanup()
+
+		result, err := ValidateBytesWithOptions("platform.yaml", data, resolved, Options{Only: []string{CheckUnknown}})
+		if err != nil {
+			t.Fatalf("validation error for %s: %v", name, err)
+		}
+		results = append(results, result)
+	}
+
+	AttributeUnknownKeys(results)
+
+	messages := func(r *model.ValidationResult) map[string]model.Finding {
+		m := map[string]model.Finding{}
+		for _, f := range r.Errors() {
+			m[f.KeyPath] = f
+		}
+		return m
+	}
+	plain, schema := messages(results[0]), messages(results[1])
+
+	if got := plain["auth"].DisplayMessage(); !strings.Contains(got, `(known to chart "test-chart-with-schema`) {
+		t.Errorf("expected auth to be attributed to test-chart-with-schema, got %q", got)
+	}
+	if got := schema["service"].DisplayMessage(); !strings.Contains(got, `(known to chart "test-chart`) {
+		t.Errorf("expected service to be attributed to test-chart, got %q", got)
+	}
+	if msg := plain["auth"].Message; strings.Contains(msg, "known to") {
+		t.Errorf("expected the attribution to stay out of the message, got %q", msg)
+	}
+	for _, m := range []map[string]model.Finding{plain, schema} {
+		if f, ok := m["bogus"]; !ok || len(f.KnownTo) > 0 {
+			t.Errorf("expected bogus to be unknown to both charts without attribution, got %v", f.KnownTo)
+		}
+	}
+}
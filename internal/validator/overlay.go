@@ -0,0 +1,144 @@
+package validator
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/chrishham/helm-values-checker/internal/chart"
+	"github.com/chrishham/helm-values-checker/internal/model"
+	"gopkg.in/yaml.v3"
+)
+
+// ValidateOverlays validates the effective values of a base file with
+// overlay files merged over it in order, as with Kustomize-style patches:
+// mappings are merged key by key, and scalars and lists replace what came
+// before. Findings are reported against the merged tree, and each records
+// in File the file its position comes from, with SourceLine from that
+// file. Findings about the tree as a whole name no file.
+func ValidateOverlays(base string, overlays []string, resolved *chart.ResolvedChart, opts Options) (*model.ValidationResult, error) {
+	files := append([]string{base}, overlays...)
+	contents := make([][]byte, len(files))
+	nodeFiles := map[*yaml.Node]int{}
+
+	var merged *yaml.Node
+	for i, file := range files {
+		data, err := readValuesFile(file)
+		if err != nil {
+			return nil, err
+		}
+		doc, err := decodeUserValues(file, data, opts)
+		if err != nil {
+			return nil, err
+		}
+		node, err := topLevelMapping(doc)
+		if err != nil {
+			return nil, fmt.Errorf("values file %s: %w", file, err)
+		}
+		contents[i] = data
+		recordNodeFiles(node, i, nodeFiles)
+		if merged == nil {
+			merged = node
+		} else {
+			merged = mergeOverrides(merged, node)
+		}
+	}
+
+	result, err := ValidateNodeWithOptions(merged, resolved, opts)
+	if err != nil {
+		return nil, fmt.Errorf("values files %s: %w", strings.Join(files, ", "), err)
+	}
+	result.ValuesFile = strings.Join(files, " + ")
+	for i := range result.Findings {
+		f := &result.Findings[i]
+		idx, ok := findingSource(merged, f, nodeFiles)
+		if !ok {
+			continue
+		}
+		f.File = files[idx]
+		attachSourceLines(result.Findings[i:i+1], contents[idx])
+	}
+	return result, nil
+}
+
+// recordNodeFiles records file as the source of every node under node.
+// Aliases are not followed; the nodes they point to are recorded where
+// they are defined.
+func recordNodeFiles(node *yaml.Node, file int, nodeFiles map[*yaml.Node]int) {
+	nodeFiles[node] = file
+	if node.Kind == yaml.MappingNode || node.Kind == yaml.SequenceNode {
+		for _, child := range node.Content {
+			recordNodeFiles(child, file, nodeFiles)
+		}
+	}
+}
+
+// findingSource returns the file of the merged tree's node at the
+// finding's position: the node that survived the merge, whichever file
+// last set its path. When nodes from several files share the position,
+// the one at or above the finding's key path is preferred. Findings
+// without a line, or whose position no single node explains, have none.
+func findingSource(merged *yaml.Node, f *model.Finding, nodeFiles map[*yaml.Node]int) (int, bool) {
+	if f.Line == 0 {
+		return 0, false
+	}
+	type candidate struct {
+		path string
+		file int
+	}
+	var candidates []candidate
+	var walk func(node *yaml.Node, path string)
+	walk = func(node *yaml.Node, path string) {
+		if node.Line == f.Line && (f.Column == 0 || node.Column == f.Column) {
+			if file, ok := nodeFile(node, nodeFiles); ok {
+				candidates = append(candidates, candidate{path, file})
+			}
+		}
+		switch node.Kind {
+		case yaml.MappingNode:
+			for i := 0; i+1 < len(node.Content); i += 2 {
+				childPath := joinPath(path, node.Content[i].Value)
+				walk(node.Content[i], childPath)
+				walk(node.Content[i+1], childPath)
+			}
+		case yaml.SequenceNode:
+			for idx, elem := range node.Content {
+				walk(elem, fmt.Sprintf("%s[%d]", path, idx))
+			}
+		}
+	}
+	walk(merged, "")
+
+	files := map[int]bool{}
+	for _, c := range candidates {
+		files[c.file] = true
+	}
+	if len(files) > 1 {
+		files = map[int]bool{}
+		for _, c := range candidates {
+			if c.path != "" && withinAny(f.KeyPath, []string{c.path}) {
+				files[c.file] = true
+			}
+		}
+	}
+	if len(files) != 1 {
+		return 0, false
+	}
+	for file := range files {
+		return file, true
+	}
+	return 0, false
+}
+
+// nodeFile returns the file a merged node came from. Mappings merged from
+// several files are copies of the first file's mapping, so they are
+// attributed through their first key, which that file set.
+func nodeFile(node *yaml.Node, nodeFiles map[*yaml.Node]int) (int, bool) {
+	if file, ok := nodeFiles[node]; ok {
+		return file, true
+	}
+	if node.Kind == yaml.MappingNode && len(node.Content) > 0 {
+		file, ok := nodeFiles[node.Content[0]]
+		return file, ok
+	}
+	return 0, false
+}
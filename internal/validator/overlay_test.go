@@ -0,0 +1,74 @@
+package validator
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/chrishham/helm-values-checker/internal/chart"
+	"github.com/chrishham/helm-values-checker/internal/model"
+)
+
+func TestValidateOverlays_AttributesFindings(t *testing.T) {
+	resolved, err := chart.Resolve(filepath.Join(testdataDir(), "test-chart"), "")
+	if err != nil {
+		t.Fatalf("failed to resolve chart: %v", err)
+	}
+	defer resolved.Cleanup()
+
+	dir := writeValuesDir(t, map[string]string{
+		"base.yaml":  "replicaCount: two\nimage:\n  repository: nginx\n  tag: \"1.25\"\n",
+		"patch.yaml": "# production\nreplicaCount: 3\nimage:\n  tag: \"1.26\"\n  regsitry: docker.io\n",
+		"extra.yaml": "service:\n  port: eighty\n",
+	})
+	base, patch, extra := filepath.Join(dir, "base.yaml"), filepath.Join(dir, "patch.yaml"), filepath.Join(dir, "extra.yaml")
+
+	result, err := ValidateOverlays(base, []string{patch, extra}, resolved, Options{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.ValuesFile != base+" + "+patch+" + "+extra {
+		t.Errorf("expected the result to name every file, got %q", result.ValuesFile)
+	}
+
+	byPath := map[string]model.Finding{}
+	for _, f := range result.Findings {
+		byPath[f.KeyPath] = f
+	}
+	if _, ok := byPath["replicaCount"]; ok {
+		t.Error("expected the overlay's replicaCount to replace the base's mistyped one")
+	}
+	unknown, ok := byPath["image.regsitry"]
+	if !ok || unknown.Rule != model.RuleUnknownKey || unknown.File != patch || unknown.Line != 5 || unknown.SourceLine != "regsitry: docker.io" {
+		t.Errorf("expected the unknown key to be attributed to line 5 of the overlay, got %+v", unknown)
+	}
+	if mismatch := byPath["service.port"]; mismatch.File != extra || mismatch.Line != 2 {
+		t.Errorf("expected the type mismatch to be attributed to the last overlay, got %+v", mismatch)
+	}
+
+	// A parent key both files set keeps the base's key node in the merged
+	// tree, so a finding at its line belongs to the base
+	schemaChart, err := chart.Resolve(filepath.Join(testdataDir(), "test-chart-with-schema"), "")
+	if err != nil {
+		t.Fatalf("failed to resolve chart: %v", err)
+	}
+	defer schemaChart.Cleanup()
+
+	dir = writeValuesDir(t, map[string]string{
+		"base.yaml":  "auth:\n  username: bob\n",
+		"patch.yaml": "# c\n# c\n# c\nauth:\n  username: alice\n",
+	})
+	base, patch = filepath.Join(dir, "base.yaml"), filepath.Join(dir, "patch.yaml")
+	result, err = ValidateOverlays(base, []string{patch}, schemaChart, Options{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var required *model.Finding
+	for i, f := range result.Findings {
+		if f.Rule == model.RuleSchemaRequired && f.KeyPath == "auth" {
+			required = &result.Findings[i]
+		}
+	}
+	if required == nil || required.File != base || required.Line != 1 || required.SourceLine != "auth:" {
+		t.Errorf("expected the missing auth.password to be attributed to line 1 of the base, got %+v", required)
+	}
+}
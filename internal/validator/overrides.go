@@ -0,0 +1,77 @@
+package validator
+
+import (
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+	"helm.sh/helm/v3/pkg/strvals"
+)
+
+// ParseOverrides builds a values tree from Helm-style --set and --set-string
+// expressions, applied in that order as helm install does. The returned
+// nodes carry no line numbers since they do not come from a file.
+func ParseOverrides(set, setString []string) (*yaml.Node, error) {
+	values := make(map[string]interface{})
+	for _, s := range set {
+		if err := strvals.ParseInto(s, values); err != nil {
+			return nil, fmt.Errorf("parsing --set %q: %w", s, err)
+		}
+	}
+	for _, s := range setString {
+		if err := strvals.ParseIntoString(s, values); err != nil {
+			return nil, fmt.Errorf("parsing --set-string %q: %w", s, err)
+		}
+	}
+
+	data, err := yaml.Marshal(values)
+	if err != nil {
+		return nil, fmt.Errorf("marshaling overrides: %w", err)
+	}
+	doc := &yaml.Node{}
+	if err := yaml.Unmarshal(data, doc); err != nil {
+		return nil, fmt.Errorf("unmarshaling overrides: %w", err)
+	}
+
+	node, err := topLevelMapping(doc)
+	if err != nil {
+		return nil, err
+	}
+	clearPositions(node)
+	return node, nil
+}
+
+// clearPositions zeroes line and column information throughout a tree.
+func clearPositions(node *yaml.Node) {
+	node.Line, node.Column = 0, 0
+	for _, child := range node.Content {
+		clearPositions(child)
+	}
+}
+
+// mergeOverrides returns base with override deep-merged over it: mappings
+// are merged key by key, and any other override value replaces the base
+// value. Neither input is modified.
+func mergeOverrides(base, override *yaml.Node) *yaml.Node {
+	base, override = resolveAlias(base), resolveAlias(override)
+	if base.Kind != yaml.MappingNode || override.Kind != yaml.MappingNode {
+		return override
+	}
+
+	merged := *base
+	merged.Content = append([]*yaml.Node(nil), base.Content...)
+	for i := 0; i+1 < len(override.Content); i += 2 {
+		key := override.Content[i].Value
+		replaced := false
+		for j := 0; j+1 < len(merged.Content); j += 2 {
+			if merged.Content[j].Value == key {
+				merged.Content[j+1] = mergeOverrides(merged.Content[j+1], override.Content[i+1])
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			merged.Content = append(merged.Content, override.Content[i], override.Content[i+1])
+		}
+	}
+	return &merged
+}
@@ -0,0 +1,75 @@
+package validator
+
+import (
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestParseOverrides(t *testing.T) {
+	node, err := ParseOverrides([]string{"replicaCount=3,image.tag=1.25"}, []string{"service.port=8080"})
+	if err != nil {
+		t.Fatalf("ParseOverrides error: %v", err)
+	}
+
+	if n := findNodeForPath(node, "replicaCount"); n == nil || n.ShortTag() != "!!int" || n.Line != 0 {
+		t.Errorf("expected replicaCount as an int without a line, got %+v", n)
+	}
+	if n := findNodeForPath(node, "image.tag"); n == nil || n.Value != "1.25" {
+		t.Errorf("expected image.tag override, got %+v", n)
+	}
+	if n := findNodeForPath(node, "service.port"); n == nil || n.ShortTag() != "!!str" {
+		t.Errorf("expected --set-string to produce a string, got %+v", n)
+	}
+}
+
+func TestParseOverrides_Invalid(t *testing.T) {
+	if _, err := ParseOverrides([]string{"a.b[oops=1"}, nil); err == nil {
+		t.Error("expected an error for a malformed --set expression")
+	}
+}
+
+func TestMergeOverrides(t *testing.T) {
+	base := parseYAML(t, `
+replicaCount: 1
+image:
+  repository: nginx
+  tag: latest
+`)
+	override := parseYAML(t, `
+image:
+  tag: "1.25"
+service:
+  port: 80
+`)
+	merged := mergeOverrides(base, override)
+
+	if n := findNodeForPath(merged, "image.repository"); n == nil || n.Value != "nginx" {
+		t.Errorf("expected image.repository to be kept, got %+v", n)
+	}
+	if n := findNodeForPath(merged, "image.tag"); n == nil || n.Value != "1.25" {
+		t.Errorf("expected image.tag to be overridden, got %+v", n)
+	}
+	if n := findNodeForPath(merged, "service.port"); n == nil || n.Value != "80" {
+		t.Errorf("expected service.port to be added, got %+v", n)
+	}
+	if n := findNodeForPath(base, "image.tag"); n.Value != "latest" {
+		t.Errorf("expected base to be left unmodified, got image.tag=%q", n.Value)
+	}
+	if findNodeForPath(base, "service") != nil {
+		t.Error("expected base to be left unmodified, but service was added")
+	}
+}
+
+func TestMergeOverrides_ReplacesNonMapping(t *testing.T) {
+	base := parseYAML(t, `
+tags: [a, b]
+`)
+	override := parseYAML(t, `
+tags: c
+`)
+	merged := mergeOverrides(base, override)
+	if n := findNodeForPath(merged, "tags"); n == nil || n.Kind != yaml.ScalarNode || n.Value != "c" {
+		t.Errorf("expected tags to be replaced by a scalar, got %+v", n)
+	}
+}
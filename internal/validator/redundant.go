@@ -0,0 +1,70 @@
+package validator
+
+import (
+	"fmt"
+
+	"github.com/chrishham/helm-values-checker/internal/model"
+	"gopkg.in/yaml.v3"
+)
+
+// detectRedundantValues reports user scalars that repeat the chart default
+// at the same path, with the same tag and value, since removing them would
+// not change the rendered chart. Only mappings with a non-empty default
+// mapping are descended into: keys under an empty default ({}) have no
+// default of their own, and sequences are replaced as a whole by Helm.
+func detectRedundantValues(userNode, defaultsNode *yaml.Node, ignoreKeys []string, path string) []model.Finding {
+	var findings []model.Finding
+
+	if userNode == nil || defaultsNode == nil {
+		return findings
+	}
+	userNode, defaultsNode = resolveAlias(userNode), resolveAlias(defaultsNode)
+	if userNode.Kind != yaml.MappingNode || defaultsNode.Kind != yaml.MappingNode || len(defaultsNode.Content) == 0 {
+		return findings
+	}
+
+	for i := 0; i+1 < len(userNode.Content); i += 2 {
+		key := userNode.Content[i].Value
+		childPath := joinPath(path, key)
+		if matchesIgnore(childPath, ignoreKeys) {
+			continue
+		}
+
+		defaultVal := getValueForKey(defaultsNode, key)
+		if defaultVal == nil {
+			continue
+		}
+		valNode := resolveAlias(userNode.Content[i+1])
+
+		if valNode.Kind == yaml.MappingNode {
+			findings = append(findings, detectRedundantValues(valNode, defaultVal, ignoreKeys, childPath)...)
+			continue
+		}
+		if valNode.Kind != yaml.ScalarNode || defaultVal.Kind != yaml.ScalarNode {
+			continue
+		}
+		if valNode.ShortTag() != defaultVal.ShortTag() || valNode.Value != defaultVal.Value {
+			continue
+		}
+
+		findings = append(findings, model.Finding{
+			Severity: model.SeverityWarning,
+			Rule:     model.RuleRedundantValue,
+			Line:     userNode.Content[i].Line,
+			Column:   userNode.Content[i].Column,
+			KeyPath:  childPath,
+			Message:  fmt.Sprintf("Value at %q matches the chart default (%s) and can be removed", childPath, describeScalar(valNode)),
+		})
+	}
+
+	return findings
+}
+
+// describeScalar renders a scalar for a message, quoting strings so that
+// empty or blank values stay visible.
+func describeScalar(node *yaml.Node) string {
+	if node.ShortTag() == "!!str" {
+		return fmt.Sprintf("%q", node.Value)
+	}
+	return node.Value
+}
@@ -0,0 +1,76 @@
+package validator
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/chrishham/helm-values-checker/internal/chart"
+	"github.com/chrishham/helm-values-checker/internal/model"
+)
+
+func TestDetectRedundantValues(t *testing.T) {
+	defaults := parseYAML(t, `
+replicaCount: 1
+image:
+  tag: latest
+  pullPolicy: IfNotPresent
+port: "80"
+nodeSelector: {}
+tolerations:
+  - key: a
+`)
+	user := parseYAML(t, `
+replicaCount: 1
+image:
+  tag: latest
+  pullPolicy: Always
+port: 80
+nodeSelector:
+  disk: ssd
+tolerations:
+  - key: a
+`)
+	findings := detectRedundantValues(user, defaults, nil, "")
+	if len(findings) != 2 {
+		t.Fatalf("expected 2 findings, got %d: %v", len(findings), findings)
+	}
+	if findings[0].KeyPath != "replicaCount" || findings[0].Line != 2 || findings[0].Rule != model.RuleRedundantValue {
+		t.Errorf("expected replicaCount on line 2, got %+v", findings[0])
+	}
+	if findings[1].KeyPath != "image.tag" || findings[1].Severity != model.SeverityWarning {
+		t.Errorf("expected a warning for image.tag, got %+v", findings[1])
+	}
+}
+
+func TestDetectRedundantValues_IgnoreKeys(t *testing.T) {
+	defaults := parseYAML(t, "replicaCount: 1\n")
+	user := parseYAML(t, "replicaCount: 1\n")
+	if findings := detectRedundantValues(user, defaults, []string{"replicaCount"}, ""); len(findings) != 0 {
+		t.Errorf("expected ignored key to be skipped, got %v", findings)
+	}
+}
+
+func TestValidate_WarnRedundant(t *testing.T) {
+	resolved, err := chart.Resolve(filepath.Join(testdataDir(), "test-chart"), "")
+	if err != nil {
+		t.Fatalf("failed to resolve chart: %v", err)
+	}
+	defer resolved.Cleanup()
+
+	data := []byte("replicaCount: 1\n")
+	result, err := ValidateBytesWithOptions("values.yaml", data, resolved, Options{})
+	if err != nil {
+		t.Fatalf("validation error: %v", err)
+	}
+	if len(result.Findings) != 0 {
+		t.Errorf("expected no findings without WarnRedundant, got %v", result.Findings)
+	}
+
+	result, err = ValidateBytesWithOptions("values.yaml", data, resolved, Options{WarnRedundant: true})
+	if err != nil {
+		t.Fatalf("validation error: %v", err)
+	}
+	if len(result.Findings) != 1 || result.Findings[0].KeyPath != "replicaCount" || result.Findings[0].Message != `Value at "replicaCount" matches the chart default (1) and can be removed` {
+		t.Errorf("expected replicaCount to be flagged, got %v", result.Findings)
+	}
+}
@@ -0,0 +1,54 @@
+package validator
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// remoteValuesTimeout bounds the whole request for a remote values file,
+// including reading the body.
+const remoteValuesTimeout = 30 * time.Second
+
+// remoteValuesClient fetches values files given as http(s) URLs.
+var remoteValuesClient = &http.Client{Timeout: remoteValuesTimeout}
+
+// isValuesURL reports whether a values file argument is a URL rather than
+// a local path. Any scheme counts, so that unsupported ones are refused
+// instead of being looked up on disk.
+func isValuesURL(valuesFile string) bool {
+	return strings.Contains(valuesFile, "://")
+}
+
+// fetchValuesFile downloads a values file over http or https, enforcing
+// maxValuesFileSize while reading.
+func fetchValuesFile(rawURL string) ([]byte, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid values URL %s: %w", rawURL, err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("unsupported scheme %q for values file %s: only http and https are supported", u.Scheme, rawURL)
+	}
+
+	resp, err := remoteValuesClient.Get(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("fetching values file %s: %w", rawURL, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetching values file %s: %s", rawURL, resp.Status)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxValuesFileSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("fetching values file %s: %w", rawURL, err)
+	}
+	if len(data) > maxValuesFileSize {
+		return nil, fmt.Errorf("values file %s is too large (max %d bytes)", rawURL, maxValuesFileSize)
+	}
+	return data, nil
+}
@@ -0,0 +1,62 @@
+package validator
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/chrishham/helm-values-checker/internal/chart"
+)
+
+func TestValidateWithOptions_RemoteValuesFile(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/env/values.yaml" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte("replicaCount: 2\nimage:\n  regsitry: docker.io\n"))
+	}))
+	defer srv.Close()
+
+	resolved, err := chart.Resolve(filepath.Join(testdataDir(), "test-chart"), "")
+	if err != nil {
+		t.Fatalf("failed to resolve chart: %v", err)
+	}
+	defer resolved.Cleanup()
+
+	url := srv.URL + "/env/values.yaml"
+	result, err := ValidateWithOptions(url, resolved, Options{})
+	if err != nil {
+		t.Fatalf("validation error: %v", err)
+	}
+	if result.ValuesFile != url {
+		t.Errorf("expected the URL to be reported as the values file, got %q", result.ValuesFile)
+	}
+	errs := result.Errors()
+	if len(errs) != 1 || errs[0].KeyPath != "image.regsitry" {
+		t.Errorf("expected the misspelled key to be flagged, got %+v", result.Findings)
+	}
+
+	if _, err := ValidateWithOptions(srv.URL+"/missing.yaml", resolved, Options{}); err == nil || !strings.Contains(err.Error(), "404") {
+		t.Errorf("expected a 404 error, got %v", err)
+	}
+}
+
+func TestFetchValuesFile_RefusesOtherSchemes(t *testing.T) {
+	if _, err := fetchValuesFile("ftp://example.com/values.yaml"); err == nil || !strings.Contains(err.Error(), "unsupported scheme") {
+		t.Errorf("expected ftp to be refused, got %v", err)
+	}
+}
+
+func TestFetchValuesFile_TooLarge(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("key: " + strings.Repeat("x", maxValuesFileSize) + "\n"))
+	}))
+	defer srv.Close()
+
+	if _, err := fetchValuesFile(srv.URL); err == nil || !strings.Contains(err.Error(), "too large") {
+		t.Errorf("expected a size error, got %v", err)
+	}
+}
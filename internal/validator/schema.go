@@ -3,15 +3,21 @@ package validator
 import (
 	"encoding/json"
 	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
 	"strings"
+	"unicode/utf8"
 
+	"github.com/chrishham/helm-values-checker/internal/chart"
 	"github.com/chrishham/helm-values-checker/internal/model"
 	"github.com/xeipuuv/gojsonschema"
 	"gopkg.in/yaml.v3"
 )
 
 // SchemaTypeMap maps dot-separated property paths to the allowed JSON Schema
-// type strings (e.g., "maxRetries" → ["integer", "null"]).
+// type strings (e.g., "maxRetries" → ["integer", "null"]). Array item types
+// use a "[*]" suffix (e.g., "tags[*]" → ["string"]).
 type SchemaTypeMap map[string][]string
 
 // extractSchemaTypes parses a JSON schema and returns a map of property paths
@@ -27,11 +33,16 @@ func extractSchemaTypes(schemaBytes []byte) SchemaTypeMap {
 		return types
 	}
 
-	walkSchemaTypes(schema, "", types)
+	walkSchemaTypes(schema, schema, "", types, nil)
 	return types
 }
 
-func walkSchemaTypes(schema map[string]interface{}, path string, types SchemaTypeMap) {
+func walkSchemaTypes(root, schema map[string]interface{}, path string, types SchemaTypeMap, seen map[string]bool) {
+	schema, seen = derefSchema(root, schema, seen)
+	if schema == nil {
+		return
+	}
+
 	props, ok := schema["properties"].(map[string]interface{})
 	if !ok {
 		return
@@ -42,30 +53,403 @@ func walkSchemaTypes(schema map[string]interface{}, path string, types SchemaTyp
 		if !ok {
 			continue
 		}
+		propDef, propSeen := derefSchema(root, propDef, seen)
+		if propDef == nil {
+			continue
+		}
 
 		fullPath := joinPath(path, name)
+		if typeList := schemaTypeList(propDef); len(typeList) > 0 {
+			types[fullPath] = typeList
+		}
 
-		// Collect type(s) — handles both "type": "string" and "type": ["string", "null"]
-		switch t := propDef["type"].(type) {
-		case string:
-			types[fullPath] = []string{t}
+		// Array item types are recorded under "<path>[*]"
+		if items, ok := propDef["items"].(map[string]interface{}); ok {
+			if items, itemSeen := derefSchema(root, items, propSeen); items != nil {
+				itemPath := fullPath + "[*]"
+				if typeList := schemaTypeList(items); len(typeList) > 0 {
+					types[itemPath] = typeList
+				}
+				walkSchemaTypes(root, items, itemPath, types, itemSeen)
+			}
+		}
+
+		// Recurse into nested properties
+		walkSchemaTypes(root, propDef, fullPath, types, propSeen)
+	}
+}
+
+// extractIntegerFormats returns the property paths whose schema declares
+// an int32 or int64 format, mapped to that format. Array items use the
+// "[*]" suffix, as in SchemaTypeMap.
+func extractIntegerFormats(schemaBytes []byte) map[string]string {
+	formats := make(map[string]string)
+	if len(schemaBytes) == 0 {
+		return formats
+	}
+
+	var schema map[string]interface{}
+	if err := json.Unmarshal(schemaBytes, &schema); err != nil {
+		return formats
+	}
+
+	walkIntegerFormats(schema, schema, "", formats, nil)
+	return formats
+}
+
+func walkIntegerFormats(root, schema map[string]interface{}, path string, formats map[string]string, seen map[string]bool) {
+	schema, seen = derefSchema(root, schema, seen)
+	if schema == nil {
+		return
+	}
+
+	props, ok := schema["properties"].(map[string]interface{})
+	if !ok {
+		return
+	}
+
+	for name, v := range props {
+		propDef, ok := v.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		propDef, propSeen := derefSchema(root, propDef, seen)
+		if propDef == nil {
+			continue
+		}
+
+		fullPath := joinPath(path, name)
+		if format := integerFormat(propDef); format != "" {
+			formats[fullPath] = format
+		}
+
+		if items, ok := propDef["items"].(map[string]interface{}); ok {
+			if items, itemSeen := derefSchema(root, items, propSeen); items != nil {
+				itemPath := fullPath + "[*]"
+				if format := integerFormat(items); format != "" {
+					formats[itemPath] = format
+				}
+				walkIntegerFormats(root, items, itemPath, formats, itemSeen)
+			}
+		}
+
+		walkIntegerFormats(root, propDef, fullPath, formats, propSeen)
+	}
+}
+
+// integerFormat returns a schema node's "format" if it is int32 or int64.
+func integerFormat(def map[string]interface{}) string {
+	switch format, _ := def["format"].(string); format {
+	case "int32", "int64":
+		return format
+	}
+	return ""
+}
+
+// schemaTypeList returns the declared type(s) of a schema node. It handles
+// both "type": "string" and "type": ["string", "null"], and falls back to
+// an anyOf/oneOf union of simple type-only branches.
+func schemaTypeList(def map[string]interface{}) []string {
+	switch t := def["type"].(type) {
+	case string:
+		return []string{t}
+	case []interface{}:
+		var typeList []string
+		for _, item := range t {
+			if s, ok := item.(string); ok {
+				typeList = append(typeList, s)
+			}
+		}
+		return typeList
+	}
+	return unionTypeList(def)
+}
+
+// unionTypeList returns the combined types of an anyOf or oneOf whose
+// branches each declare only a type (e.g., [{type: string}, {type: integer}]).
+// Branches with other constraints make the union opaque, so nil is returned
+// and gojsonschema alone judges the value.
+func unionTypeList(def map[string]interface{}) []string {
+	for _, keyword := range []string{"anyOf", "oneOf"} {
+		branches, ok := def[keyword].([]interface{})
+		if !ok || len(branches) == 0 {
+			continue
+		}
+
+		var typeList []string
+		seen := make(map[string]bool)
+		for _, b := range branches {
+			branch, ok := b.(map[string]interface{})
+			if !ok || !isTypeOnlySchema(branch) {
+				return nil
+			}
+			branchTypes := schemaTypeList(branch)
+			if len(branchTypes) == 0 {
+				return nil
+			}
+			for _, t := range branchTypes {
+				if !seen[t] {
+					seen[t] = true
+					typeList = append(typeList, t)
+				}
+			}
+		}
+		return typeList
+	}
+	return nil
+}
+
+// isTypeOnlySchema reports whether a schema constrains nothing but its type,
+// ignoring annotations.
+func isTypeOnlySchema(def map[string]interface{}) bool {
+	for key := range def {
+		switch key {
+		case "type", "title", "description":
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// seqIndexRe matches sequence indices in finding paths (e.g., "hosts[0]").
+var seqIndexRe = regexp.MustCompile(`\[\d+\]`)
+
+// schemaPath converts a finding path with concrete sequence indices into
+// the SchemaTypeMap key convention ("hosts[0].name" → "hosts[*].name").
+func schemaPath(path string) string {
+	return seqIndexRe.ReplaceAllString(path, "[*]")
+}
+
+// derefSchema follows in-document "$ref" pointers (e.g., "#/definitions/port")
+// until it reaches a schema without a $ref. seen holds the refs already followed
+// on the current walk path; a ref that repeats is a cycle and yields nil.
+// External refs are never followed (they are rejected by containsExternalRef
+// before validation), and unresolvable pointers leave the schema unchanged.
+func derefSchema(root, schema map[string]interface{}, seen map[string]bool) (map[string]interface{}, map[string]bool) {
+	for {
+		ref, ok := schema["$ref"].(string)
+		if !ok || !strings.HasPrefix(ref, "#") {
+			return schema, seen
+		}
+		if seen[ref] {
+			return nil, seen
+		}
+		target := resolveRef(root, ref)
+		if target == nil {
+			return schema, seen
+		}
+
+		next := make(map[string]bool, len(seen)+1)
+		for k := range seen {
+			next[k] = true
+		}
+		next[ref] = true
+		schema, seen = target, next
+	}
+}
+
+// resolveRef resolves a fragment-only JSON pointer such as "#/definitions/port"
+// against the root schema. Returns nil if the pointer does not resolve to an object.
+func resolveRef(root map[string]interface{}, ref string) map[string]interface{} {
+	pointer := strings.TrimPrefix(ref, "#")
+	if pointer == "" {
+		return root
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return nil
+	}
+
+	var cur interface{} = root
+	for _, token := range strings.Split(pointer[1:], "/") {
+		token = strings.ReplaceAll(token, "~1", "/")
+		token = strings.ReplaceAll(token, "~0", "~")
+		switch node := cur.(type) {
+		case map[string]interface{}:
+			next, ok := node[token]
+			if !ok {
+				return nil
+			}
+			cur = next
 		case []interface{}:
-			var typeList []string
-			for _, item := range t {
-				if s, ok := item.(string); ok {
-					typeList = append(typeList, s)
+			idx, err := strconv.Atoi(token)
+			if err != nil || idx < 0 || idx >= len(node) {
+				return nil
+			}
+			cur = node[idx]
+		default:
+			return nil
+		}
+	}
+
+	resolved, _ := cur.(map[string]interface{})
+	return resolved
+}
+
+// checkSchemaDrafts warns once per schema, the chart's and each subchart's,
+// that declares a JSON Schema draft gojsonschema does not implement. Such
+// schemas are still validated, but keywords added after draft-07 are
+// silently ignored.
+func checkSchemaDrafts(parentBytes []byte, subcharts map[string][]byte) []model.Finding {
+	var findings []model.Finding
+	add := func(owner string, schema []byte) {
+		draft := chart.SchemaDraft(schema)
+		if chart.SupportedSchemaDraft(draft) {
+			return
+		}
+		findings = append(findings, model.Finding{
+			Severity: model.SeverityWarning,
+			Rule:     model.RuleSchemaDraft,
+			Message: fmt.Sprintf("%s declares JSON Schema %s, which is not supported: only draft-04 to draft-07 keywords are checked, "+
+				"so newer ones (e.g., prefixItems, dependentRequired, unevaluatedProperties) are ignored", owner, draft),
+		})
+	}
+
+	add("Chart schema", parentBytes)
+	names := make([]string, 0, len(subcharts))
+	for name := range subcharts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		add(fmt.Sprintf("Schema of subchart %q", name), subcharts[name])
+	}
+	return findings
+}
+
+// mergeSubchartSchemas returns the parent schema with each subchart schema
+// deep-merged under properties.<name>, so values set under a subchart key are
+// validated against that subchart's schema in a single pass. In-document
+// $refs in a subchart schema are rewritten to point at its new location.
+// Returns parentBytes unchanged when there is nothing to merge or it cannot
+// be parsed (validateSchema reports the parse error).
+func mergeSubchartSchemas(parentBytes []byte, subcharts map[string][]byte) []byte {
+	if len(subcharts) == 0 {
+		return parentBytes
+	}
+
+	parent := map[string]interface{}{"type": "object"}
+	if len(parentBytes) > 0 {
+		if err := json.Unmarshal(parentBytes, &parent); err != nil {
+			return parentBytes
+		}
+	}
+
+	props, _ := parent["properties"].(map[string]interface{})
+	if props == nil {
+		props = make(map[string]interface{})
+	}
+
+	names := make([]string, 0, len(subcharts))
+	for name := range subcharts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	merged := false
+	for _, name := range names {
+		var sub map[string]interface{}
+		if err := json.Unmarshal(subcharts[name], &sub); err != nil {
+			continue
+		}
+		delete(sub, "$schema")
+		delete(sub, "$id")
+		rebaseRefs(sub, "#/properties/"+escapePointerToken(name))
+
+		if existing, ok := props[name].(map[string]interface{}); ok {
+			deepMergeSchema(existing, sub)
+		} else {
+			props[name] = sub
+		}
+		merged = true
+	}
+	if !merged {
+		return parentBytes
+	}
+	parent["properties"] = props
+
+	out, err := json.Marshal(parent)
+	if err != nil {
+		return parentBytes
+	}
+	return out
+}
+
+// deepMergeSchema merges src into dst. Nested properties are merged
+// recursively, required lists are unioned, and any other keyword already
+// set in dst wins.
+func deepMergeSchema(dst, src map[string]interface{}) {
+	for k, v := range src {
+		switch k {
+		case "properties":
+			srcProps, ok := v.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			dstProps, ok := dst["properties"].(map[string]interface{})
+			if !ok {
+				dst["properties"] = srcProps
+				continue
+			}
+			for name, sp := range srcProps {
+				dp, dok := dstProps[name].(map[string]interface{})
+				spMap, sok := sp.(map[string]interface{})
+				if dok && sok {
+					deepMergeSchema(dp, spMap)
+				} else if _, exists := dstProps[name]; !exists {
+					dstProps[name] = sp
+				}
+			}
+		case "required":
+			srcReq, ok := v.([]interface{})
+			if !ok {
+				continue
+			}
+			dstReq, _ := dst["required"].([]interface{})
+			seen := make(map[interface{}]bool)
+			for _, r := range dstReq {
+				seen[r] = true
+			}
+			for _, r := range srcReq {
+				if !seen[r] {
+					dstReq = append(dstReq, r)
+					seen[r] = true
 				}
 			}
-			if len(typeList) > 0 {
-				types[fullPath] = typeList
+			dst["required"] = dstReq
+		default:
+			if _, exists := dst[k]; !exists {
+				dst[k] = v
 			}
 		}
+	}
+}
 
-		// Recurse into nested properties
-		walkSchemaTypes(propDef, fullPath, types)
+// rebaseRefs rewrites in-document $ref pointers in v so they resolve from
+// base (e.g., "#/definitions/x" → "#/properties/redis/definitions/x").
+func rebaseRefs(v interface{}, base string) {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		for k, child := range val {
+			if s, ok := child.(string); ok && k == "$ref" && strings.HasPrefix(s, "#") {
+				val[k] = base + strings.TrimPrefix(s, "#")
+				continue
+			}
+			rebaseRefs(child, base)
+		}
+	case []interface{}:
+		for _, item := range val {
+			rebaseRefs(item, base)
+		}
 	}
 }
 
+// escapePointerToken escapes a property name for use in a JSON pointer.
+func escapePointerToken(s string) string {
+	s = strings.ReplaceAll(s, "~", "~0")
+	return strings.ReplaceAll(s, "/", "~1")
+}
+
 // containsExternalRef recursively walks a parsed JSON structure looking for
 // any "$ref" key whose value is not a fragment-only reference (starting with "#").
 // Returns the offending ref string if found, or empty string if all refs are safe.
@@ -95,8 +479,9 @@ func containsExternalRef(v interface{}) string {
 // validateSchema runs JSON Schema validation on user values, checking
 // required fields and deprecated markers. When schemaTypes is non-nil,
 // invalid_type errors are filtered out because the custom type checker
-// handles those with better messages.
-func validateSchema(userNode *yaml.Node, schemaBytes []byte, ignoreKeys []string, schemaTypes SchemaTypeMap) ([]model.Finding, error) {
+// handles those with better messages. Missing required keys whose full
+// path matches ignoreRequired are not reported.
+func validateSchema(userNode *yaml.Node, schemaBytes []byte, ignoreKeys, ignoreRequired []string, schemaTypes SchemaTypeMap) ([]model.Finding, error) {
 	var findings []model.Finding
 
 	if len(schemaBytes) == 0 {
@@ -111,11 +496,15 @@ func validateSchema(userNode *yaml.Node, schemaBytes []byte, ignoreKeys []string
 	if ref := containsExternalRef(schemaMap); ref != "" {
 		findings = append(findings, model.Finding{
 			Severity: model.SeverityError,
+			Rule:     model.RuleSchemaExternalRef,
 			Message:  fmt.Sprintf("Schema contains external $ref %q which is not allowed for security reasons", ref),
 		})
 		return findings, nil
 	}
 
+	schemaRoot, _ := schemaMap.(map[string]interface{})
+	deprecated := findDeprecatedPaths(schemaRoot, schemaRoot, "", nil)
+
 	// Convert user yaml.Node tree to a generic map for JSON schema validation
 	var userMap interface{}
 	userYAML, err := yaml.Marshal(userNode)
@@ -140,6 +529,11 @@ func validateSchema(userNode *yaml.Node, schemaBytes []byte, ignoreKeys []string
 	}
 
 	for _, e := range result.Errors() {
+		// A failed then/else or allOf is reported through the errors inside it
+		if wrapperErrors[e.Type()] && hasNestedError(result.Errors(), e) {
+			continue
+		}
+
 		// Skip type errors when custom type checker handles them
 		if len(schemaTypes) > 0 && e.Type() == "invalid_type" {
 			continue
@@ -159,11 +553,70 @@ func validateSchema(userNode *yaml.Node, schemaBytes []byte, ignoreKeys []string
 			continue
 		}
 
+		// A failed type-only union is a type error the custom checker reports
+		if (e.Type() == "number_any_of" || e.Type() == "number_one_of") && unionTypeMismatch(userNode, path, schemaTypes) {
+			continue
+		}
+
+		line, column := findPositionForPath(userNode, path)
+		if e.Type() == "required" {
+			prop, _ := e.Details()["property"].(string)
+			full := joinPath(path, prop)
+			if matchesIgnore(full, ignoreRequired) {
+				continue
+			}
+
+			// The schema of a missing key is never evaluated, so keys it
+			// requires in turn are reported here, all at the missing key's line
+			missing := []requiredKey{{parent: path, name: prop}}
+			missing = append(missing, requiredDescendants(schemaRoot, schemaAtPath(schemaRoot, full), full, nil)...)
+			for _, k := range missing {
+				keyPath := joinPath(k.parent, k.name)
+				if k.parent != path && (matchesIgnore(keyPath, ignoreRequired) || matchesIgnore(k.parent, ignoreKeys)) {
+					continue
+				}
+
+				// A required key that is also deprecated is mid-migration: say that
+				// it is still required, with the deprecation message
+				message := fmt.Sprintf("Schema validation: %s is required", keyPath)
+				if k.parent == path {
+					message += conditionalRequirement(schemaRoot, userNode, path, k.name)
+				}
+				if msg, ok := deprecated[keyPath]; ok && k.name != "" {
+					message = requiredDeprecatedMessage(keyPath, msg)
+				}
+				findings = append(findings, model.Finding{
+					Severity: model.SeverityError,
+					Rule:     model.RuleSchemaRequired,
+					Line:     line,
+					Column:   column,
+					KeyPath:  k.parent,
+					Message:  message,
+					DocURL:   schemaDocURL(schemaAtPath(schemaRoot, keyPath)),
+				})
+			}
+			continue
+		}
+
+		message := schemaErrorMessage(e, userNode, schemaRoot, path)
+		if dup, _, _ := duplicateItem(e, userNode, path); dup != nil {
+			line, column = dup.Line, dup.Column
+		}
+		if msg, conflict := exclusiveKeysMessage(e.Type(), userNode, schemaRoot, path); msg != "" {
+			message = msg
+			if l, c := findPositionForPath(userNode, conflict); l > 0 {
+				line, column = l, c
+			}
+		}
+
 		findings = append(findings, model.Finding{
 			Severity: model.SeverityError,
-			Line:     findLineForPath(userNode, path),
+			Rule:     schemaRule(e.Type()),
+			Line:     line,
+			Column:   column,
 			KeyPath:  path,
-			Message:  fmt.Sprintf("Schema validation: %s", e.Description()),
+			Message:  message,
+			DocURL:   schemaDocURL(schemaAtPath(schemaRoot, path)),
 		})
 	}
 
@@ -173,6 +626,512 @@ func validateSchema(userNode *yaml.Node, schemaBytes []byte, ignoreKeys []string
 	return findings, nil
 }
 
+// requiredKey is a key named in the "required" list of the object at parent.
+type requiredKey struct {
+	parent, name string
+}
+
+// requiredDescendants returns the keys required beneath a missing required
+// key at path, following only chains of required object properties, in
+// schema order.
+func requiredDescendants(root, schema map[string]interface{}, path string, seen map[string]bool) []requiredKey {
+	var result []requiredKey
+
+	schema, seen = derefSchema(root, schema, seen)
+	if schema == nil {
+		return result
+	}
+	req, _ := schema["required"].([]interface{})
+	props, _ := schema["properties"].(map[string]interface{})
+	for _, r := range req {
+		name, ok := r.(string)
+		if !ok {
+			continue
+		}
+		result = append(result, requiredKey{parent: path, name: name})
+		if child, ok := props[name].(map[string]interface{}); ok {
+			result = append(result, requiredDescendants(root, child, joinPath(path, name), seen)...)
+		}
+	}
+	return result
+}
+
+// requiredDeprecatedMessage describes a missing key that the schema marks
+// both required and deprecated, using the deprecation message if any.
+func requiredDeprecatedMessage(path, deprecationMsg string) string {
+	message := fmt.Sprintf("Schema validation: %s is required but deprecated", path)
+	if deprecationMsg != "" {
+		message += " - " + deprecationMsg
+	}
+	return message
+}
+
+// wrapperErrors are the gojsonschema error types that only say a
+// subschema failed, alongside the errors from inside it.
+var wrapperErrors = map[string]bool{
+	"condition_then": true,
+	"condition_else": true,
+	"number_all_of":  true,
+}
+
+// hasNestedError reports whether errs holds an error other than a wrapper
+// at or below the field of the wrapper error e.
+func hasNestedError(errs []gojsonschema.ResultError, e gojsonschema.ResultError) bool {
+	field := e.Field()
+	for _, other := range errs {
+		if wrapperErrors[other.Type()] {
+			continue
+		}
+		f := other.Field()
+		if field == "(root)" || f == field || strings.HasPrefix(f, field+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// conditionalRequirement explains a missing key prop of the object at path
+// that the schema requires only through an if/then/else, as in " when
+// persistence.enabled is true" or " unless persistence.enabled is true". It
+// returns "" when prop is required unconditionally or the if condition is
+// not a simple match on property values.
+func conditionalRequirement(schemaRoot map[string]interface{}, userNode *yaml.Node, path, prop string) string {
+	def := schemaAtPath(schemaRoot, path)
+	obj := findNodeForPath(userNode, path)
+	if def == nil || obj == nil || obj.Kind != yaml.MappingNode || requires(def, prop) {
+		return ""
+	}
+
+	conditionals := []map[string]interface{}{def}
+	if all, ok := def["allOf"].([]interface{}); ok {
+		for _, sub := range all {
+			subDef, _ := sub.(map[string]interface{})
+			if subDef, _ = derefSchema(schemaRoot, subDef, nil); subDef != nil {
+				conditionals = append(conditionals, subDef)
+			}
+		}
+	}
+	for _, cond := range conditionals {
+		ifDef, _ := cond["if"].(map[string]interface{})
+		if ifDef, _ = derefSchema(schemaRoot, ifDef, nil); ifDef == nil {
+			continue
+		}
+		clauses, holds, ok := describeCondition(schemaRoot, ifDef, obj, path)
+		if !ok {
+			continue
+		}
+		branch, word := "then", " when "
+		if !holds {
+			branch, word = "else", " unless "
+		}
+		b, _ := cond[branch].(map[string]interface{})
+		if b, _ = derefSchema(schemaRoot, b, nil); b != nil && requires(b, prop) {
+			return word + strings.Join(clauses, " and ")
+		}
+	}
+	return ""
+}
+
+// describeCondition describes an if schema that only pins properties of obj
+// (at path) with const or enum, possibly nested, as clauses such as
+// "persistence.enabled is true", and reports whether obj satisfies all of
+// them. ok is false for any other condition, or when obj leaves a pinned key
+// unset, which JSON Schema treats as a match that would read misleadingly.
+func describeCondition(root, ifDef map[string]interface{}, obj *yaml.Node, path string) (clauses []string, holds, ok bool) {
+	props, _ := ifDef["properties"].(map[string]interface{})
+	if len(props) == 0 {
+		return nil, false, false
+	}
+	for key := range ifDef {
+		if key != "properties" && key != "required" && key != "type" {
+			return nil, false, false
+		}
+	}
+
+	names := make([]string, 0, len(props))
+	for name := range props {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	holds = true
+	for _, name := range names {
+		propDef, _ := props[name].(map[string]interface{})
+		propDef, _ = derefSchema(root, propDef, nil)
+		value := getValueForKey(obj, name)
+		if propDef == nil || value == nil {
+			return nil, false, false
+		}
+		full := joinPath(path, name)
+
+		var allowed []interface{}
+		if c, isConst := propDef["const"]; isConst {
+			allowed = []interface{}{c}
+		} else if enum, isEnum := propDef["enum"].([]interface{}); isEnum && len(enum) > 0 {
+			allowed = enum
+		} else if _, nested := propDef["properties"]; nested && value.Kind == yaml.MappingNode {
+			sub, subHolds, subOK := describeCondition(root, propDef, value, full)
+			if !subOK {
+				return nil, false, false
+			}
+			clauses = append(clauses, sub...)
+			holds = holds && subHolds
+			continue
+		} else {
+			return nil, false, false
+		}
+
+		got := nodeJSONValue(value)
+		match := false
+		values := make([]string, len(allowed))
+		for i, a := range allowed {
+			values[i] = jsonValue(a)
+			match = match || values[i] == got
+		}
+		clauses = append(clauses, fmt.Sprintf("%s is %s", full, orList(values)))
+		holds = holds && match
+	}
+	return clauses, holds, true
+}
+
+// requires reports whether the "required" list of def names prop.
+func requires(def map[string]interface{}, prop string) bool {
+	req, _ := def["required"].([]interface{})
+	for _, r := range req {
+		if r == prop {
+			return true
+		}
+	}
+	return false
+}
+
+// unionTypeMismatch reports whether the value at path has a type outside the
+// union recorded for it in schemaTypes.
+func unionTypeMismatch(userNode *yaml.Node, path string, schemaTypes SchemaTypeMap) bool {
+	allowed, ok := schemaTypes[schemaPath(path)]
+	if !ok {
+		return false
+	}
+	node := findNodeForPath(userNode, path)
+	if node == nil {
+		return false
+	}
+	compatible, _ := schemaTypesCompatible(node.ShortTag(), allowed)
+	return !compatible
+}
+
+// schemaRule maps a gojsonschema error type to a finding rule ID.
+func schemaRule(errType string) string {
+	switch errType {
+	case "required":
+		return model.RuleSchemaRequired
+	case "enum":
+		return model.RuleSchemaEnum
+	case "const":
+		return model.RuleSchemaConst
+	case "number_gte", "number_gt", "number_lte", "number_lt":
+		return model.RuleSchemaRange
+	case "string_gte", "string_lte":
+		return model.RuleSchemaLength
+	case "pattern":
+		return model.RuleSchemaPattern
+	case "format":
+		return model.RuleSchemaFormat
+	case "multiple_of":
+		return model.RuleSchemaMultipleOf
+	case "invalid_type":
+		return model.RuleSchemaType
+	case "number_one_of":
+		return model.RuleSchemaOneOf
+	case "number_any_of":
+		return model.RuleSchemaAnyOf
+	case "number_not", "false":
+		return model.RuleSchemaNot
+	case "condition_then", "condition_else", "number_all_of":
+		return model.RuleSchemaCondition
+	case "array_min_items", "array_max_items", "array_no_additional_items", "contains":
+		return model.RuleSchemaItems
+	case "unique":
+		return model.RuleSchemaUnique
+	case "array_min_properties", "array_max_properties", "additional_property_not_allowed", "invalid_property_pattern", "invalid_property_name":
+		return model.RuleSchemaProperties
+	case "missing_dependency":
+		return model.RuleSchemaDependency
+	default:
+		return model.RuleSchemaConstraint
+	}
+}
+
+// schemaErrorMessage builds a user-facing message for a gojsonschema error.
+// Constraint violations whose default description omits the offending value
+// are rewritten to include the value taken from the user's yaml.Node tree
+// and, where needed, the constraint taken from schemaRoot.
+func schemaErrorMessage(e gojsonschema.ResultError, userNode *yaml.Node, schemaRoot map[string]interface{}, path string) string {
+	details := e.Details()
+	label := path
+	if label == "" {
+		label = "(root)"
+	}
+
+	var value string
+	if node := findNodeForPath(userNode, path); node != nil {
+		value = node.Value
+	}
+
+	switch e.Type() {
+	case "number_gte":
+		return fmt.Sprintf("Schema validation: %s %s is below minimum %v", label, value, details["min"])
+	case "number_gt":
+		return fmt.Sprintf("Schema validation: %s %s must be greater than exclusiveMinimum %v", label, value, details["min"])
+	case "number_lte":
+		return fmt.Sprintf("Schema validation: %s %s is above maximum %v", label, value, details["max"])
+	case "number_lt":
+		return fmt.Sprintf("Schema validation: %s %s must be less than exclusiveMaximum %v", label, value, details["max"])
+	case "multiple_of":
+		factor := fmt.Sprint(details["multiple"])
+		if def := schemaAtPath(schemaRoot, path); def != nil {
+			if m, ok := def["multipleOf"]; ok {
+				factor = jsonValue(m)
+			}
+		}
+		return fmt.Sprintf("Schema validation: %s %s must be a multiple of %s", label, value, factor)
+	case "string_gte":
+		if value == "" && fmt.Sprint(details["min"]) == "1" {
+			return fmt.Sprintf("Schema validation: %s must not be empty (minLength 1)", label)
+		}
+		return fmt.Sprintf("Schema validation: %s length %d is below minLength %v", label, utf8.RuneCountInString(value), details["min"])
+	case "string_lte":
+		return fmt.Sprintf("Schema validation: %s length %d is above maxLength %v", label, utf8.RuneCountInString(value), details["max"])
+	case "const":
+		expected := fmt.Sprint(details["allowed"])
+		if def := schemaAtPath(schemaRoot, path); def != nil {
+			if c, ok := def["const"]; ok {
+				expected = jsonValue(c)
+			}
+		}
+		got := ""
+		if node := findNodeForPath(userNode, path); node != nil {
+			got = nodeJSONValue(node)
+		}
+		return fmt.Sprintf("Schema validation: %s must equal %s, got %s", label, expected, got)
+	case "format":
+		format := fmt.Sprint(details["format"])
+		return fmt.Sprintf("Schema validation: %s %q is not a valid %s (format %q)", label, value, formatDescription(format), format)
+	case "array_min_items":
+		if node := findNodeForPath(userNode, path); node != nil && node.Kind == yaml.SequenceNode {
+			return fmt.Sprintf("Schema validation: %s has %d item(s), fewer than minItems %v", label, len(node.Content), details["min"])
+		}
+	case "array_max_items":
+		if node := findNodeForPath(userNode, path); node != nil && node.Kind == yaml.SequenceNode {
+			return fmt.Sprintf("Schema validation: %s has %d item(s), more than maxItems %v", label, len(node.Content), details["max"])
+		}
+	case "unique":
+		if dup, i, j := duplicateItem(e, userNode, path); dup != nil {
+			return fmt.Sprintf("Schema validation: %s must have unique items, but items %d and %d are both %s", label, i, j, nodeJSONValue(dup))
+		}
+	}
+
+	return fmt.Sprintf("Schema validation: %s", e.Description())
+}
+
+// duplicateItem returns the second of two equal items gojsonschema reports
+// for a failed uniqueItems on the list at path, with the indexes of both, or
+// nil for other errors.
+func duplicateItem(e gojsonschema.ResultError, userNode *yaml.Node, path string) (*yaml.Node, int, int) {
+	if e.Type() != "unique" {
+		return nil, 0, 0
+	}
+	i, iok := e.Details()["i"].(int)
+	j, jok := e.Details()["j"].(int)
+	list := findNodeForPath(userNode, path)
+	if !iok || !jok || list == nil || list.Kind != yaml.SequenceNode || j < 0 || j >= len(list.Content) {
+		return nil, 0, 0
+	}
+	return list.Content[j], i, j
+}
+
+// exclusiveKeysMessage explains a failed oneOf or not on the object at path
+// in terms of the keys the user set, for schemas that encode "set A or B,
+// not both" through the keys each branch requires (or declares). It returns
+// the message and the path of the last conflicting key, whose line the
+// finding points at, or "" when the failure is about something else.
+func exclusiveKeysMessage(errType string, userNode *yaml.Node, schemaRoot map[string]interface{}, path string) (string, string) {
+	def := schemaAtPath(schemaRoot, path)
+	obj := findNodeForPath(userNode, path)
+	if def == nil || obj == nil || obj.Kind != yaml.MappingNode {
+		return "", ""
+	}
+	switch errType {
+	case "number_one_of":
+		branches, _ := def["oneOf"].([]interface{})
+		var alternatives, matched []string
+		for _, b := range branches {
+			branch, _ := b.(map[string]interface{})
+			keys := branchKeys(schemaRoot, branch, nil)
+			if len(keys) == 0 {
+				return "", ""
+			}
+			alternatives = append(alternatives, strings.Join(qualify(path, keys), " and "))
+			if allSet(obj, keys) {
+				matched = append(matched, qualify(path, keys)...)
+			}
+		}
+		if len(branches) < 2 || len(matched) < 2 {
+			return "", ""
+		}
+		return fmt.Sprintf("Schema validation: only one of %s may be set, got %s",
+			orList(alternatives), strings.Join(matched, " and ")), matched[len(matched)-1]
+	case "number_not":
+		branch, _ := def["not"].(map[string]interface{})
+		keys := branchKeys(schemaRoot, branch, nil)
+		if len(keys) == 0 || !allSet(obj, keys) {
+			return "", ""
+		}
+		full := qualify(path, keys)
+		if len(full) == 1 {
+			return fmt.Sprintf("Schema validation: %s must not be set", full[0]), full[0]
+		}
+		return fmt.Sprintf("Schema validation: %s cannot be set together", strings.Join(full, " and ")), full[len(full)-1]
+	}
+	return "", ""
+}
+
+// branchKeys returns the keys a oneOf/not branch is about: those it
+// requires, or else the properties it declares, in schema order (properties
+// in name order). Branches combining others with allOf contribute the keys
+// of each.
+func branchKeys(root, branch map[string]interface{}, seen map[string]bool) []string {
+	branch, seen = derefSchema(root, branch, seen)
+	if branch == nil {
+		return nil
+	}
+
+	var keys []string
+	if req, ok := branch["required"].([]interface{}); ok {
+		for _, r := range req {
+			if name, ok := r.(string); ok {
+				keys = append(keys, name)
+			}
+		}
+	} else if props, ok := branch["properties"].(map[string]interface{}); ok {
+		for name := range props {
+			keys = append(keys, name)
+		}
+		sort.Strings(keys)
+	}
+	if all, ok := branch["allOf"].([]interface{}); ok {
+		for _, sub := range all {
+			if subDef, ok := sub.(map[string]interface{}); ok {
+				keys = append(keys, branchKeys(root, subDef, seen)...)
+			}
+		}
+	}
+	return keys
+}
+
+// allSet reports whether the mapping obj sets every key in keys.
+func allSet(obj *yaml.Node, keys []string) bool {
+	for _, k := range keys {
+		if getValueForKey(obj, k) == nil {
+			return false
+		}
+	}
+	return true
+}
+
+// orList joins items as "a or b" or "a, b, or c".
+func orList(items []string) string {
+	if len(items) <= 2 {
+		return strings.Join(items, " or ")
+	}
+	return strings.Join(items[:len(items)-1], ", ") + ", or " + items[len(items)-1]
+}
+
+// qualify joins each key onto path.
+func qualify(path string, keys []string) []string {
+	full := make([]string, len(keys))
+	for i, k := range keys {
+		full[i] = joinPath(path, k)
+	}
+	return full
+}
+
+// formatDescriptions names the JSON Schema string formats checked by
+// gojsonschema for use in messages.
+var formatDescriptions = map[string]string{
+	"date":          "date",
+	"time":          "time",
+	"date-time":     "date-time",
+	"hostname":      "hostname",
+	"email":         "email address",
+	"idn-email":     "email address",
+	"ipv4":          "IPv4 address",
+	"ipv6":          "IPv6 address",
+	"uri":           "absolute URI",
+	"uri-reference": "URI reference",
+	"iri":           "absolute IRI",
+	"iri-reference": "IRI reference",
+	"uri-template":  "URI template",
+	"uuid":          "UUID",
+	"regex":         "regular expression",
+	"json-pointer":  "JSON pointer",
+}
+
+// formatDescription returns a readable name for a schema format, falling
+// back to the format itself.
+func formatDescription(format string) string {
+	if d, ok := formatDescriptions[format]; ok {
+		return d
+	}
+	return format
+}
+
+// schemaAtPath returns the schema governing a gojsonschema field path such
+// as "image.tag" or "hosts.0.name", following properties and array items.
+func schemaAtPath(root map[string]interface{}, path string) map[string]interface{} {
+	schema, seen := derefSchema(root, root, nil)
+	if path == "" {
+		return schema
+	}
+	for _, segment := range strings.Split(path, ".") {
+		if schema == nil {
+			return nil
+		}
+		var next map[string]interface{}
+		if props, ok := schema["properties"].(map[string]interface{}); ok {
+			next, _ = props[segment].(map[string]interface{})
+		}
+		if next == nil {
+			if _, err := strconv.Atoi(segment); err == nil {
+				next, _ = schema["items"].(map[string]interface{})
+			}
+		}
+		if next == nil {
+			return nil
+		}
+		schema, seen = derefSchema(root, next, seen)
+	}
+	return schema
+}
+
+// jsonValue renders a schema value for messages (e.g., "v2" with quotes).
+func jsonValue(v interface{}) string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(data)
+}
+
+// nodeJSONValue renders a user value the same way as jsonValue.
+func nodeJSONValue(node *yaml.Node) string {
+	var v interface{}
+	if err := node.Decode(&v); err != nil {
+		return node.Value
+	}
+	return jsonValue(v)
+}
+
 // extractSchemaKeys extracts all property paths defined in a JSON schema.
 func extractSchemaKeys(schemaBytes []byte) map[string]bool {
 	keys := make(map[string]bool)
@@ -185,11 +1144,16 @@ func extractSchemaKeys(schemaBytes []byte) map[string]bool {
 		return keys
 	}
 
-	walkSchemaProperties(schema, "", keys)
+	walkSchemaProperties(schema, schema, "", keys, nil)
 	return keys
 }
 
-func walkSchemaProperties(schema map[string]interface{}, path string, keys map[string]bool) {
+func walkSchemaProperties(root, schema map[string]interface{}, path string, keys map[string]bool, seen map[string]bool) {
+	schema, seen = derefSchema(root, schema, seen)
+	if schema == nil {
+		return
+	}
+
 	props, ok := schema["properties"].(map[string]interface{})
 	if !ok {
 		return
@@ -200,13 +1164,159 @@ func walkSchemaProperties(schema map[string]interface{}, path string, keys map[s
 		keys[fullPath] = true
 
 		if propDef, ok := v.(map[string]interface{}); ok {
-			walkSchemaProperties(propDef, fullPath, keys)
+			walkSchemaProperties(root, propDef, fullPath, keys, seen)
+		}
+	}
+}
+
+// extractSchemaDefaults builds a values tree from the "default" keywords of
+// a JSON schema's properties, following $refs, with properties in name
+// order. An object property without a default contributes the defaults of
+// its own properties, if any. It returns nil when there are none.
+func extractSchemaDefaults(schemaBytes []byte) *yaml.Node {
+	if len(schemaBytes) == 0 {
+		return nil
+	}
+
+	var schema map[string]interface{}
+	if err := json.Unmarshal(schemaBytes, &schema); err != nil {
+		return nil
+	}
+
+	return walkSchemaDefaults(schema, schema, nil)
+}
+
+func walkSchemaDefaults(root, schema map[string]interface{}, seen map[string]bool) *yaml.Node {
+	schema, seen = derefSchema(root, schema, seen)
+	if schema == nil {
+		return nil
+	}
+	props, ok := schema["properties"].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+
+	names := make([]string, 0, len(props))
+	for name := range props {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var node *yaml.Node
+	for _, name := range names {
+		propDef, ok := props[name].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		propDef, propSeen := derefSchema(root, propDef, seen)
+		if propDef == nil {
+			continue
+		}
+
+		var value *yaml.Node
+		if def, ok := propDef["default"]; ok {
+			value = &yaml.Node{}
+			if err := value.Encode(def); err != nil {
+				continue
+			}
+		} else if value = walkSchemaDefaults(root, propDef, propSeen); value == nil {
+			continue
+		}
+
+		if node == nil {
+			node = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+		}
+		node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: name}, value)
+	}
+	return node
+}
+
+// fillDefaults returns defaults with keys from schemaDefaults added where
+// defaults has none, descending into mappings present in both. Chart
+// defaults take precedence, and an empty default mapping stays empty so
+// that it still accepts any structure; neither tree is modified.
+func fillDefaults(defaults, schemaDefaults *yaml.Node) *yaml.Node {
+	if schemaDefaults == nil {
+		return defaults
+	}
+	if defaults == nil {
+		return schemaDefaults
+	}
+	base := resolveAlias(defaults)
+	if base.Kind != yaml.MappingNode || len(base.Content) == 0 || schemaDefaults.Kind != yaml.MappingNode {
+		return defaults
+	}
+
+	filled := *base
+	filled.Content = append([]*yaml.Node(nil), base.Content...)
+	for i := 0; i+1 < len(schemaDefaults.Content); i += 2 {
+		key := schemaDefaults.Content[i].Value
+		if j := mappingKeyIndex(&filled, key); j >= 0 {
+			filled.Content[j+1] = fillDefaults(filled.Content[j+1], schemaDefaults.Content[i+1])
+			continue
+		}
+		filled.Content = append(filled.Content, schemaDefaults.Content[i], schemaDefaults.Content[i+1])
+	}
+	return &filled
+}
+
+// extractSchemaPatterns returns the patternProperties regexes of each object
+// in a JSON schema, keyed by the object's dot-separated path ("" for the
+// root), with array items under the "[*]" suffix as in SchemaTypeMap.
+// Patterns that Go's regexp package cannot compile are skipped.
+func extractSchemaPatterns(schemaBytes []byte) map[string][]*regexp.Regexp {
+	patterns := make(map[string][]*regexp.Regexp)
+	if len(schemaBytes) == 0 {
+		return patterns
+	}
+
+	var schema map[string]interface{}
+	if err := json.Unmarshal(schemaBytes, &schema); err != nil {
+		return patterns
+	}
+
+	walkSchemaPatterns(schema, schema, "", patterns, nil)
+	return patterns
+}
+
+func walkSchemaPatterns(root, schema map[string]interface{}, path string, patterns map[string][]*regexp.Regexp, seen map[string]bool) {
+	schema, seen = derefSchema(root, schema, seen)
+	if schema == nil {
+		return
+	}
+
+	if pp, ok := schema["patternProperties"].(map[string]interface{}); ok {
+		exprs := make([]string, 0, len(pp))
+		for expr := range pp {
+			exprs = append(exprs, expr)
+		}
+		sort.Strings(exprs)
+		for _, expr := range exprs {
+			if re, err := regexp.Compile(expr); err == nil {
+				patterns[path] = append(patterns[path], re)
+			}
+		}
+	}
+
+	if items, ok := schema["items"].(map[string]interface{}); ok {
+		walkSchemaPatterns(root, items, path+"[*]", patterns, seen)
+	}
+
+	props, ok := schema["properties"].(map[string]interface{})
+	if !ok {
+		return
+	}
+
+	for name, v := range props {
+		if propDef, ok := v.(map[string]interface{}); ok {
+			walkSchemaPatterns(root, propDef, joinPath(path, name), patterns, seen)
 		}
 	}
 }
 
 // checkDeprecated walks the JSON schema looking for deprecated markers
-// and warns when user values set those keys.
+// and warns when user values set those keys. Keys the schema also lists as
+// required are called out, since they cannot simply be removed yet.
 func checkDeprecated(userNode *yaml.Node, schemaBytes []byte, ignoreKeys []string) []model.Finding {
 	var findings []model.Finding
 
@@ -215,22 +1325,33 @@ func checkDeprecated(userNode *yaml.Node, schemaBytes []byte, ignoreKeys []strin
 		return findings
 	}
 
-	deprecated := findDeprecatedPaths(schema, "")
+	required := make(map[string]bool)
+	for _, path := range findRequiredPaths(schema, schema, "", nil) {
+		required[path] = true
+	}
+
+	deprecated := findDeprecatedPaths(schema, schema, "", nil)
 	for path, msg := range deprecated {
 		if matchesIgnore(path, ignoreKeys) {
 			continue
 		}
 
-		if line := findLineForPath(userNode, path); line > 0 {
+		if line, column := findPositionForPath(userNode, path); line > 0 {
 			message := fmt.Sprintf("Deprecated key %q", path)
+			if required[path] {
+				message += " is still required by the schema"
+			}
 			if msg != "" {
 				message += " - " + msg
 			}
 			findings = append(findings, model.Finding{
 				Severity: model.SeverityWarning,
+				Rule:     model.RuleSchemaDeprecated,
 				Line:     line,
+				Column:   column,
 				KeyPath:  path,
 				Message:  message,
+				DocURL:   schemaDocURL(schemaAtPath(schema, path)),
 			})
 		}
 	}
@@ -238,10 +1359,92 @@ func checkDeprecated(userNode *yaml.Node, schemaBytes []byte, ignoreKeys []strin
 	return findings
 }
 
+// schemaDocURL returns the documentation link of a property definition: its
+// "x-docs" URL, or else its "$comment" if that is a URL. It returns "" for
+// neither.
+func schemaDocURL(propDef map[string]interface{}) string {
+	for _, keyword := range []string{"x-docs", "$comment"} {
+		if s, ok := propDef[keyword].(string); ok {
+			if s = strings.TrimSpace(s); strings.HasPrefix(s, "https://") || strings.HasPrefix(s, "http://") {
+				return s
+			}
+		}
+	}
+	return ""
+}
+
+// checkRequiredDefaults reports keys listed in a schema "required" array that
+// the user did not set but the chart defaults provide, so teams can audit
+// that required settings were consciously left at their default.
+func checkRequiredDefaults(userNode, defaultsNode *yaml.Node, schemaBytes []byte, ignoreKeys []string) []model.Finding {
+	var findings []model.Finding
+
+	var schema map[string]interface{}
+	if err := json.Unmarshal(schemaBytes, &schema); err != nil {
+		return findings
+	}
+
+	required := findRequiredPaths(schema, schema, "", nil)
+	sort.Strings(required)
+	for _, path := range required {
+		if matchesIgnore(path, ignoreKeys) {
+			continue
+		}
+		if findNodeForPath(userNode, path) != nil || findNodeForPath(defaultsNode, path) == nil {
+			continue
+		}
+		findings = append(findings, model.Finding{
+			Severity: model.SeverityInfo,
+			Rule:     model.RuleRequiredDefault,
+			KeyPath:  path,
+			Message:  fmt.Sprintf("Required key %q is not set; using the chart default", path),
+		})
+	}
+
+	return findings
+}
+
+// findRequiredPaths walks schema properties collecting the dot-separated
+// paths of every property named in a "required" array.
+func findRequiredPaths(root, schema map[string]interface{}, path string, seen map[string]bool) []string {
+	var result []string
+
+	schema, seen = derefSchema(root, schema, seen)
+	if schema == nil {
+		return result
+	}
+
+	if req, ok := schema["required"].([]interface{}); ok {
+		for _, r := range req {
+			if name, ok := r.(string); ok {
+				result = append(result, joinPath(path, name))
+			}
+		}
+	}
+
+	props, ok := schema["properties"].(map[string]interface{})
+	if !ok {
+		return result
+	}
+
+	for name, v := range props {
+		if propDef, ok := v.(map[string]interface{}); ok {
+			result = append(result, findRequiredPaths(root, propDef, joinPath(path, name), seen)...)
+		}
+	}
+
+	return result
+}
+
 // findDeprecatedPaths walks schema properties looking for deprecated markers.
-func findDeprecatedPaths(schema map[string]interface{}, path string) map[string]string {
+func findDeprecatedPaths(root, schema map[string]interface{}, path string, seen map[string]bool) map[string]string {
 	result := make(map[string]string)
 
+	schema, seen = derefSchema(root, schema, seen)
+	if schema == nil {
+		return result
+	}
+
 	props, ok := schema["properties"].(map[string]interface{})
 	if !ok {
 		return result
@@ -252,19 +1455,19 @@ func findDeprecatedPaths(schema map[string]interface{}, path string) map[string]
 		if !ok {
 			continue
 		}
+		propDef, propSeen := derefSchema(root, propDef, seen)
+		if propDef == nil {
+			continue
+		}
 
 		fullPath := joinPath(path, name)
 
-		if dep, ok := propDef["deprecated"].(bool); ok && dep {
-			msg := ""
-			if desc, ok := propDef["description"].(string); ok {
-				msg = desc
-			}
+		if msg, ok := deprecationMessage(propDef); ok {
 			result[fullPath] = msg
 		}
 
 		// Recurse into nested properties
-		for k, v := range findDeprecatedPaths(propDef, fullPath) {
+		for k, v := range findDeprecatedPaths(root, propDef, fullPath, propSeen) {
 			result[k] = v
 		}
 	}
@@ -272,11 +1475,94 @@ func findDeprecatedPaths(schema map[string]interface{}, path string) map[string]
 	return result
 }
 
+// extractQuantityPaths returns the property paths whose schema declares a
+// string pattern that looks like a Kubernetes quantity (e.g., "10Gi").
+// Array items use the "[*]" suffix, as in SchemaTypeMap.
+func extractQuantityPaths(schemaBytes []byte) []string {
+	if len(schemaBytes) == 0 {
+		return nil
+	}
+
+	var schema map[string]interface{}
+	if err := json.Unmarshal(schemaBytes, &schema); err != nil {
+		return nil
+	}
+
+	paths := findQuantityPaths(schema, schema, "", nil)
+	sort.Strings(paths)
+	return paths
+}
+
+// findQuantityPaths walks schema properties and array items collecting the
+// paths whose "pattern" passes isQuantityPattern.
+func findQuantityPaths(root, schema map[string]interface{}, path string, seen map[string]bool) []string {
+	var result []string
+
+	schema, seen = derefSchema(root, schema, seen)
+	if schema == nil {
+		return result
+	}
+
+	props, ok := schema["properties"].(map[string]interface{})
+	if !ok {
+		return result
+	}
+
+	for name, v := range props {
+		propDef, ok := v.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		propDef, propSeen := derefSchema(root, propDef, seen)
+		if propDef == nil {
+			continue
+		}
+
+		fullPath := joinPath(path, name)
+		if pattern, ok := propDef["pattern"].(string); ok && isQuantityPattern(pattern) {
+			result = append(result, fullPath)
+		}
+
+		if items, ok := propDef["items"].(map[string]interface{}); ok {
+			if items, itemSeen := derefSchema(root, items, propSeen); items != nil {
+				itemPath := fullPath + "[*]"
+				if pattern, ok := items["pattern"].(string); ok && isQuantityPattern(pattern) {
+					result = append(result, itemPath)
+				}
+				result = append(result, findQuantityPaths(root, items, itemPath, itemSeen)...)
+			}
+		}
+
+		result = append(result, findQuantityPaths(root, propDef, fullPath, propSeen)...)
+	}
+
+	return result
+}
+
+// isQuantityPattern reports whether a schema pattern looks like the
+// Kubernetes quantity regex: it accepts a number with a binary suffix but
+// not the suffix alone.
+func isQuantityPattern(pattern string) bool {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return false
+	}
+	return re.MatchString("128Mi") && re.MatchString("10Gi") && !re.MatchString("Gi")
+}
+
 // findLineForPath tries to find the line number for a dot-separated path
 // in the yaml.Node tree.
 func findLineForPath(node *yaml.Node, path string) int {
+	line, _ := findPositionForPath(node, path)
+	return line
+}
+
+// findPositionForPath is like findLineForPath but also returns the column
+// of the key, or 0, 0 if the path is not found. The root has no key, so it
+// has a line but no column.
+func findPositionForPath(node *yaml.Node, path string) (int, int) {
 	if path == "" {
-		return node.Line
+		return node.Line, 0
 	}
 
 	parts := strings.SplitN(path, ".", 2)
@@ -287,17 +1573,84 @@ func findLineForPath(node *yaml.Node, path string) int {
 	}
 
 	if node.Kind != yaml.MappingNode {
-		return 0
+		return 0, 0
 	}
 
 	for i := 0; i+1 < len(node.Content); i += 2 {
 		if node.Content[i].Value == key {
 			if rest == "" {
-				return node.Content[i].Line
+				return node.Content[i].Line, node.Content[i].Column
 			}
-			return findLineForPath(node.Content[i+1], rest)
+			return findPositionForPath(node.Content[i+1], rest)
 		}
 	}
 
-	return 0
+	return 0, 0
+}
+
+// findNodeForPath returns the value node at a dot-separated path in the
+// yaml.Node tree, or nil if the path does not exist. Numeric segments index
+// into sequences (e.g., "hosts.0.name"), matching gojsonschema field paths.
+func findNodeForPath(node *yaml.Node, path string) *yaml.Node {
+	if node == nil {
+		return nil
+	}
+	node = resolveAlias(node)
+	if path == "" {
+		return node
+	}
+
+	parts := strings.SplitN(path, ".", 2)
+	key := parts[0]
+	rest := ""
+	if len(parts) > 1 {
+		rest = parts[1]
+	}
+
+	switch node.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if node.Content[i].Value == key {
+				return findNodeForPath(node.Content[i+1], rest)
+			}
+		}
+	case yaml.SequenceNode:
+		if idx, err := strconv.Atoi(key); err == nil && idx >= 0 && idx < len(node.Content) {
+			return findNodeForPath(node.Content[idx], rest)
+		}
+	}
+
+	return nil
+}
+
+// deprecationExtensions lists the non-standard keywords some charts use to
+// mark a property deprecated, in lookup order.
+var deprecationExtensions = []string{"x-deprecated", "deprecationMessage", "x-deprecation"}
+
+// deprecationMessage reports whether a property definition is marked
+// deprecated, either by the standard "deprecated: true" or one of the
+// vendor extensions. A string-valued extension is used as the message;
+// otherwise the property's description is.
+func deprecationMessage(propDef map[string]interface{}) (string, bool) {
+	deprecated := false
+	if dep, ok := propDef["deprecated"].(bool); ok && dep {
+		deprecated = true
+	}
+
+	for _, key := range deprecationExtensions {
+		switch v := propDef[key].(type) {
+		case string:
+			if v != "" {
+				return v, true
+			}
+		case bool:
+			deprecated = deprecated || v
+		}
+	}
+
+	if !deprecated {
+		return "", false
+	}
+	desc, _ := propDef["description"].(string)
+	return desc, true
 }
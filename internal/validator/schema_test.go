@@ -45,7 +45,7 @@ func TestValidateSchema_RequiredFields(t *testing.T) {
 	user := parseYAML(t, `
 other: value
 `)
-	findings, err := validateSchema(user, schema, nil, nil)
+	findings, err := validateSchema(user, schema, nil, nil, nil)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -75,7 +75,7 @@ func TestValidateSchema_DeprecatedKeys(t *testing.T) {
 	user := parseYAML(t, `
 oldSetting: "some-value"
 `)
-	findings, err := validateSchema(user, schema, nil, nil)
+	findings, err := validateSchema(user, schema, nil, nil, nil)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -94,7 +94,7 @@ func TestValidateSchema_NoSchema(t *testing.T) {
 	user := parseYAML(t, `
 anything: goes
 `)
-	findings, err := validateSchema(user, nil, nil, nil)
+	findings, err := validateSchema(user, nil, nil, nil, nil)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -161,7 +161,7 @@ replicaCount: "not-a-number"
 `)
 
 	// Without schema types: invalid_type error should appear
-	findingsWithout, err := validateSchema(user, schema, nil, nil)
+	findingsWithout, err := validateSchema(user, schema, nil, nil, nil)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -177,7 +177,7 @@ replicaCount: "not-a-number"
 
 	// With schema types: invalid_type error should be filtered
 	schemaTypes := SchemaTypeMap{"replicaCount": {"integer"}}
-	findingsWith, err := validateSchema(user, schema, nil, schemaTypes)
+	findingsWith, err := validateSchema(user, schema, nil, nil, schemaTypes)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -249,7 +249,7 @@ func TestValidateSchema_ExternalRefBlocked(t *testing.T) {
 	}`)
 
 	user := parseYAML(t, `x: value`)
-	findings, err := validateSchema(user, schema, nil, nil)
+	findings, err := validateSchema(user, schema, nil, nil, nil)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -276,7 +276,7 @@ func TestValidateSchema_FragmentRefAllowed(t *testing.T) {
 	}`)
 
 	user := parseYAML(t, `x: hello`)
-	findings, err := validateSchema(user, schema, nil, nil)
+	findings, err := validateSchema(user, schema, nil, nil, nil)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -290,8 +290,919 @@ func TestValidateSchema_FragmentRefAllowed(t *testing.T) {
 func TestValidateSchema_MalformedSchemaReturnsError(t *testing.T) {
 	schema := []byte(`{not valid json`)
 	user := parseYAML(t, `x: value`)
-	_, err := validateSchema(user, schema, nil, nil)
+	_, err := validateSchema(user, schema, nil, nil, nil)
 	if err == nil {
 		t.Error("expected error for malformed schema, got nil")
 	}
 }
+
+func TestValidateSchema_MinimumIncludesValue(t *testing.T) {
+	schema := []byte(`{
+		"type": "object",
+		"properties": {
+			"replicaCount": {"type": "integer", "minimum": 1}
+		}
+	}`)
+
+	user := parseYAML(t, `
+image: nginx
+replicaCount: 0
+`)
+	findings, err := validateSchema(user, schema, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(findings) != 1 {
+		t.Fatalf("expected 1 finding, got %d: %v", len(findings), findings)
+	}
+	if !strings.Contains(findings[0].Message, "replicaCount 0 is below minimum 1") {
+		t.Errorf("expected minimum message with value, got: %s", findings[0].Message)
+	}
+	if findings[0].Line != 3 {
+		t.Errorf("expected line 3, got %d", findings[0].Line)
+	}
+}
+
+func TestValidateSchema_MaxLengthIncludesLength(t *testing.T) {
+	schema := []byte(`{
+		"type": "object",
+		"properties": {
+			"name": {"type": "string", "maxLength": 5}
+		}
+	}`)
+
+	user := parseYAML(t, `
+name: "much-too-long"
+`)
+	findings, err := validateSchema(user, schema, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(findings) != 1 {
+		t.Fatalf("expected 1 finding, got %d: %v", len(findings), findings)
+	}
+	if !strings.Contains(findings[0].Message, "name length 13 is above maxLength 5") {
+		t.Errorf("expected maxLength message with length, got: %s", findings[0].Message)
+	}
+	if findings[0].Line != 2 {
+		t.Errorf("expected line 2, got %d", findings[0].Line)
+	}
+}
+
+func TestExtractSchema_FollowsInternalRefs(t *testing.T) {
+	schema := []byte(`{
+		"type": "object",
+		"definitions": {
+			"probe": {
+				"type": "object",
+				"properties": {
+					"enabled": {"type": "boolean"},
+					"http": {"$ref": "#/definitions/httpGet"}
+				}
+			},
+			"httpGet": {
+				"type": "object",
+				"properties": {
+					"path": {"type": "string"},
+					"port": {"type": "integer"}
+				}
+			}
+		},
+		"properties": {
+			"livenessProbe": {"$ref": "#/definitions/probe"}
+		}
+	}`)
+
+	keys := extractSchemaKeys(schema)
+	for _, k := range []string{"livenessProbe", "livenessProbe.enabled", "livenessProbe.http", "livenessProbe.http.path", "livenessProbe.http.port"} {
+		if !keys[k] {
+			t.Errorf("expected schema key %q to be present", k)
+		}
+	}
+
+	types := extractSchemaTypes(schema)
+	if got := types["livenessProbe"]; len(got) != 1 || got[0] != "object" {
+		t.Errorf("types[livenessProbe]: expected [object], got %v", got)
+	}
+	if got := types["livenessProbe.http.port"]; len(got) != 1 || got[0] != "integer" {
+		t.Errorf("types[livenessProbe.http.port]: expected [integer], got %v", got)
+	}
+}
+
+func TestExtractSchema_RefCycleTerminates(t *testing.T) {
+	schema := []byte(`{
+		"definitions": {
+			"node": {
+				"type": "object",
+				"properties": {
+					"name": {"type": "string"},
+					"child": {"$ref": "#/definitions/node"}
+				}
+			}
+		},
+		"properties": {
+			"tree": {"$ref": "#/definitions/node"}
+		}
+	}`)
+
+	keys := extractSchemaKeys(schema)
+	if !keys["tree.name"] || !keys["tree.child"] {
+		t.Errorf("expected tree.name and tree.child, got %v", keys)
+	}
+	if keys["tree.child.child.child"] {
+		t.Error("expected cyclic $ref not to be expanded indefinitely")
+	}
+}
+
+func TestCheckRequiredDefaults(t *testing.T) {
+	schema := []byte(`{
+		"type": "object",
+		"required": ["auth", "replicaCount"],
+		"properties": {
+			"replicaCount": {"type": "integer"},
+			"auth": {
+				"type": "object",
+				"required": ["username", "password"],
+				"properties": {
+					"username": {"type": "string"},
+					"password": {"type": "string"}
+				}
+			}
+		}
+	}`)
+
+	defaults := parseYAML(t, `
+replicaCount: 1
+auth:
+  username: admin
+  password: ""
+`)
+	user := parseYAML(t, `
+replicaCount: 3
+auth:
+  password: secret
+`)
+
+	findings := checkRequiredDefaults(user, defaults, schema, nil)
+	if len(findings) != 1 {
+		t.Fatalf("expected 1 finding, got %d: %v", len(findings), findings)
+	}
+	if findings[0].Severity != model.SeverityInfo {
+		t.Errorf("expected SeverityInfo, got %v", findings[0].Severity)
+	}
+	if findings[0].KeyPath != "auth.username" {
+		t.Errorf("expected keyPath 'auth.username', got %q", findings[0].KeyPath)
+	}
+}
+
+func TestMergeSubchartSchemas(t *testing.T) {
+	parent := []byte(`{
+		"type": "object",
+		"properties": {
+			"redis": {
+				"type": "object",
+				"properties": {"enabled": {"type": "boolean"}}
+			}
+		}
+	}`)
+	sub := []byte(`{
+		"$schema": "https://json-schema.org/draft-07/schema#",
+		"type": "object",
+		"required": ["port"],
+		"definitions": {"port": {"type": "integer"}},
+		"properties": {
+			"port": {"$ref": "#/definitions/port"}
+		}
+	}`)
+
+	merged := mergeSubchartSchemas(parent, map[string][]byte{"redis": sub})
+
+	keys := extractSchemaKeys(merged)
+	for _, k := range []string{"redis", "redis.enabled", "redis.port"} {
+		if !keys[k] {
+			t.Errorf("expected merged schema key %q", k)
+		}
+	}
+	if got := extractSchemaTypes(merged)["redis.port"]; len(got) != 1 || got[0] != "integer" {
+		t.Errorf("expected rebased $ref to resolve redis.port to integer, got %v", got)
+	}
+
+	user := parseYAML(t, `
+redis:
+  enabled: true
+`)
+	findings, err := validateSchema(user, merged, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(findings) != 1 || findings[0].KeyPath != "redis" {
+		t.Errorf("expected 1 required finding at 'redis', got %v", findings)
+	}
+}
+
+func TestValidateSchema_RequiredRule(t *testing.T) {
+	schema := []byte(`{
+		"type": "object",
+		"required": ["name"],
+		"properties": {"name": {"type": "string"}}
+	}`)
+
+	findings, err := validateSchema(parseYAML(t, `other: value`), schema, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(findings) != 1 || findings[0].Rule != model.RuleSchemaRequired {
+		t.Errorf("expected 1 finding with rule %q, got %v", model.RuleSchemaRequired, findings)
+	}
+}
+
+func TestCheckDeprecated_VendorExtensions(t *testing.T) {
+	tests := []struct {
+		name    string
+		prop    string
+		message string
+	}{
+		{"deprecated with description", `"deprecated": true, "description": "use newSetting instead"`, `Deprecated key "oldSetting" - use newSetting instead`},
+		{"x-deprecated bool", `"x-deprecated": true, "description": "use newSetting instead"`, `Deprecated key "oldSetting" - use newSetting instead`},
+		{"x-deprecated string", `"x-deprecated": "removed in 3.0", "description": "the old setting"`, `Deprecated key "oldSetting" - removed in 3.0`},
+		{"deprecationMessage", `"deprecationMessage": "use newSetting instead"`, `Deprecated key "oldSetting" - use newSetting instead`},
+		{"x-deprecation string", `"x-deprecation": "use newSetting instead"`, `Deprecated key "oldSetting" - use newSetting instead`},
+		{"deprecated without message", `"deprecated": true`, `Deprecated key "oldSetting"`},
+	}
+
+	user := parseYAML(t, `
+oldSetting: "some-value"
+`)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schema := []byte(`{"type": "object", "properties": {"oldSetting": {"type": "string", ` + tt.prop + `}}}`)
+			findings := checkDeprecated(user, schema, nil)
+			if len(findings) != 1 {
+				t.Fatalf("expected 1 finding, got %d: %v", len(findings), findings)
+			}
+			if findings[0].Message != tt.message {
+				t.Errorf("expected message %q, got %q", tt.message, findings[0].Message)
+			}
+		})
+	}
+}
+
+func TestCheckDeprecated_XDeprecatedFalse(t *testing.T) {
+	schema := []byte(`{"type": "object", "properties": {"setting": {"type": "string", "x-deprecated": false}}}`)
+	user := parseYAML(t, `
+setting: "value"
+`)
+	if findings := checkDeprecated(user, schema, nil); len(findings) != 0 {
+		t.Errorf("expected no findings, got %v", findings)
+	}
+}
+
+func TestValidateSchema_ConstMessage(t *testing.T) {
+	schema := []byte(`{
+		"type": "object",
+		"properties": {
+			"config": {
+				"type": "object",
+				"properties": {
+					"apiVersion": {"type": "string", "const": "v2"}
+				}
+			}
+		}
+	}`)
+
+	user := parseYAML(t, `
+image: nginx
+config:
+  apiVersion: v1
+`)
+	findings, err := validateSchema(user, schema, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(findings) != 1 {
+		t.Fatalf("expected 1 finding, got %d: %v", len(findings), findings)
+	}
+	want := `Schema validation: config.apiVersion must equal "v2", got "v1"`
+	if findings[0].Message != want {
+		t.Errorf("expected message %q, got %q", want, findings[0].Message)
+	}
+	if findings[0].Line != 4 {
+		t.Errorf("expected line 4, got %d", findings[0].Line)
+	}
+	if findings[0].Rule != model.RuleSchemaConst {
+		t.Errorf("expected rule %q, got %q", model.RuleSchemaConst, findings[0].Rule)
+	}
+}
+
+func TestValidateSchema_RequiredAndDeprecated(t *testing.T) {
+	schema := []byte(`{
+		"type": "object",
+		"required": ["oldSetting"],
+		"properties": {
+			"oldSetting": {"type": "string", "deprecated": true, "description": "set newSetting instead"},
+			"newSetting": {"type": "string"}
+		}
+	}`)
+
+	// Missing: one error explaining both constraints
+	findings, err := validateSchema(parseYAML(t, `newSetting: value`), schema, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(findings) != 1 {
+		t.Fatalf("expected 1 finding, got %d: %v", len(findings), findings)
+	}
+	want := "Schema validation: oldSetting is required but deprecated - set newSetting instead"
+	if findings[0].Rule != model.RuleSchemaRequired || findings[0].Message != want {
+		t.Errorf("expected %s finding %q, got %v", model.RuleSchemaRequired, want, findings[0])
+	}
+
+	// Present: the deprecation warning notes that the key is still required
+	findings, err = validateSchema(parseYAML(t, `oldSetting: value`), schema, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(findings) != 1 {
+		t.Fatalf("expected 1 finding, got %d: %v", len(findings), findings)
+	}
+	want = `Deprecated key "oldSetting" is still required by the schema - set newSetting instead`
+	if findings[0].Rule != model.RuleSchemaDeprecated || findings[0].Message != want {
+		t.Errorf("expected %s finding %q, got %v", model.RuleSchemaDeprecated, want, findings[0])
+	}
+}
+
+func TestCheckSchemaDrafts(t *testing.T) {
+	draft07 := []byte(`{"$schema": "http://json-schema.org/draft-07/schema#", "type": "object"}`)
+	draft2020 := []byte(`{"$schema": "https://json-schema.org/draft/2020-12/schema", "type": "object"}`)
+
+	if findings := checkSchemaDrafts(draft07, map[string][]byte{"database": draft07}); len(findings) != 0 {
+		t.Errorf("expected no warnings for draft-07 schemas, got %v", findings)
+	}
+
+	findings := checkSchemaDrafts(draft2020, map[string][]byte{"database": draft07, "cache": draft2020})
+	if len(findings) != 2 {
+		t.Fatalf("expected 2 warnings, got %d: %v", len(findings), findings)
+	}
+	for _, f := range findings {
+		if f.Rule != model.RuleSchemaDraft || f.Severity != model.SeverityWarning || !strings.Contains(f.Message, "2020-12, which is not supported") {
+			t.Errorf("unexpected finding: %+v", f)
+		}
+	}
+	if !strings.HasPrefix(findings[0].Message, "Chart schema") || !strings.Contains(findings[1].Message, `subchart "cache"`) {
+		t.Errorf("expected the chart schema first, then subchart cache, got %v", findings)
+	}
+}
+
+func TestValidateSchema_IgnoreRequired(t *testing.T) {
+	schema := []byte(`{
+		"type": "object",
+		"required": ["name", "auth"],
+		"properties": {
+			"name": {"type": "string"},
+			"auth": {
+				"type": "object",
+				"required": ["username", "password"],
+				"properties": {
+					"username": {"type": "string"},
+					"password": {"type": "string"}
+				}
+			}
+		}
+	}`)
+	user := parseYAML(t, `
+auth:
+  username: admin
+`)
+
+	findings, err := validateSchema(user, schema, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(findings) != 2 {
+		t.Fatalf("expected 2 required findings without ignores, got %v", findings)
+	}
+
+	findings, err = validateSchema(user, schema, nil, []string{"name", "auth.*"}, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(findings) != 0 {
+		t.Errorf("expected ignored required keys not to error, got %v", findings)
+	}
+
+	// Only the named key is ignored, not its siblings or its parent's errors
+	findings, err = validateSchema(user, schema, nil, []string{"name"}, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(findings) != 1 || !strings.Contains(findings[0].Message, "password") {
+		t.Errorf("expected only auth.password to be reported, got %v", findings)
+	}
+}
+
+func TestValidateSchema_NestedRequiredChain(t *testing.T) {
+	schema := []byte(`{
+		"type": "object",
+		"required": ["config"],
+		"properties": {
+			"config": {
+				"type": "object",
+				"required": ["db"],
+				"properties": {
+					"db": {
+						"type": "object",
+						"required": ["host"],
+						"properties": {
+							"host": {"type": "string"},
+							"port": {"type": "integer"}
+						}
+					}
+				}
+			}
+		}
+	}`)
+
+	tests := []struct {
+		values string
+		want   []string
+	}{
+		{"other: 1", []string{"config", "config.db", "config.db.host"}},
+		{"config: {}", []string{"config.db", "config.db.host"}},
+		{"config:\n  db:\n    port: 5432", []string{"config.db.host"}},
+		{"config:\n  db:\n    host: db.local", nil},
+	}
+	for _, tt := range tests {
+		findings, err := validateSchema(parseYAML(t, tt.values), schema, nil, nil, nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(findings) != len(tt.want) {
+			t.Errorf("%q: expected %d findings, got %v", tt.values, len(tt.want), findings)
+			continue
+		}
+		for i, path := range tt.want {
+			if want := "Schema validation: " + path + " is required"; findings[i].Message != want || findings[i].Rule != model.RuleSchemaRequired {
+				t.Errorf("%q: finding %d = %q, want %q", tt.values, i, findings[i].Message, want)
+			}
+		}
+	}
+
+	// Descendants of a missing key can be ignored individually
+	findings, err := validateSchema(parseYAML(t, "other: 1"), schema, nil, []string{"config.db.host"}, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(findings) != 2 {
+		t.Errorf("expected config and config.db only, got %v", findings)
+	}
+}
+
+func TestValidateSchema_FormatMessage(t *testing.T) {
+	schema := []byte(`{
+		"type": "object",
+		"properties": {
+			"webhookURL": {"type": "string", "format": "uri"},
+			"adminEmail": {"type": "string", "format": "email"}
+		}
+	}`)
+
+	user := parseYAML(t, `
+webhookURL: hooks.example.com/notify
+adminEmail: ops@example.com
+`)
+	findings, err := validateSchema(user, schema, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(findings) != 1 {
+		t.Fatalf("expected 1 finding, got %d: %v", len(findings), findings)
+	}
+	want := `Schema validation: webhookURL "hooks.example.com/notify" is not a valid absolute URI (format "uri")`
+	if findings[0].Message != want {
+		t.Errorf("expected message %q, got %q", want, findings[0].Message)
+	}
+	if findings[0].Line != 2 || findings[0].Rule != model.RuleSchemaFormat {
+		t.Errorf("expected a schema-format finding at line 2, got %+v", findings[0])
+	}
+
+	valid := parseYAML(t, "webhookURL: https://hooks.example.com/notify\n")
+	findings, err = validateSchema(valid, schema, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(findings) != 0 {
+		t.Errorf("expected a well-formed URI to pass, got %v", findings)
+	}
+}
+
+func TestExtractSchemaDefaults_KnownKeys(t *testing.T) {
+	schema := []byte(`{
+		"type": "object",
+		"definitions": {
+			"probe": {"type": "object", "default": {"periodSeconds": 10, "path": "/healthz"}}
+		},
+		"properties": {
+			"replicaCount": {"type": "integer", "default": 1},
+			"metrics": {
+				"type": "object",
+				"properties": {
+					"port": {"type": "integer", "default": 9090},
+					"path": {"type": "string"}
+				}
+			},
+			"probe": {"$ref": "#/definitions/probe"}
+		}
+	}`)
+
+	defaults := parseYAML(t, "replicaCount: 3\nmetrics:\n  enabled: false\n")
+	known := fillDefaults(defaults, extractSchemaDefaults(schema))
+
+	// probe.periodSeconds exists only as part of a schema default
+	user := parseYAML(t, `
+replicaCount: 2
+metrics:
+  port: 9100
+probe:
+  periodSeconds: 5
+  timeout: 1
+`)
+	findings := detectUnknownKeys(user, known, extractSchemaKeys(schema), nil, nil, nil, "", nil, nil, false, true)
+	if len(findings) != 1 || findings[0].KeyPath != "probe.timeout" {
+		t.Errorf("expected only probe.timeout to be unknown, got %+v", findings)
+	}
+
+	if v := getValueForKey(known, "replicaCount"); v == nil || v.Value != "3" {
+		t.Errorf("expected the chart default to take precedence, got %v", v)
+	}
+	if m := getValueForKey(known, "metrics"); getValueForKey(m, "port") == nil || getValueForKey(m, "enabled") == nil {
+		t.Errorf("expected metrics to combine chart and schema defaults")
+	}
+	if len(getValueForKey(defaults, "metrics").Content) != 2 {
+		t.Errorf("expected the chart defaults to be left unmodified")
+	}
+}
+
+func TestDetectTypeMismatches_SchemaDefaults(t *testing.T) {
+	schema := []byte(`{"type": "object", "properties": {"timeout": {"type": "integer", "default": 30}}}`)
+	known := fillDefaults(parseYAML(t, "replicaCount: 1\n"), extractSchemaDefaults(schema))
+
+	findings := detectTypeMismatches(parseYAML(t, "timeout: \"30\"\n"), known, nil, nil, "", nil)
+	if len(findings) != 1 || findings[0].KeyPath != "timeout" {
+		t.Errorf("expected a string for a schema-defaulted integer to be a type mismatch, got %+v", findings)
+	}
+}
+
+func TestValidateSchema_OneOfConflictingKeys(t *testing.T) {
+	schema := []byte(`{
+		"type": "object",
+		"properties": {
+			"auth": {
+				"type": "object",
+				"properties": {
+					"password": {"type": "string"},
+					"existingSecret": {"type": "string"}
+				},
+				"oneOf": [
+					{"required": ["password"]},
+					{"required": ["existingSecret"]}
+				]
+			}
+		}
+	}`)
+
+	user := parseYAML(t, `
+auth:
+  password: hunter2
+  existingSecret: db-credentials
+`)
+	findings, err := validateSchema(user, schema, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(findings) != 1 {
+		t.Fatalf("expected 1 finding, got %d: %v", len(findings), findings)
+	}
+	want := "Schema validation: only one of auth.password or auth.existingSecret may be set, got auth.password and auth.existingSecret"
+	if findings[0].Message != want {
+		t.Errorf("expected message %q, got %q", want, findings[0].Message)
+	}
+	if findings[0].Line != 4 || findings[0].KeyPath != "auth" {
+		t.Errorf("expected the finding at the second key's line 4, got %+v", findings[0])
+	}
+
+	one := parseYAML(t, "auth:\n  existingSecret: db-credentials\n")
+	if findings, _ := validateSchema(one, schema, nil, nil, nil); len(findings) != 0 {
+		t.Errorf("expected one alternative to pass, got %v", findings)
+	}
+}
+
+func TestValidateSchema_NotTogether(t *testing.T) {
+	schema := []byte(`{
+		"type": "object",
+		"properties": {
+			"ingress": {
+				"type": "object",
+				"not": {"required": ["host", "hosts"]}
+			}
+		}
+	}`)
+
+	user := parseYAML(t, `
+ingress:
+  host: example.com
+  hosts: [a.example.com]
+`)
+	findings, err := validateSchema(user, schema, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(findings) != 1 || findings[0].Message != "Schema validation: ingress.host and ingress.hosts cannot be set together" {
+		t.Errorf("expected a conflict naming both keys, got %v", findings)
+	}
+}
+
+func TestValidateSchema_EmptyStringMinLength(t *testing.T) {
+	schema := []byte(`{
+		"type": "object",
+		"required": ["password", "username"],
+		"properties": {
+			"password": {"type": "string", "minLength": 1},
+			"username": {"type": "string", "minLength": 3}
+		}
+	}`)
+
+	user := parseYAML(t, `
+password: ""
+username: ab
+`)
+	findings, err := validateSchema(user, schema, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	messages := map[string]string{}
+	for _, f := range findings {
+		messages[f.KeyPath] = f.Message
+	}
+	if want := "Schema validation: password must not be empty (minLength 1)"; messages["password"] != want {
+		t.Errorf("expected %q, got %q", want, messages["password"])
+	}
+	if want := "Schema validation: username length 2 is below minLength 3"; messages["username"] != want {
+		t.Errorf("expected %q, got %q", want, messages["username"])
+	}
+}
+
+func TestValidateSchema_ArrayLengthMessages(t *testing.T) {
+	schema := []byte(`{
+		"type": "object",
+		"properties": {
+			"hosts": {"type": "array", "minItems": 2},
+			"ports": {"type": "array", "maxItems": 1}
+		}
+	}`)
+
+	user := parseYAML(t, `
+hosts:
+  - a.example.com
+ports: [80, 443]
+`)
+	findings, err := validateSchema(user, schema, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	messages := map[string]string{}
+	for _, f := range findings {
+		messages[f.KeyPath] = f.Message
+	}
+	if want := "Schema validation: hosts has 1 item(s), fewer than minItems 2"; messages["hosts"] != want {
+		t.Errorf("expected %q, got %q", want, messages["hosts"])
+	}
+	if want := "Schema validation: ports has 2 item(s), more than maxItems 1"; messages["ports"] != want {
+		t.Errorf("expected %q, got %q", want, messages["ports"])
+	}
+}
+
+func TestValidateSchema_UniqueItemsNamesDuplicate(t *testing.T) {
+	schema := []byte(`{
+		"type": "object",
+		"properties": {
+			"hosts": {"type": "array", "items": {"type": "string"}, "uniqueItems": true}
+		}
+	}`)
+
+	user := parseYAML(t, `
+hosts:
+  - a.example.com
+  - b.example.com
+  - a.example.com
+`)
+	findings, err := validateSchema(user, schema, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(findings) != 1 {
+		t.Fatalf("expected 1 finding, got %d: %v", len(findings), findings)
+	}
+	want := `Schema validation: hosts must have unique items, but items 0 and 2 are both "a.example.com"`
+	if findings[0].Message != want {
+		t.Errorf("expected message %q, got %q", want, findings[0].Message)
+	}
+	if findings[0].Line != 5 {
+		t.Errorf("expected the finding at the duplicate's line 5, got %d", findings[0].Line)
+	}
+}
+
+func TestValidateSchema_Column(t *testing.T) {
+	schema := []byte(`{
+		"type": "object",
+		"properties": {
+			"service": {
+				"type": "object",
+				"properties": {"port": {"type": "integer", "maximum": 65535}}
+			}
+		}
+	}`)
+
+	user := parseYAML(t, `
+service:
+    port: 70000
+`)
+	findings, err := validateSchema(user, schema, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(findings) != 1 || findings[0].Line != 3 || findings[0].Column != 5 {
+		t.Errorf("expected one finding at 3:5, got %v", findings)
+	}
+
+	// Findings about the root have a line but no column
+	findings, err = validateSchema(user, []byte(`{"type": "object", "required": ["image"]}`), nil, nil, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(findings) != 1 || findings[0].Column != 0 || !strings.HasPrefix(findings[0].String(), "line 2: ") {
+		t.Errorf("expected one root finding without a column, got %v", findings)
+	}
+}
+
+func TestValidateSchema_ConditionalRequired(t *testing.T) {
+	schema := []byte(`{
+		"type": "object",
+		"properties": {
+			"persistence": {
+				"type": "object",
+				"properties": {
+					"enabled": {"type": "boolean"},
+					"size": {"type": "string"},
+					"existingClaim": {"type": "string"}
+				},
+				"if": {"properties": {"enabled": {"const": true}}, "required": ["enabled"]},
+				"then": {"required": ["size"]},
+				"else": {"required": ["existingClaim"]}
+			},
+			"mode": {"type": "string"},
+			"replicas": {"type": "integer"}
+		},
+		"allOf": [
+			{
+				"if": {"properties": {"mode": {"enum": ["ha", "cluster"]}}, "required": ["mode"]},
+				"then": {"required": ["replicas"]}
+			}
+		]
+	}`)
+
+	tests := []struct {
+		name, values, want string
+	}{
+		{"then", "persistence:\n  enabled: true\n", "Schema validation: persistence.size is required when persistence.enabled is true"},
+		{"else", "persistence:\n  enabled: false\n", "Schema validation: persistence.existingClaim is required unless persistence.enabled is true"},
+		{"allOf enum", "mode: ha\n", `Schema validation: replicas is required when mode is "ha" or "cluster"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			findings, err := validateSchema(parseYAML(t, tt.values), schema, nil, nil, nil)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(findings) != 1 {
+				t.Fatalf("expected 1 finding, got %d: %v", len(findings), findings)
+			}
+			if findings[0].Rule != model.RuleSchemaRequired || findings[0].Message != tt.want {
+				t.Errorf("expected %q, got %s %q", tt.want, findings[0].Rule, findings[0].Message)
+			}
+		})
+	}
+}
+
+func TestValidateSchema_MultipleOf(t *testing.T) {
+	schema := []byte(`{
+		"type": "object",
+		"properties": {
+			"probe": {
+				"type": "object",
+				"properties": {
+					"periodSeconds": {"type": "integer", "multipleOf": 5},
+					"ratio": {"type": "number", "multipleOf": 0.25}
+				}
+			}
+		}
+	}`)
+
+	tests := []struct {
+		values string
+		want   string // "" for no finding
+	}{
+		{"probe:\n  periodSeconds: 12\n", "Schema validation: probe.periodSeconds 12 must be a multiple of 5"},
+		{"probe:\n  ratio: 0.3\n", "Schema validation: probe.ratio 0.3 must be a multiple of 0.25"},
+		{"probe:\n  periodSeconds: 15\n  ratio: 0.75\n", ""},
+	}
+	for _, tt := range tests {
+		findings, err := validateSchema(parseYAML(t, tt.values), schema, nil, nil, nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if tt.want == "" {
+			if len(findings) != 0 {
+				t.Errorf("%q: expected no findings, got %v", tt.values, findings)
+			}
+			continue
+		}
+		if len(findings) != 1 || findings[0].Message != tt.want || findings[0].Rule != "schema-multiple-of" {
+			t.Errorf("%q: expected %q, got %v", tt.values, tt.want, findings)
+		}
+	}
+}
+
+func TestCheckDeprecated_DocURL(t *testing.T) {
+	schema := []byte(`{
+		"type": "object",
+		"properties": {
+			"oldSetting": {"type": "string", "deprecated": true, "x-docs": "https://example.com/docs/migration#oldSetting"},
+			"legacyMode": {"type": "boolean", "deprecated": true, "$comment": "kept for 2.x charts"}
+		}
+	}`)
+
+	user := parseYAML(t, `
+oldSetting: "some-value"
+legacyMode: true
+`)
+	findings := checkDeprecated(user, schema, nil)
+	if len(findings) != 2 {
+		t.Fatalf("expected 2 findings, got %d: %v", len(findings), findings)
+	}
+	for _, f := range findings {
+		want := ""
+		if f.KeyPath == "oldSetting" {
+			want = "https://example.com/docs/migration#oldSetting"
+		}
+		if f.DocURL != want {
+			t.Errorf("%s: expected DocURL %q, got %q", f.KeyPath, want, f.DocURL)
+		}
+	}
+}
+
+func TestValidateSchema_DocURL(t *testing.T) {
+	schema := []byte(`{
+		"type": "object",
+		"required": ["auth"],
+		"properties": {
+			"auth": {"type": "object", "$comment": "https://example.com/docs/auth"},
+			"replicaCount": {"type": "integer", "minimum": 1, "x-docs": "https://example.com/docs/scaling"}
+		}
+	}`)
+
+	findings, err := validateSchema(parseYAML(t, "replicaCount: 0\n"), schema, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	urls := map[string]string{}
+	for _, f := range findings {
+		urls[f.Rule] = f.DocURL
+	}
+	if urls[model.RuleSchemaRequired] != "https://example.com/docs/auth" || urls[model.RuleSchemaRange] != "https://example.com/docs/scaling" {
+		t.Errorf("expected the doc links of auth and replicaCount, got %v", findings)
+	}
+}
+
+func TestSchemaRule_RegisteredForEveryErrorType(t *testing.T) {
+	registered := make(map[string]bool)
+	for _, r := range model.Rules {
+		registered[r.ID] = true
+	}
+	// Every error type gojsonschema reports
+	types := []string{
+		"false", "required", "invalid_type", "number_any_of", "number_one_of", "number_all_of",
+		"number_not", "missing_dependency", "internal", "const", "enum", "array_no_additional_items",
+		"array_min_items", "array_max_items", "unique", "contains", "array_min_properties",
+		"array_max_properties", "additional_property_not_allowed", "invalid_property_pattern",
+		"invalid_property_name", "string_gte", "string_lte", "pattern", "format", "multiple_of",
+		"number_gte", "number_gt", "number_lte", "number_lt", "condition_then", "condition_else",
+	}
+	for _, typ := range types {
+		if rule := schemaRule(typ); !registered[rule] {
+			t.Errorf("schema error type %s maps to unregistered rule %q", typ, rule)
+		}
+	}
+	if got := schemaRule("number_one_of"); got != model.RuleSchemaOneOf {
+		t.Errorf("expected oneOf failures to report %s, got %s", model.RuleSchemaOneOf, got)
+	}
+}
@@ -2,10 +2,13 @@ package validator
 
 import (
 	"fmt"
+	"math"
+	"regexp"
 	"strings"
 
 	"github.com/chrishham/helm-values-checker/internal/model"
 	"gopkg.in/yaml.v3"
+	"k8s.io/apimachinery/pkg/api/resource"
 )
 
 // jsonSchemaTypeToYAMLTags maps a JSON Schema type name to the set of
@@ -62,22 +65,17 @@ func friendlyTypes(tags []string) string {
 		}
 	}
 
-	switch len(unique) {
-	case 0:
+	if len(unique) == 0 {
 		return "unknown"
-	case 1:
-		return unique[0]
-	case 2:
-		return unique[0] + " or " + unique[1]
-	default:
-		return strings.Join(unique[:len(unique)-1], ", ") + ", or " + unique[len(unique)-1]
 	}
+	return orList(unique)
 }
 
 // detectTypeMismatches walks matching keys between user and default trees
 // and reports type mismatches. When schemaTypes is non-nil, it is used as
-// a fallback for keys whose default is null or absent.
-func detectTypeMismatches(userNode, defaultsNode *yaml.Node, ignoreKeys []string, path string, schemaTypes SchemaTypeMap) []model.Finding {
+// a fallback for keys whose default is null or absent. Paths matching
+// quantityPaths are treated as Kubernetes quantities (see isQuantityPath).
+func detectTypeMismatches(userNode, defaultsNode *yaml.Node, ignoreKeys, quantityPaths []string, path string, schemaTypes SchemaTypeMap) []model.Finding {
 	var findings []model.Finding
 
 	if userNode == nil || defaultsNode == nil {
@@ -101,49 +99,17 @@ func detectTypeMismatches(userNode, defaultsNode *yaml.Node, ignoreKeys []string
 		defaultVal := getValueForKey(defaultsNode, key)
 		if defaultVal == nil {
 			// Key not in defaults — check schema types if available
-			if schemaTypes != nil {
-				if allowedTypes, ok := schemaTypes[fullPath]; ok {
-					if valNode.ShortTag() != "!!null" {
-						compatible, allowedTags := schemaTypesCompatible(valNode.ShortTag(), allowedTypes)
-						if !compatible && !(isResourceQuantityPath(fullPath) && isStringIntMismatch(valNode.ShortTag(), allowedTags[0])) {
-							findings = append(findings, model.Finding{
-								Severity: model.SeverityError,
-								Line:     valNode.Line,
-								KeyPath:  fullPath,
-								Message:  fmt.Sprintf("Type mismatch at %q: expected %s, got %s (%q)", fullPath, friendlyTypes(allowedTags), friendlyType(valNode.ShortTag()), valNode.Value),
-							})
-						}
-					}
-				}
-			}
+			findings = append(findings, checkSchemaType(valNode, ignoreKeys, quantityPaths, fullPath, schemaTypes)...)
 			continue
 		}
 
 		// Resolve aliases
-		if defaultVal.Kind == yaml.AliasNode && defaultVal.Alias != nil {
-			defaultVal = defaultVal.Alias
-		}
-		if valNode.Kind == yaml.AliasNode && valNode.Alias != nil {
-			valNode = valNode.Alias
-		}
+		defaultVal = resolveAlias(defaultVal)
+		valNode = resolveAlias(valNode)
 
 		// Null default — check schema types if available, otherwise accept any type
 		if defaultVal.ShortTag() == "!!null" {
-			if schemaTypes != nil {
-				if allowedTypes, ok := schemaTypes[fullPath]; ok {
-					if valNode.ShortTag() != "!!null" {
-						compatible, allowedTags := schemaTypesCompatible(valNode.ShortTag(), allowedTypes)
-						if !compatible && !(isResourceQuantityPath(fullPath) && isStringIntMismatch(valNode.ShortTag(), allowedTags[0])) {
-							findings = append(findings, model.Finding{
-								Severity: model.SeverityError,
-								Line:     valNode.Line,
-								KeyPath:  fullPath,
-								Message:  fmt.Sprintf("Type mismatch at %q: expected %s, got %s (%q)", fullPath, friendlyTypes(allowedTags), friendlyType(valNode.ShortTag()), valNode.Value),
-							})
-						}
-					}
-				}
-			}
+			findings = append(findings, checkSchemaType(valNode, ignoreKeys, quantityPaths, fullPath, schemaTypes)...)
 			continue
 		}
 
@@ -158,78 +124,333 @@ func detectTypeMismatches(userNode, defaultsNode *yaml.Node, ignoreKeys []string
 			if len(defaultVal.Content) == 0 {
 				continue
 			}
-			findings = append(findings, detectTypeMismatches(valNode, defaultVal, ignoreKeys, fullPath, schemaTypes)...)
+			findings = append(findings, detectTypeMismatches(valNode, defaultVal, ignoreKeys, quantityPaths, fullPath, schemaTypes)...)
 			continue
 		}
 
 		// Sequence comparison
 		if defaultVal.Kind == yaml.SequenceNode && valNode.Kind == yaml.SequenceNode {
-			findings = append(findings, checkSequence(valNode, defaultVal, ignoreKeys, fullPath, schemaTypes)...)
-			continue
-		}
-
-		// Kubernetes resource quantities (cpu, memory, etc.) accept both strings and numbers
-		if isResourceQuantityPath(fullPath) && isStringIntMismatch(valNode.ShortTag(), defaultVal.ShortTag()) {
+			findings = append(findings, checkSequence(valNode, defaultVal, ignoreKeys, quantityPaths, fullPath, schemaTypes)...)
 			continue
 		}
 
-		// Type comparison for scalars
-		if !typesCompatible(valNode.ShortTag(), defaultVal.ShortTag()) {
+		// Shape mismatch (e.g., a map where the default is a list), whatever
+		// the tags of the two nodes say
+		if valNode.Kind != defaultVal.Kind && valNode.Kind != yaml.ScalarNode {
 			findings = append(findings, model.Finding{
 				Severity: model.SeverityError,
+				Rule:     model.RuleTypeMismatch,
 				Line:     valNode.Line,
+				Column:   valNode.Column,
 				KeyPath:  fullPath,
-				Message:  fmt.Sprintf("Type mismatch at %q: expected %s, got %s (%q)", fullPath, friendlyType(defaultVal.ShortTag()), friendlyType(valNode.ShortTag()), valNode.Value),
+				Message:  fmt.Sprintf("Type mismatch at %q: expected %s, got %s", fullPath, shapeName(defaultVal), shapeName(valNode)),
 			})
 			continue
 		}
 
-		// Kind mismatch (e.g., user provides scalar where mapping expected)
-		if defaultVal.Kind != valNode.Kind && defaultVal.Kind != yaml.ScalarNode && valNode.Kind != yaml.ScalarNode {
+		// Kubernetes resource quantities (cpu, memory, etc.) accept both
+		// strings and numbers, but strings must parse as a quantity
+		if isQuantityPath(fullPath, quantityPaths) {
+			if f, ok := quantityFinding(valNode, defaultVal, fullPath); ok {
+				findings = append(findings, f)
+				continue
+			}
+			if isStringIntMismatch(valNode.ShortTag(), defaultVal.ShortTag()) {
+				continue
+			}
+		}
+
+		// Type comparison for scalars
+		if !typesCompatible(valNode.ShortTag(), defaultVal.ShortTag()) {
+			if f, ok := placeholderFinding(valNode, fullPath, []string{defaultVal.ShortTag()}); ok {
+				findings = append(findings, f)
+				continue
+			}
+			msg := fmt.Sprintf("Type mismatch at %q: expected %s, got %s (%q)", fullPath, friendlyType(defaultVal.ShortTag()), valueType(valNode), valNode.Value)
+			msg += quoteHint(valNode, func(tag string) bool { return typesCompatible(tag, defaultVal.ShortTag()) })
+			msg += boolHint(valNode, []string{defaultVal.ShortTag()})
 			findings = append(findings, model.Finding{
 				Severity: model.SeverityError,
+				Rule:     model.RuleTypeMismatch,
 				Line:     valNode.Line,
+				Column:   valNode.Column,
 				KeyPath:  fullPath,
-				Message:  fmt.Sprintf("Type mismatch at %q: expected %s, got %s", fullPath, kindName(defaultVal.Kind), kindName(valNode.Kind)),
+				Message:  msg,
 			})
+			continue
 		}
+
 	}
 
 	return findings
 }
 
-// checkSequence validates elements in a user sequence against the first element
-// of the default sequence as a template.
-func checkSequence(userSeq, defaultSeq *yaml.Node, ignoreKeys []string, path string, schemaTypes SchemaTypeMap) []model.Finding {
+// checkSchemaType validates a value whose default is null or absent against
+// the schema-declared type for path, if any. Sequences are further checked
+// element by element against the schema's item type.
+func checkSchemaType(valNode *yaml.Node, ignoreKeys, quantityPaths []string, path string, schemaTypes SchemaTypeMap) []model.Finding {
 	var findings []model.Finding
 
-	if len(defaultSeq.Content) == 0 || len(userSeq.Content) == 0 {
+	if schemaTypes == nil {
 		return findings
 	}
+	valNode = resolveAlias(valNode)
+
+	if allowedTypes, ok := schemaTypes[schemaPath(path)]; ok {
+		if valNode.ShortTag() != "!!null" {
+			compatible, allowedTags := schemaTypesCompatible(valNode.ShortTag(), allowedTypes)
+			if !compatible && !(isQuantityPath(path, quantityPaths) && isStringIntMismatch(valNode.ShortTag(), allowedTags[0])) {
+				if f, ok := placeholderFinding(valNode, path, allowedTags); ok {
+					return append(findings, f)
+				}
+				msg := fmt.Sprintf("Type mismatch at %q: expected %s, got %s", path, friendlyTypes(allowedTags), valueType(valNode))
+				if valNode.Kind == yaml.ScalarNode {
+					msg += fmt.Sprintf(" (%q)", valNode.Value)
+					msg += quoteHint(valNode, func(tag string) bool { ok, _ := schemaTypesCompatible(tag, allowedTypes); return ok })
+					msg += boolHint(valNode, allowedTags)
+				}
+				findings = append(findings, model.Finding{
+					Severity: model.SeverityError,
+					Rule:     model.RuleTypeMismatch,
+					Line:     valNode.Line,
+					Column:   valNode.Column,
+					KeyPath:  path,
+					Message:  msg,
+				})
+				return findings
+			}
+		}
+	}
 
-	template := defaultSeq.Content[0]
-	if template.Kind == yaml.AliasNode && template.Alias != nil {
-		template = template.Alias
+	if valNode.Kind == yaml.SequenceNode {
+		findings = append(findings, checkSequence(valNode, &yaml.Node{Kind: yaml.SequenceNode}, ignoreKeys, quantityPaths, path, schemaTypes)...)
 	}
 
-	if template.Kind != yaml.MappingNode {
+	return findings
+}
+
+// checkSequence validates elements in a user sequence. Scalar elements are
+// checked against the schema item type ("<path>[*]" in schemaTypes); mapping
+// elements are checked against the first element of the default sequence as
+// a template, falling back to schema item properties when there is none.
+func checkSequence(userSeq, defaultSeq *yaml.Node, ignoreKeys, quantityPaths []string, path string, schemaTypes SchemaTypeMap) []model.Finding {
+	var findings []model.Finding
+
+	if len(userSeq.Content) == 0 {
 		return findings
 	}
 
+	var template *yaml.Node
+	if len(defaultSeq.Content) > 0 {
+		template = resolveAlias(defaultSeq.Content[0])
+	}
+
+	itemTypes, hasItemTypes := schemaTypes[schemaPath(path)+"[*]"]
+
 	for idx, elem := range userSeq.Content {
-		if elem.Kind == yaml.AliasNode && elem.Alias != nil {
-			elem = elem.Alias
+		elem = resolveAlias(elem)
+		elemPath := fmt.Sprintf("%s[%d]", path, idx)
+		if matchesIgnore(elemPath, ignoreKeys) {
+			continue
+		}
+
+		if hasItemTypes && elem.ShortTag() != "!!null" {
+			if compatible, allowedTags := schemaTypesCompatible(elem.ShortTag(), itemTypes); !compatible {
+				if f, ok := placeholderFinding(elem, elemPath, allowedTags); ok {
+					findings = append(findings, f)
+					continue
+				}
+				msg := fmt.Sprintf("Type mismatch at %q: expected %s, got %s", elemPath, friendlyTypes(allowedTags), valueType(elem))
+				if elem.Kind == yaml.ScalarNode {
+					msg += fmt.Sprintf(" (%q)", elem.Value)
+					msg += quoteHint(elem, func(tag string) bool { ok, _ := schemaTypesCompatible(tag, itemTypes); return ok })
+				}
+				findings = append(findings, model.Finding{
+					Severity: model.SeverityError,
+					Rule:     model.RuleTypeMismatch,
+					Line:     elem.Line,
+					Column:   elem.Column,
+					KeyPath:  elemPath,
+					Message:  msg,
+				})
+				continue
+			}
+		}
+
+		// Without a schema item type, the default's first element sets the
+		// expected shape of the elements
+		if !hasItemTypes && template != nil && template.Kind != yaml.ScalarNode && elem.Kind != template.Kind && elem.ShortTag() != "!!null" {
+			msg := fmt.Sprintf("Type mismatch at %q: expected %s, got %s", elemPath, shapeName(template), shapeName(elem))
+			if elem.Kind == yaml.ScalarNode {
+				msg += fmt.Sprintf(" (%q)", elem.Value)
+			}
+			findings = append(findings, model.Finding{
+				Severity: model.SeverityError,
+				Rule:     model.RuleTypeMismatch,
+				Line:     elem.Line,
+				Column:   elem.Column,
+				KeyPath:  elemPath,
+				Message:  msg,
+			})
+			continue
+		}
+
+		if elem.Kind != yaml.MappingNode {
+			continue
+		}
+		if template != nil && template.Kind == yaml.MappingNode {
+			findings = append(findings, detectUnknownKeys(elem, template, nil, nil, nil, ignoreKeys, elemPath, nil, nil, false, true)...)
+			findings = append(findings, detectTypeMismatches(elem, template, ignoreKeys, quantityPaths, elemPath, schemaTypes)...)
+		} else if hasItemTypes {
+			findings = append(findings, detectTypeMismatches(elem, &yaml.Node{Kind: yaml.MappingNode}, ignoreKeys, quantityPaths, elemPath, schemaTypes)...)
+		}
+	}
+
+	return findings
+}
+
+// checkIntegerFormats walks the user values and reports integers that do
+// not fit the int32 or int64 format the schema declares for their path.
+// formats maps schema paths to formats, as from extractIntegerFormats.
+func checkIntegerFormats(node *yaml.Node, formats map[string]string, ignoreKeys []string, path string) []model.Finding {
+	var findings []model.Finding
+
+	if len(formats) == 0 || node == nil {
+		return findings
+	}
+	if path != "" && matchesIgnore(path, ignoreKeys) {
+		return findings
+	}
+	node = resolveAlias(node)
+
+	switch node.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			findings = append(findings, checkIntegerFormats(node.Content[i+1], formats, ignoreKeys, joinPath(path, node.Content[i].Value))...)
+		}
+	case yaml.SequenceNode:
+		for idx, elem := range node.Content {
+			findings = append(findings, checkIntegerFormats(elem, formats, ignoreKeys, fmt.Sprintf("%s[%d]", path, idx))...)
 		}
-		if elem.Kind == yaml.MappingNode {
-			elemPath := fmt.Sprintf("%s[%d]", path, idx)
-			findings = append(findings, detectUnknownKeys(elem, template, nil, nil, ignoreKeys, elemPath, nil)...)
-			findings = append(findings, detectTypeMismatches(elem, template, ignoreKeys, elemPath, schemaTypes)...)
+	case yaml.ScalarNode:
+		format, ok := formats[schemaPath(path)]
+		if !ok || node.ShortTag() != "!!int" {
+			return findings
+		}
+		lo, hi := int64(math.MinInt64), int64(math.MaxInt64)
+		if format == "int32" {
+			lo, hi = math.MinInt32, math.MaxInt32
 		}
+		// Values beyond int64 fail to decode and overflow either format
+		var n int64
+		if err := node.Decode(&n); err == nil && n >= lo && n <= hi {
+			return findings
+		}
+		findings = append(findings, model.Finding{
+			Severity: model.SeverityError,
+			Rule:     model.RuleIntOverflow,
+			Line:     node.Line,
+			Column:   node.Column,
+			KeyPath:  path,
+			Message:  fmt.Sprintf("Value %s at %q overflows %s: must be between %d and %d", node.Value, path, format, lo, hi),
+		})
 	}
 
 	return findings
 }
 
+// placeholderRe matches an unexpanded envsubst-style placeholder (e.g., "${REPLICAS}").
+var placeholderRe = regexp.MustCompile(`^\$\{[A-Z0-9_]+\}$`)
+
+// placeholderFinding returns a warning in place of a type mismatch when a
+// string value looks like an unexpanded placeholder and none of the expected
+// tags is a string. Such values are usually substituted before deployment.
+func placeholderFinding(valNode *yaml.Node, path string, expectedTags []string) (model.Finding, bool) {
+	if valNode.Kind != yaml.ScalarNode || valNode.ShortTag() != "!!str" || !placeholderRe.MatchString(valNode.Value) {
+		return model.Finding{}, false
+	}
+	for _, tag := range expectedTags {
+		if tag == "!!str" {
+			return model.Finding{}, false
+		}
+	}
+
+	return model.Finding{
+		Severity: model.SeverityWarning,
+		Rule:     model.RuleUnexpandedPlaceholder,
+		Line:     valNode.Line,
+		Column:   valNode.Column,
+		KeyPath:  path,
+		Message:  fmt.Sprintf("Value %q at %q looks like an unexpanded placeholder for a %s field", valNode.Value, path, placeholderFieldType(expectedTags)),
+	}, true
+}
+
+// templateRe matches a Go template action in a string (e.g., "{{ .Release.Name }}").
+var templateRe = regexp.MustCompile(`\{\{.*\}\}`)
+
+// dropTemplatedMismatches returns findings without the type mismatches and
+// invalid quantities on string values that contain a template action,
+// which are rendered later (e.g., by helm template) and so have no type
+// yet. Mismatches are matched to values by position, so values without a
+// line, such as --set values, are kept.
+func dropTemplatedMismatches(findings []model.Finding, userNode *yaml.Node) []model.Finding {
+	templated := make(map[[2]int]bool)
+	collectTemplated(userNode, templated)
+	if len(templated) == 0 {
+		return findings
+	}
+	kept := findings[:0]
+	for _, f := range findings {
+		deferred := f.Rule == model.RuleTypeMismatch || f.Rule == model.RuleInvalidQuantity
+		if !deferred || !templated[[2]int{f.Line, f.Column}] {
+			kept = append(kept, f)
+		}
+	}
+	return kept
+}
+
+// collectTemplated records the positions of the string scalars under node
+// that contain a template action. Aliases are not followed; the nodes they
+// point to are recorded where they are defined.
+func collectTemplated(node *yaml.Node, positions map[[2]int]bool) {
+	if node == nil {
+		return
+	}
+	if node.Kind == yaml.ScalarNode {
+		if node.ShortTag() == "!!str" && node.Line > 0 && templateRe.MatchString(node.Value) {
+			positions[[2]int{node.Line, node.Column}] = true
+		}
+		return
+	}
+	for _, child := range node.Content {
+		collectTemplated(child, positions)
+	}
+}
+
+// placeholderFieldType describes the expected type for placeholder warnings.
+func placeholderFieldType(tags []string) string {
+	numeric, boolean := true, true
+	for _, tag := range tags {
+		switch tag {
+		case "!!int", "!!float":
+			boolean = false
+		case "!!bool":
+			numeric = false
+		case "!!null":
+		default:
+			numeric, boolean = false, false
+		}
+	}
+	switch {
+	case numeric:
+		return "numeric"
+	case boolean:
+		return "boolean"
+	default:
+		return friendlyTypes(tags)
+	}
+}
+
 // typesCompatible checks if two yaml tags are compatible types.
 func typesCompatible(userTag, defaultTag string) bool {
 	if userTag == defaultTag {
@@ -248,6 +469,47 @@ func typesCompatible(userTag, defaultTag string) bool {
 	return false
 }
 
+// valueType describes the type of a user value for messages, telling a
+// quoted string ("3") apart from the number it looks like.
+func valueType(node *yaml.Node) string {
+	if isQuotedString(node) {
+		return "quoted string"
+	}
+	return friendlyType(node.ShortTag())
+}
+
+// quoteHint suggests dropping the quotes from a quoted string when the value
+// unquoted would have a type accepted by compatible, or returns "".
+func quoteHint(node *yaml.Node, compatible func(tag string) bool) string {
+	if !isQuotedString(node) {
+		return ""
+	}
+	tag := (&yaml.Node{Kind: yaml.ScalarNode, Value: node.Value}).ShortTag()
+	if tag == "!!str" || !compatible(tag) {
+		return ""
+	}
+	return fmt.Sprintf("; remove the quotes to pass it as %s", friendlyType(tag))
+}
+
+// boolHint points out that booleans are spelled true and false when the
+// value is the integer 0 or 1 and a bool is expected, or returns "".
+func boolHint(node *yaml.Node, expectedTags []string) string {
+	if node.ShortTag() != "!!int" || (node.Value != "0" && node.Value != "1") {
+		return ""
+	}
+	for _, tag := range expectedTags {
+		if tag == "!!bool" {
+			return "; use true/false, not 1/0"
+		}
+	}
+	return ""
+}
+
+// isQuotedString reports whether node is a single- or double-quoted scalar.
+func isQuotedString(node *yaml.Node) bool {
+	return node.Kind == yaml.ScalarNode && node.ShortTag() == "!!str" && node.Style&(yaml.DoubleQuotedStyle|yaml.SingleQuotedStyle) != 0
+}
+
 func friendlyType(tag string) string {
 	switch tag {
 	case "!!str":
@@ -269,6 +531,15 @@ func friendlyType(tag string) string {
 	}
 }
 
+// isQuantityPath reports whether path holds a Kubernetes quantity, where a
+// string like "10Gi" and a plain number are interchangeable: either a
+// resources.{limits,requests} field or a path matching one of the given
+// glob patterns. Patterns may use "[*]" for sequence indices, as schema
+// paths do.
+func isQuantityPath(path string, quantityPaths []string) bool {
+	return isResourceQuantityPath(path) || matchesIgnore(path, quantityPaths) || matchesIgnore(schemaPath(path), quantityPaths)
+}
+
 // isResourceQuantityPath returns true if the path looks like a Kubernetes
 // resource quantity field (e.g., resources.limits.cpu, resources.requests.memory).
 func isResourceQuantityPath(path string) bool {
@@ -285,23 +556,48 @@ func isResourceQuantityPath(path string) bool {
 	return false
 }
 
+// quantityFinding returns an error when a string value at a quantity path
+// does not parse as a Kubernetes quantity (e.g., "2GG"). Only defaults that
+// are themselves quantities (strings or numbers) are considered, and
+// unexpanded placeholders are left alone.
+func quantityFinding(valNode, defaultVal *yaml.Node, path string) (model.Finding, bool) {
+	if valNode.Kind != yaml.ScalarNode || valNode.ShortTag() != "!!str" || placeholderRe.MatchString(valNode.Value) {
+		return model.Finding{}, false
+	}
+	switch defaultVal.ShortTag() {
+	case "!!str", "!!int", "!!float":
+	default:
+		return model.Finding{}, false
+	}
+	if _, err := resource.ParseQuantity(valNode.Value); err == nil {
+		return model.Finding{}, false
+	}
+
+	return model.Finding{
+		Severity: model.SeverityError,
+		Rule:     model.RuleInvalidQuantity,
+		Line:     valNode.Line,
+		Column:   valNode.Column,
+		KeyPath:  path,
+		Message:  fmt.Sprintf("Invalid quantity %q at %q: expected a Kubernetes quantity such as 128Mi, 2Gi, or 500m", valNode.Value, path),
+	}, true
+}
+
 // isStringIntMismatch returns true if one tag is string and the other is int or float.
 func isStringIntMismatch(tagA, tagB string) bool {
 	numeric := map[string]bool{"!!int": true, "!!float": true}
 	return (tagA == "!!str" && numeric[tagB]) || (tagB == "!!str" && numeric[tagA])
 }
 
-func kindName(k yaml.Kind) string {
-	switch k {
-	case yaml.ScalarNode:
-		return "scalar"
+// shapeName names the shape of a node for messages: list, map, or the
+// type of a scalar.
+func shapeName(node *yaml.Node) string {
+	switch node.Kind {
 	case yaml.MappingNode:
-		return "mapping"
+		return "map"
 	case yaml.SequenceNode:
-		return "sequence"
-	case yaml.AliasNode:
-		return "alias"
+		return "list"
 	default:
-		return "unknown"
+		return friendlyType(node.ShortTag())
 	}
 }
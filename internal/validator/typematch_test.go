@@ -1,7 +1,11 @@
 package validator
 
 import (
+	"strings"
 	"testing"
+
+	"github.com/chrishham/helm-values-checker/internal/model"
+	"gopkg.in/yaml.v3"
 )
 
 func TestDetectTypeMismatches_NoMismatches(t *testing.T) {
@@ -19,7 +23,7 @@ image:
   tag: "v1.0"
 enabled: false
 `)
-	findings := detectTypeMismatches(user, defaults, nil, "", nil)
+	findings := detectTypeMismatches(user, defaults, nil, nil, "", nil)
 	if len(findings) != 0 {
 		t.Errorf("expected no findings, got %d: %v", len(findings), findings)
 	}
@@ -32,7 +36,7 @@ replicaCount: 1
 	user := parseYAML(t, `
 replicaCount: "three"
 `)
-	findings := detectTypeMismatches(user, defaults, nil, "", nil)
+	findings := detectTypeMismatches(user, defaults, nil, nil, "", nil)
 	if len(findings) != 1 {
 		t.Fatalf("expected 1 finding, got %d: %v", len(findings), findings)
 	}
@@ -48,7 +52,7 @@ name: "default"
 	user := parseYAML(t, `
 name: true
 `)
-	findings := detectTypeMismatches(user, defaults, nil, "", nil)
+	findings := detectTypeMismatches(user, defaults, nil, nil, "", nil)
 	if len(findings) != 1 {
 		t.Fatalf("expected 1 finding, got %d: %v", len(findings), findings)
 	}
@@ -61,7 +65,7 @@ customValue: null
 	user := parseYAML(t, `
 customValue: "anything-goes"
 `)
-	findings := detectTypeMismatches(user, defaults, nil, "", nil)
+	findings := detectTypeMismatches(user, defaults, nil, nil, "", nil)
 	if len(findings) != 0 {
 		t.Errorf("expected no findings for null default, got %d: %v", len(findings), findings)
 	}
@@ -74,7 +78,7 @@ ratio: 1.5
 	user := parseYAML(t, `
 ratio: 2
 `)
-	findings := detectTypeMismatches(user, defaults, nil, "", nil)
+	findings := detectTypeMismatches(user, defaults, nil, nil, "", nil)
 	if len(findings) != 0 {
 		t.Errorf("expected no findings for int/float compat, got %d: %v", len(findings), findings)
 	}
@@ -93,7 +97,7 @@ config:
     timeout: 30s
     retries: "three"
 `)
-	findings := detectTypeMismatches(user, defaults, nil, "", nil)
+	findings := detectTypeMismatches(user, defaults, nil, nil, "", nil)
 	if len(findings) != 1 {
 		t.Fatalf("expected 1 finding for nested type mismatch, got %d: %v", len(findings), findings)
 	}
@@ -121,7 +125,7 @@ resources:
     cpu: 2
     memory: 512
 `)
-	findings := detectTypeMismatches(user, defaults, nil, "", nil)
+	findings := detectTypeMismatches(user, defaults, nil, nil, "", nil)
 	if len(findings) != 0 {
 		t.Errorf("expected no findings for resource quantity int/string compat, got %d:", len(findings))
 		for _, f := range findings {
@@ -147,7 +151,7 @@ clickhouse:
         cpu: 10
         memory: 4096
 `)
-	findings := detectTypeMismatches(user, defaults, nil, "", nil)
+	findings := detectTypeMismatches(user, defaults, nil, nil, "", nil)
 	if len(findings) != 0 {
 		t.Errorf("expected no findings for deeply nested resource quantity, got %d:", len(findings))
 		for _, f := range findings {
@@ -167,7 +171,7 @@ podSecurityContext:
   fsGroup: "2000"
 replicaCount: 2
 `)
-	findings := detectTypeMismatches(user, defaults, nil, "", nil)
+	findings := detectTypeMismatches(user, defaults, nil, nil, "", nil)
 	if len(findings) != 0 {
 		t.Errorf("expected no findings for empty map default children, got %d:", len(findings))
 		for _, f := range findings {
@@ -189,7 +193,7 @@ config:
     name: true
     count: 5
 `)
-	findings := detectTypeMismatches(user, defaults, nil, "", nil)
+	findings := detectTypeMismatches(user, defaults, nil, nil, "", nil)
 	if len(findings) != 1 {
 		t.Fatalf("expected 1 finding for non-empty map type mismatch, got %d: %v", len(findings), findings)
 	}
@@ -205,7 +209,7 @@ name: "default"
 	user := parseYAML(t, `
 name: null
 `)
-	findings := detectTypeMismatches(user, defaults, nil, "", nil)
+	findings := detectTypeMismatches(user, defaults, nil, nil, "", nil)
 	if len(findings) != 0 {
 		t.Errorf("expected no findings for user null, got %d: %v", len(findings), findings)
 	}
@@ -219,7 +223,7 @@ maxRetries: null
 maxRetries: "not-a-number"
 `)
 	schema := SchemaTypeMap{"maxRetries": {"integer", "null"}}
-	findings := detectTypeMismatches(user, defaults, nil, "", schema)
+	findings := detectTypeMismatches(user, defaults, nil, nil, "", schema)
 	if len(findings) != 1 {
 		t.Fatalf("expected 1 finding, got %d: %v", len(findings), findings)
 	}
@@ -236,7 +240,7 @@ label: null
 label: "hello"
 `)
 	schema := SchemaTypeMap{"label": {"string"}}
-	findings := detectTypeMismatches(user, defaults, nil, "", schema)
+	findings := detectTypeMismatches(user, defaults, nil, nil, "", schema)
 	if len(findings) != 0 {
 		t.Errorf("expected no findings, got %d: %v", len(findings), findings)
 	}
@@ -250,7 +254,7 @@ maxRetries: null
 maxRetries: 5
 `)
 	schema := SchemaTypeMap{"maxRetries": {"integer", "null"}}
-	findings := detectTypeMismatches(user, defaults, nil, "", schema)
+	findings := detectTypeMismatches(user, defaults, nil, nil, "", schema)
 	if len(findings) != 0 {
 		t.Errorf("expected no findings for int matching integer|null, got %d: %v", len(findings), findings)
 	}
@@ -264,7 +268,7 @@ maxRetries: null
 maxRetries: true
 `)
 	schema := SchemaTypeMap{"maxRetries": {"integer", "null"}}
-	findings := detectTypeMismatches(user, defaults, nil, "", schema)
+	findings := detectTypeMismatches(user, defaults, nil, nil, "", schema)
 	if len(findings) != 1 {
 		t.Fatalf("expected 1 finding for bool vs integer|null, got %d: %v", len(findings), findings)
 	}
@@ -277,7 +281,7 @@ customValue: null
 	user := parseYAML(t, `
 customValue: "anything-goes"
 `)
-	findings := detectTypeMismatches(user, defaults, nil, "", nil)
+	findings := detectTypeMismatches(user, defaults, nil, nil, "", nil)
 	if len(findings) != 0 {
 		t.Errorf("expected no findings for null default without schema, got %d: %v", len(findings), findings)
 	}
@@ -292,7 +296,7 @@ other: "value"
 schemaOnly: true
 `)
 	schema := SchemaTypeMap{"schemaOnly": {"string"}}
-	findings := detectTypeMismatches(user, defaults, nil, "", schema)
+	findings := detectTypeMismatches(user, defaults, nil, nil, "", schema)
 	if len(findings) != 1 {
 		t.Fatalf("expected 1 finding for schema-only key type mismatch, got %d: %v", len(findings), findings)
 	}
@@ -309,7 +313,7 @@ ratio: null
 ratio: 42
 `)
 	schema := SchemaTypeMap{"ratio": {"number"}}
-	findings := detectTypeMismatches(user, defaults, nil, "", schema)
+	findings := detectTypeMismatches(user, defaults, nil, nil, "", schema)
 	if len(findings) != 0 {
 		t.Errorf("expected no findings for int matching number schema, got %d: %v", len(findings), findings)
 	}
@@ -323,8 +327,403 @@ maxRetries: null
 maxRetries: null
 `)
 	schema := SchemaTypeMap{"maxRetries": {"integer"}}
-	findings := detectTypeMismatches(user, defaults, nil, "", schema)
+	findings := detectTypeMismatches(user, defaults, nil, nil, "", schema)
 	if len(findings) != 0 {
 		t.Errorf("expected no findings for user null regardless of schema, got %d: %v", len(findings), findings)
 	}
 }
+
+func TestDetectTypeMismatches_SchemaItems_StringListWithInt(t *testing.T) {
+	defaults := parseYAML(t, `
+tags: []
+`)
+	user := parseYAML(t, `
+tags:
+  - alpha
+  - 3
+`)
+	schema := extractSchemaTypes([]byte(`{
+		"properties": {
+			"tags": {"type": "array", "items": {"type": "string"}}
+		}
+	}`))
+	findings := detectTypeMismatches(user, defaults, nil, nil, "", schema)
+	if len(findings) != 1 {
+		t.Fatalf("expected 1 finding, got %d: %v", len(findings), findings)
+	}
+	if findings[0].KeyPath != "tags[1]" {
+		t.Errorf("expected keyPath 'tags[1]', got %q", findings[0].KeyPath)
+	}
+	if findings[0].Line != 4 {
+		t.Errorf("expected line 4, got %d", findings[0].Line)
+	}
+}
+
+func TestDetectTypeMismatches_SchemaItems_ObjectListMistypedField(t *testing.T) {
+	defaults := parseYAML(t, `
+ports: []
+`)
+	user := parseYAML(t, `
+ports:
+  - name: http
+    containerPort: 8080
+  - name: metrics
+    containerPort: "9090"
+`)
+	schema := extractSchemaTypes([]byte(`{
+		"properties": {
+			"ports": {
+				"type": "array",
+				"items": {
+					"type": "object",
+					"properties": {
+						"name": {"type": "string"},
+						"containerPort": {"type": "integer"}
+					}
+				}
+			}
+		}
+	}`))
+	findings := detectTypeMismatches(user, defaults, nil, nil, "", schema)
+	if len(findings) != 1 {
+		t.Fatalf("expected 1 finding, got %d: %v", len(findings), findings)
+	}
+	if findings[0].KeyPath != "ports[1].containerPort" {
+		t.Errorf("expected keyPath 'ports[1].containerPort', got %q", findings[0].KeyPath)
+	}
+}
+
+func TestExtractSchemaTypes_ArrayItems(t *testing.T) {
+	types := extractSchemaTypes([]byte(`{
+		"properties": {
+			"hosts": {
+				"type": "array",
+				"items": {
+					"type": "object",
+					"properties": {"name": {"type": "string"}}
+				}
+			}
+		}
+	}`))
+	if got := types["hosts[*]"]; len(got) != 1 || got[0] != "object" {
+		t.Errorf("types[hosts[*]]: expected [object], got %v", got)
+	}
+	if got := types["hosts[*].name"]; len(got) != 1 || got[0] != "string" {
+		t.Errorf("types[hosts[*].name]: expected [string], got %v", got)
+	}
+}
+
+func TestDetectTypeMismatches_AnyOfUnion(t *testing.T) {
+	schemaBytes := []byte(`{
+		"properties": {
+			"port": {"anyOf": [{"type": "string"}, {"type": "integer"}]}
+		}
+	}`)
+	schemaTypes := extractSchemaTypes(schemaBytes)
+	if got := schemaTypes["port"]; len(got) != 2 || got[0] != "string" || got[1] != "integer" {
+		t.Fatalf("expected union [string integer], got %v", got)
+	}
+
+	defaults := parseYAML(t, `
+port: null
+`)
+	for _, value := range []string{`"http"`, `8080`} {
+		user := parseYAML(t, "port: "+value+"\n")
+		if findings := detectTypeMismatches(user, defaults, nil, nil, "", schemaTypes); len(findings) != 0 {
+			t.Errorf("expected port: %s to be accepted, got %v", value, findings)
+		}
+		schemaFindings, err := validateSchema(user, schemaBytes, nil, nil, schemaTypes)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(schemaFindings) != 0 {
+			t.Errorf("expected no schema findings for port: %s, got %v", value, schemaFindings)
+		}
+	}
+
+	user := parseYAML(t, `
+port: true
+`)
+	if findings := detectTypeMismatches(user, defaults, nil, nil, "", schemaTypes); len(findings) != 1 {
+		t.Errorf("expected 1 type mismatch for a bool port, got %v", findings)
+	}
+	schemaFindings, err := validateSchema(user, schemaBytes, nil, nil, schemaTypes)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(schemaFindings) != 0 {
+		t.Errorf("expected the anyOf error to be left to the type checker, got %v", schemaFindings)
+	}
+}
+
+func TestExtractSchemaTypes_OpaqueUnion(t *testing.T) {
+	schemaTypes := extractSchemaTypes([]byte(`{
+		"properties": {
+			"size": {"oneOf": [{"type": "string", "pattern": "^[0-9]+Gi$"}, {"type": "integer"}]}
+		}
+	}`))
+	if got, ok := schemaTypes["size"]; ok {
+		t.Errorf("expected no type entry for a union with constrained branches, got %v", got)
+	}
+}
+
+func TestDetectTypeMismatches_PlaceholderForIntegerField(t *testing.T) {
+	defaults := parseYAML(t, `
+replicaCount: 1
+maxRetries: null
+`)
+	user := parseYAML(t, `
+replicaCount: "${REPLICAS}"
+maxRetries: "${MAX_RETRIES}"
+`)
+	schemaTypes := extractSchemaTypes([]byte(`{
+		"properties": {
+			"maxRetries": {"type": "integer"}
+		}
+	}`))
+
+	findings := detectTypeMismatches(user, defaults, nil, nil, "", schemaTypes)
+	if len(findings) != 2 {
+		t.Fatalf("expected 2 findings, got %d: %v", len(findings), findings)
+	}
+	for _, f := range findings {
+		if f.Severity != model.SeverityWarning || f.Rule != model.RuleUnexpandedPlaceholder {
+			t.Errorf("expected placeholder warning for %s, got %+v", f.KeyPath, f)
+		}
+	}
+	want := `Value "${REPLICAS}" at "replicaCount" looks like an unexpanded placeholder for a numeric field`
+	if findings[0].Message != want {
+		t.Errorf("expected message %q, got %q", want, findings[0].Message)
+	}
+}
+
+func TestDetectTypeMismatches_PlaceholderRequiresExactForm(t *testing.T) {
+	defaults := parseYAML(t, `
+replicaCount: 1
+`)
+	user := parseYAML(t, `
+replicaCount: "replicas-${REPLICAS}"
+`)
+	findings := detectTypeMismatches(user, defaults, nil, nil, "", nil)
+	if len(findings) != 1 || findings[0].Rule != model.RuleTypeMismatch {
+		t.Errorf("expected a plain type mismatch, got %v", findings)
+	}
+}
+
+func TestDetectTypeMismatches_QuantityPaths(t *testing.T) {
+	defaults := parseYAML(t, `
+persistence:
+  size: 10Gi
+jvm:
+  heap: 512Mi
+`)
+	user := parseYAML(t, `
+persistence:
+  size: 10
+jvm:
+  heap: 1024
+`)
+
+	// Without the flag, a number for a quantity string outside resources is a mismatch
+	if findings := detectTypeMismatches(user, defaults, nil, nil, "", nil); len(findings) != 2 {
+		t.Fatalf("expected 2 findings without quantity paths, got %d: %v", len(findings), findings)
+	}
+
+	findings := detectTypeMismatches(user, defaults, nil, []string{"persistence.size"}, "", nil)
+	if len(findings) != 1 || findings[0].KeyPath != "jvm.heap" {
+		t.Errorf("expected only jvm.heap to be reported, got %v", findings)
+	}
+
+	if findings := detectTypeMismatches(user, defaults, nil, []string{"persistence.size", "jvm.*"}, "", nil); len(findings) != 0 {
+		t.Errorf("expected no findings with both paths marked, got %v", findings)
+	}
+}
+
+func TestExtractQuantityPaths(t *testing.T) {
+	schema := []byte(`{
+		"properties": {
+			"persistence": {
+				"properties": {
+					"size": {"type": "string", "pattern": "^(\\+|-)?(([0-9]+(\\.[0-9]*)?)|(\\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\\+|-)?(([0-9]+(\\.[0-9]*)?)|(\\.[0-9]+))))?$"},
+					"storageClass": {"type": "string", "pattern": "^[a-z0-9-]+$"}
+				}
+			},
+			"volumes": {
+				"type": "array",
+				"items": {"type": "object", "properties": {"size": {"type": "string", "pattern": "^[0-9]+(Mi|Gi)$"}}}
+			}
+		}
+	}`)
+
+	got := extractQuantityPaths(schema)
+	want := []string{"persistence.size", "volumes[*].size"}
+	if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+		t.Fatalf("extractQuantityPaths = %v, want %v", got, want)
+	}
+
+	// A schema-declared quantity accepts a number in place of the string
+	schemaTypes := extractSchemaTypes(schema)
+	user := parseYAML(t, `
+persistence:
+  size: 10
+volumes:
+  - size: 20
+`)
+	if findings := detectTypeMismatches(user, &yaml.Node{Kind: yaml.MappingNode}, nil, got, "", schemaTypes); len(findings) != 0 {
+		t.Errorf("expected no findings for numeric quantities, got %v", findings)
+	}
+}
+
+func TestDetectTypeMismatches_InvalidQuantity(t *testing.T) {
+	defaults := parseYAML(t, `
+resources:
+  limits:
+    cpu: 100m
+    memory: 128Mi
+`)
+	user := parseYAML(t, `
+resources:
+  limits:
+    cpu: 500m
+    memory: "2GG"
+`)
+	findings := detectTypeMismatches(user, defaults, nil, nil, "", nil)
+	if len(findings) != 1 {
+		t.Fatalf("expected 1 finding, got %d: %v", len(findings), findings)
+	}
+	if findings[0].Rule != model.RuleInvalidQuantity || findings[0].KeyPath != "resources.limits.memory" {
+		t.Errorf("expected %s at resources.limits.memory, got %v", model.RuleInvalidQuantity, findings[0])
+	}
+
+	user = parseYAML(t, `
+resources:
+  limits:
+    cpu: 1
+    memory: "2Gi"
+`)
+	if findings := detectTypeMismatches(user, defaults, nil, nil, "", nil); len(findings) != 0 {
+		t.Errorf("expected no findings for valid quantities, got %v", findings)
+	}
+}
+
+func TestCheckIntegerFormats(t *testing.T) {
+	schema := []byte(`{
+		"properties": {
+			"replicas": {"type": "integer", "format": "int32"},
+			"maxBytes": {"type": "integer", "format": "int64"},
+			"ports": {"type": "array", "items": {"type": "integer", "format": "int32"}}
+		}
+	}`)
+	formats := extractIntegerFormats(schema)
+	if formats["replicas"] != "int32" || formats["maxBytes"] != "int64" || formats["ports[*]"] != "int32" {
+		t.Fatalf("unexpected formats: %v", formats)
+	}
+
+	user := parseYAML(t, `
+replicas: 5000000000
+maxBytes: 5000000000
+ports:
+  - 8080
+  - 2147483648
+`)
+	findings := checkIntegerFormats(user, formats, nil, "")
+	if len(findings) != 2 {
+		t.Fatalf("expected 2 findings, got %d: %v", len(findings), findings)
+	}
+	want := `Value 5000000000 at "replicas" overflows int32: must be between -2147483648 and 2147483647`
+	if findings[0].Rule != model.RuleIntOverflow || findings[0].Message != want || findings[0].Line != 2 {
+		t.Errorf("expected %q at line 2, got %+v", want, findings[0])
+	}
+	if findings[1].KeyPath != "ports[1]" {
+		t.Errorf("expected overflow at ports[1], got %v", findings[1])
+	}
+
+	// A value beyond int64 overflows an int64 field too
+	user = parseYAML(t, `maxBytes: 9223372036854775808`)
+	if findings := checkIntegerFormats(user, formats, nil, ""); len(findings) != 1 {
+		t.Errorf("expected 1 finding for an int64 overflow, got %v", findings)
+	}
+}
+
+func TestDetectTypeMismatches_QuotedNumber(t *testing.T) {
+	defaults := parseYAML(t, `
+replicaCount: 1
+port: 80
+`)
+	user := parseYAML(t, `
+replicaCount: "3"
+port: http
+`)
+	findings := detectTypeMismatches(user, defaults, nil, nil, "", nil)
+	if len(findings) != 2 {
+		t.Fatalf("expected 2 findings, got %d: %v", len(findings), findings)
+	}
+	want := `Type mismatch at "replicaCount": expected int, got quoted string ("3"); remove the quotes to pass it as int`
+	if findings[0].Message != want {
+		t.Errorf("expected message %q, got %q", want, findings[0].Message)
+	}
+	want = `Type mismatch at "port": expected int, got string ("http")`
+	if findings[1].Message != want {
+		t.Errorf("expected message %q, got %q", want, findings[1].Message)
+	}
+}
+
+func TestDetectTypeMismatches_ListMapShape(t *testing.T) {
+	defaults := parseYAML(t, `
+extraEnv:
+  - name: LOG_LEVEL
+    value: info
+podLabels:
+  app: web
+initContainers: []
+ports:
+  - name: http
+    containerPort: 80
+`)
+	user := parseYAML(t, `
+extraEnv:
+  LOG_LEVEL: debug
+podLabels:
+  - app=web
+initContainers:
+  name: wait
+ports:
+  - http:80
+`)
+	findings := detectTypeMismatches(user, defaults, nil, nil, "", nil)
+	want := map[string]string{
+		"extraEnv":       `Type mismatch at "extraEnv": expected list, got map`,
+		"podLabels":      `Type mismatch at "podLabels": expected map, got list`,
+		"initContainers": `Type mismatch at "initContainers": expected list, got map`,
+		"ports[0]":       `Type mismatch at "ports[0]": expected map, got string ("http:80")`,
+	}
+	if len(findings) != len(want) {
+		t.Fatalf("expected %d findings, got %d: %v", len(want), len(findings), findings)
+	}
+	for _, f := range findings {
+		if f.Message != want[f.KeyPath] {
+			t.Errorf("unexpected message at %s: %q", f.KeyPath, f.Message)
+		}
+	}
+}
+
+func TestDetectTypeMismatches_IntForBool(t *testing.T) {
+	defaults := parseYAML(t, `
+enabled: false
+replicaCount: 1
+`)
+	user := parseYAML(t, `
+enabled: 1
+replicaCount: true
+`)
+	findings := detectTypeMismatches(user, defaults, nil, nil, "", nil)
+	if len(findings) != 2 {
+		t.Fatalf("expected 2 findings, got %d: %v", len(findings), findings)
+	}
+	if want := `Type mismatch at "enabled": expected bool, got int ("1"); use true/false, not 1/0`; findings[0].Message != want {
+		t.Errorf("expected %q, got %q", want, findings[0].Message)
+	}
+	if strings.Contains(findings[1].Message, "true/false") {
+		t.Errorf("expected no bool hint where an int is expected, got %q", findings[1].Message)
+	}
+}
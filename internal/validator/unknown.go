@@ -2,6 +2,9 @@ package validator
 
 import (
 	"fmt"
+	"math"
+	"regexp"
+	"sort"
 	"strings"
 
 	"github.com/agnivade/levenshtein"
@@ -10,7 +13,9 @@ import (
 )
 
 // collectAllPaths walks a yaml mapping tree and collects all dot-separated
-// key paths. Used to suggest relocated keys.
+// key paths, following aliases into the mappings they point to (Helm
+// refuses to load defaults whose aliases contain themselves, so the walk
+// ends). Used to suggest relocated keys.
 func collectAllPaths(node *yaml.Node, prefix string) map[string]string {
 	paths := make(map[string]string)
 	if node == nil || node.Kind != yaml.MappingNode {
@@ -20,8 +25,8 @@ func collectAllPaths(node *yaml.Node, prefix string) map[string]string {
 		key := node.Content[i].Value
 		fullPath := joinPath(prefix, key)
 		paths[fullPath] = key
-		if node.Content[i+1].Kind == yaml.MappingNode {
-			for p, leaf := range collectAllPaths(node.Content[i+1], fullPath) {
+		if val := resolveAlias(node.Content[i+1]); val.Kind == yaml.MappingNode {
+			for p, leaf := range collectAllPaths(val, fullPath) {
 				paths[p] = leaf
 			}
 		}
@@ -29,44 +34,117 @@ func collectAllPaths(node *yaml.Node, prefix string) map[string]string {
 	return paths
 }
 
-// findDeepSuggestion searches the entire defaults tree for a key path that
-// matches the unknown key's leaf name. It uses three strategies in priority order:
+// addSchemaPaths adds the property paths a schema declares (from
+// extractSchemaKeys) to paths from collectAllPaths, so that keys only the
+// schema defines can be suggested too.
+func addSchemaPaths(paths map[string]string, schemaKeys map[string]bool) map[string]string {
+	for path := range schemaKeys {
+		if _, ok := paths[path]; !ok {
+			paths[path] = path[strings.LastIndex(path, ".")+1:]
+		}
+	}
+	return paths
+}
+
+// maxFuzzySearchPaths is the size of defaults tree above which deep
+// suggestions only look for relocated keys (an exact leaf match elsewhere),
+// skipping the scan of every path for misspellings.
+const maxFuzzySearchPaths = 20000
+
+// pathIndex holds every path in a defaults tree, from collectAllPaths, and
+// the same paths grouped by lowercased leaf key for relocated-key lookups.
+type pathIndex struct {
+	paths  map[string]string
+	byLeaf map[string][]string
+}
+
+// newPathIndex indexes paths (full path -> leaf key) by leaf.
+func newPathIndex(paths map[string]string) *pathIndex {
+	idx := &pathIndex{paths: paths, byLeaf: make(map[string][]string)}
+	for path, leaf := range paths {
+		lower := strings.ToLower(leaf)
+		idx.byLeaf[lower] = append(idx.byLeaf[lower], path)
+	}
+	return idx
+}
+
+// maxSuggestions caps how many ranked candidates a finding lists.
+const maxSuggestions = 3
+
+// rankedCandidate is a suggestion with its sort key: lower strategy ranks
+// first, then lower score, then path.
+type rankedCandidate struct {
+	path     string
+	strategy int
+	score    int
+}
+
+// rankCandidates sorts candidates and returns up to maxSuggestions paths.
+func rankCandidates(candidates []rankedCandidate) []string {
+	sort.Slice(candidates, func(i, j int) bool {
+		a, b := candidates[i], candidates[j]
+		if a.strategy != b.strategy {
+			return a.strategy < b.strategy
+		}
+		if a.score != b.score {
+			return a.score < b.score
+		}
+		return a.path < b.path
+	})
+	var paths []string
+	for _, c := range candidates {
+		if len(paths) == maxSuggestions {
+			break
+		}
+		paths = append(paths, c.path)
+	}
+	return paths
+}
+
+// findDeepSuggestions searches the entire defaults tree for key paths that
+// match the unknown key's leaf name. It uses three strategies in priority
+// order:
 //  1. Exact leaf name at a different path (relocated key)
 //  2. Close Levenshtein match (distance < 4, same as sibling matching)
 //  3. Substring containment where the added/removed portion is short
 //     (e.g., orgCreationDisabled → userOrgCreationDisabled)
 //
-// Returns the full path of the best match, or empty string if none found.
-func findDeepSuggestion(unknownPath string, allPaths map[string]string) string {
+// It returns up to maxSuggestions paths, ranked by strategy and then by
+// closeness within it (shorter path for exact matches, distance, or length
+// difference).
+// limited reports that the misspelling scan was skipped because the tree
+// has more than maxFuzzySearchPaths paths.
+func findDeepSuggestions(unknownPath string, allPaths *pathIndex) (suggestions []string, limited bool) {
 	parts := strings.Split(unknownPath, ".")
 	leaf := strings.ToLower(parts[len(parts)-1])
 
-	// Track best candidates per strategy (higher priority wins)
-	var exactMatch string
-	levenBest := ""
-	levenBestDist := 4 // threshold: must be < 4
-	containBest := ""
-	containBestDiff := 1000
-
-	for path, pathLeaf := range allPaths {
-		if path == unknownPath {
-			continue
+	// Strategy 1: exact leaf match at different location
+	var candidates []rankedCandidate
+	for _, path := range allPaths.byLeaf[leaf] {
+		if path != unknownPath {
+			candidates = append(candidates, rankedCandidate{path: path, strategy: 0, score: len(path)})
 		}
-		lowerPathLeaf := strings.ToLower(pathLeaf)
+	}
 
-		// Strategy 1: exact leaf match at different location
-		if leaf == lowerPathLeaf {
-			if exactMatch == "" || len(path) < len(exactMatch) {
-				exactMatch = path
-			}
+	// Exact leaf matches outrank the rest, so the scan below can only fill
+	// in when there are fewer than maxSuggestions of them
+	if len(candidates) >= maxSuggestions {
+		return rankCandidates(candidates), false
+	}
+	if len(allPaths.paths) > maxFuzzySearchPaths {
+		return rankCandidates(candidates), true
+	}
+
+	for path, pathLeaf := range allPaths.paths {
+		lowerPathLeaf := strings.ToLower(pathLeaf)
+		if path == unknownPath || leaf == lowerPathLeaf {
 			continue
 		}
 
 		// Strategy 2: close Levenshtein match
-		dist := levenshtein.ComputeDistance(leaf, lowerPathLeaf)
-		if dist < levenBestDist {
-			levenBestDist = dist
-			levenBest = path
+		if dist := levenshtein.ComputeDistance(leaf, lowerPathLeaf); dist < 4 {
+			candidates = append(candidates, rankedCandidate{path: path, strategy: 1, score: dist})
+			continue
 		}
 
 		// Strategy 3: substring containment with short diff
@@ -80,30 +158,24 @@ func findDeepSuggestion(unknownPath string, allPaths map[string]string) string {
 				diff = -diff
 			}
 			// Only suggest if added/removed portion is at most half the shorter name
-			if diff <= shorter/2 && diff < containBestDiff {
-				containBestDiff = diff
-				containBest = path
+			if diff <= shorter/2 {
+				candidates = append(candidates, rankedCandidate{path: path, strategy: 2, score: diff})
 			}
 		}
 	}
 
-	// Return best match by priority
-	if exactMatch != "" {
-		return exactMatch
-	}
-	if levenBest != "" {
-		return levenBest
-	}
-	if containBest != "" {
-		return containBest
-	}
-	return ""
+	return rankCandidates(candidates), false
 }
 
 // detectUnknownKeys walks the user values tree and reports keys not found
-// in the chart defaults tree. allPaths is a pre-computed map of every
-// dot-separated path in the root defaults tree (used for deep suggestions).
-func detectUnknownKeys(userNode, defaultsNode *yaml.Node, schemaKeys map[string]bool, subchartDefaults map[string]*yaml.Node, ignoreKeys []string, path string, allPaths map[string]string) []model.Finding {
+// in the chart defaults tree. Keys matching a schema patternProperties regex
+// of their parent object (schemaPatterns) are known, along with everything
+// beneath them. allPaths is a pre-computed index of every dot-separated path
+// in the root defaults tree defaultsRoot (used for deep suggestions, which
+// also record the line defining the suggested key when defaultsRoot is set).
+// inSubchart marks a walk below a subchart's key, whose keys are never
+// taken for misplaced top-level keys of the root chart. With suggest false, findings carry no suggestions and no fuzzy search runs.
+func detectUnknownKeys(userNode, defaultsNode *yaml.Node, schemaKeys map[string]bool, schemaPatterns map[string][]*regexp.Regexp, subchartDefaults map[string]*yaml.Node, ignoreKeys []string, path string, allPaths *pathIndex, defaultsRoot *yaml.Node, inSubchart, suggest bool) []model.Finding {
 	var findings []model.Finding
 
 	if userNode == nil || defaultsNode == nil {
@@ -130,10 +202,14 @@ func detectUnknownKeys(userNode, defaultsNode *yaml.Node, schemaKeys map[string]
 			continue
 		}
 
-		// Check if key is a subchart name — validate against subchart defaults
+		// Check if key is a subchart name — validate against subchart defaults,
+		// plus what the parent sets for it (such as a redis.enabled condition)
 		if subDefaults, ok := subchartDefaults[key]; ok {
 			if valNode.Kind == yaml.MappingNode {
-				findings = append(findings, detectUnknownKeys(valNode, subDefaults, nil, nil, ignoreKeys, fullPath, allPaths)...)
+				if parentVal := getValueForKey(defaultsNode, key); parentVal != nil {
+					subDefaults = fillDefaults(subDefaults, resolveAlias(parentVal))
+				}
+				findings = append(findings, detectUnknownKeys(valNode, subDefaults, schemaKeys, schemaPatterns, nil, ignoreKeys, fullPath, allPaths, defaultsRoot, true, suggest)...)
 			}
 			continue
 		}
@@ -144,25 +220,59 @@ func detectUnknownKeys(userNode, defaultsNode *yaml.Node, schemaKeys map[string]
 			if schemaKeys != nil && schemaKeys[fullPath] {
 				// Key is valid per schema, continue checking children
 				if valNode.Kind == yaml.MappingNode {
-					findings = append(findings, detectUnknownKeys(valNode, &yaml.Node{Kind: yaml.MappingNode}, schemaKeys, subchartDefaults, ignoreKeys, fullPath, allPaths)...)
+					findings = append(findings, detectUnknownKeys(valNode, &yaml.Node{Kind: yaml.MappingNode}, schemaKeys, schemaPatterns, subchartDefaults, ignoreKeys, fullPath, allPaths, defaultsRoot, inSubchart, suggest)...)
 				}
 				continue
 			}
+			if matchesSchemaPattern(key, schemaPatterns[schemaPath(path)]) {
+				continue
+			}
 
 			f := model.Finding{
 				Severity: model.SeverityError,
+				Rule:     model.RuleUnknownKey,
 				Line:     keyNode.Line,
+				Column:   keyNode.Column,
 				KeyPath:  fullPath,
 				Message:  fmt.Sprintf("Unknown key %q", fullPath),
 			}
 
-			// Find closest match: first try siblings, then deep search
-			if suggestion := findClosestKey(key, defaultKeys); suggestion != "" {
-				f.Suggestion = joinPath(path, suggestion)
+			if !suggest {
+				findings = append(findings, f)
+				continue
+			}
+
+			// Find closest match: first a sibling differing only in case, then a
+			// subchart that defines this exact path, then a top-level key
+			// indented too far (unless a sibling is one edit away), then
+			// siblings, then deep search
+			var suggestions []string
+			closest := findClosestKeys(key, defaultKeys)
+			if suggestion := findCaseInsensitiveKey(key, defaultKeys); suggestion != "" {
+				f.Message = fmt.Sprintf("Unknown key %q: key is case-sensitive", fullPath)
+				suggestions = []string{joinPath(path, suggestion)}
+			} else if suggestion := findSubchartSuggestion(fullPath, subchartDefaults); suggestion != "" {
+				suggestions = []string{suggestion}
+			} else if path != "" && !inSubchart && !hasNearSibling(key, closest) && getValueForKey(defaultsRoot, key) != nil {
+				f.Message = fmt.Sprintf("Unknown key %q: %s appears nested under %s; check its indentation", fullPath, key, path)
+				suggestions = []string{key}
+				f.SuggestionLine = findLineForPath(defaultsRoot, key)
+			} else if len(closest) > 0 {
+				for _, c := range closest {
+					suggestions = append(suggestions, joinPath(path, c))
+				}
 			} else if allPaths != nil {
-				if suggestion := findDeepSuggestion(fullPath, allPaths); suggestion != "" {
-					f.Suggestion = suggestion
+				var limited bool
+				suggestions, limited = findDeepSuggestions(fullPath, allPaths)
+				if len(suggestions) > 0 && defaultsRoot != nil {
+					f.SuggestionLine = findLineForPath(defaultsRoot, suggestions[0])
 				}
+				f.LimitedSearch = limited && len(suggestions) == 0
+			}
+			if len(suggestions) > 0 {
+				f.Suggestion = suggestions[0]
+				f.Suggestions = suggestions
+				f.Confidence = suggestionConfidence(key, suggestions[0])
 			}
 
 			findings = append(findings, f)
@@ -176,13 +286,105 @@ func detectUnknownKeys(userNode, defaultsNode *yaml.Node, schemaKeys map[string]
 			if len(defaultVal.Content) == 0 {
 				continue
 			}
-			findings = append(findings, detectUnknownKeys(valNode, defaultVal, schemaKeys, subchartDefaults, ignoreKeys, fullPath, allPaths)...)
+			findings = append(findings, detectUnknownKeys(valNode, defaultVal, schemaKeys, schemaPatterns, subchartDefaults, ignoreKeys, fullPath, allPaths, defaultsRoot, inSubchart, suggest)...)
 		}
 	}
 
 	return findings
 }
 
+// suggestionConfidence scores how likely suggestion is what the user meant
+// by key, from 0 to 1: the similarity of key to the suggestion's leaf, as
+// one minus their case-insensitive Levenshtein distance over the longer
+// length. A relocated or case-only match scores 1; distant matches score low.
+func suggestionConfidence(key, suggestion string) float64 {
+	leaf := strings.ToLower(suggestion[strings.LastIndex(suggestion, ".")+1:])
+	key = strings.ToLower(key)
+
+	longer := len(key)
+	if len(leaf) > longer {
+		longer = len(leaf)
+	}
+	if longer == 0 {
+		return 0
+	}
+	similarity := 1 - float64(levenshtein.ComputeDistance(key, leaf))/float64(longer)
+	return math.Round(similarity*100) / 100
+}
+
+// findSubchartSuggestion returns the subchart-prefixed path when unknownPath
+// exists in one of the subchart defaults trees (e.g., "image.tag" set at top
+// level when only the "redis" subchart defines it suggests "redis.image.tag").
+// Subcharts are checked in name order so the result is deterministic.
+func findSubchartSuggestion(unknownPath string, subchartDefaults map[string]*yaml.Node) string {
+	names := make([]string, 0, len(subchartDefaults))
+	for name := range subchartDefaults {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if findNodeForPath(subchartDefaults[name], unknownPath) != nil {
+			return joinPath(name, unknownPath)
+		}
+	}
+	return ""
+}
+
+// matchesSchemaPattern reports whether key matches any of patterns.
+func matchesSchemaPattern(key string, patterns []*regexp.Regexp) bool {
+	for _, re := range patterns {
+		if re.MatchString(key) {
+			return true
+		}
+	}
+	return false
+}
+
+// dropPatternKeys returns findings without the unknown keys that a schema
+// patternProperties regex of their parent object accepts, along with the
+// keys beneath them. The unknown keys of sequence elements are found by the
+// type check, which has no schema patterns, so they are matched to the keys
+// of userNode by position.
+func dropPatternKeys(findings []model.Finding, userNode *yaml.Node, schemaPatterns map[string][]*regexp.Regexp) []model.Finding {
+	if len(schemaPatterns) == 0 {
+		return findings
+	}
+	accepted := make(map[[2]int]bool)
+	collectPatternKeys(userNode, schemaPatterns, "", false, accepted)
+
+	var kept []model.Finding
+	for _, f := range findings {
+		if f.Rule != model.RuleUnknownKey || !accepted[[2]int{f.Line, f.Column}] {
+			kept = append(kept, f)
+		}
+	}
+	return kept
+}
+
+// collectPatternKeys records the positions of the keys under node that a
+// schema pattern accepts, or that sit beneath such a key.
+func collectPatternKeys(node *yaml.Node, schemaPatterns map[string][]*regexp.Regexp, path string, accepted bool, positions map[[2]int]bool) {
+	if node == nil {
+		return
+	}
+	switch node.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			keyNode := node.Content[i]
+			keyAccepted := accepted || matchesSchemaPattern(keyNode.Value, schemaPatterns[schemaPath(path)])
+			if keyAccepted {
+				positions[[2]int{keyNode.Line, keyNode.Column}] = true
+			}
+			collectPatternKeys(node.Content[i+1], schemaPatterns, joinPath(path, keyNode.Value), keyAccepted, positions)
+		}
+	case yaml.SequenceNode:
+		for idx, elem := range node.Content {
+			collectPatternKeys(elem, schemaPatterns, fmt.Sprintf("%s[%d]", path, idx), accepted, positions)
+		}
+	}
+}
+
 // mappingKeys extracts all keys from a yaml mapping node.
 func mappingKeys(node *yaml.Node) map[string]bool {
 	keys := make(map[string]bool)
@@ -202,31 +404,54 @@ func getValueForKey(node *yaml.Node, key string) *yaml.Node {
 	}
 	for i := 0; i+1 < len(node.Content); i += 2 {
 		if node.Content[i].Value == key {
-			val := node.Content[i+1]
-			// Resolve aliases
-			if val.Kind == yaml.AliasNode && val.Alias != nil {
-				return val.Alias
-			}
-			return val
+			return resolveAlias(node.Content[i+1])
 		}
 	}
 	return nil
 }
 
+// findCaseInsensitiveKey returns the candidate equal to key ignoring case,
+// or "" if there is none. Ties are broken alphabetically.
+func findCaseInsensitiveKey(key string, candidates map[string]bool) string {
+	best := ""
+	for candidate := range candidates {
+		if strings.EqualFold(key, candidate) && (best == "" || candidate < best) {
+			best = candidate
+		}
+	}
+	return best
+}
+
 // findClosestKey returns the closest matching key using Levenshtein distance.
 // Returns empty string if no close match found (threshold: distance <= 3).
 func findClosestKey(key string, candidates map[string]bool) string {
-	best := ""
-	bestDist := 4 // threshold
+	if closest := findClosestKeys(key, candidates); len(closest) > 0 {
+		return closest[0]
+	}
+	return ""
+}
 
+// findClosestKeys returns up to maxSuggestions keys within the distance
+// threshold, closest first.
+func findClosestKeys(key string, candidates map[string]bool) []string {
+	var ranked []rankedCandidate
 	for candidate := range candidates {
-		dist := levenshtein.ComputeDistance(strings.ToLower(key), strings.ToLower(candidate))
-		if dist < bestDist {
-			bestDist = dist
-			best = candidate
+		if dist := levenshtein.ComputeDistance(strings.ToLower(key), strings.ToLower(candidate)); dist < 4 {
+			ranked = append(ranked, rankedCandidate{path: candidate, score: dist})
 		}
 	}
-	return best
+	return rankCandidates(ranked)
+}
+
+// hasNearSibling reports whether one of the sibling candidates ranked by
+// findClosestKeys is a single edit away from key.
+func hasNearSibling(key string, closest []string) bool {
+	for _, c := range closest {
+		if levenshtein.ComputeDistance(strings.ToLower(key), strings.ToLower(c)) <= 1 {
+			return true
+		}
+	}
+	return false
 }
 
 func joinPath(parent, child string) string {
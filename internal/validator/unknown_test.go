@@ -1,6 +1,8 @@
 package validator
 
 import (
+	"fmt"
+	"strings"
 	"testing"
 
 	"gopkg.in/yaml.v3"
@@ -30,7 +32,7 @@ image:
   repository: myapp
 replicaCount: 2
 `)
-	findings := detectUnknownKeys(user, defaults, nil, nil, nil, "", nil)
+	findings := detectUnknownKeys(user, defaults, nil, nil, nil, nil, "", nil, nil, false, true)
 	if len(findings) != 0 {
 		t.Errorf("expected no findings, got %d: %v", len(findings), findings)
 	}
@@ -50,7 +52,7 @@ image:
 replicaCount: 2
 unknownKey: true
 `)
-	findings := detectUnknownKeys(user, defaults, nil, nil, nil, "", nil)
+	findings := detectUnknownKeys(user, defaults, nil, nil, nil, nil, "", nil, nil, false, true)
 	if len(findings) != 2 {
 		t.Fatalf("expected 2 findings, got %d: %v", len(findings), findings)
 	}
@@ -80,7 +82,7 @@ image:
   unknownField: value
 customKey: true
 `)
-	findings := detectUnknownKeys(user, defaults, nil, nil, []string{"image.*", "customKey"}, "", nil)
+	findings := detectUnknownKeys(user, defaults, nil, nil, nil, []string{"image.*", "customKey"}, "", nil, nil, false, true)
 	if len(findings) != 0 {
 		t.Errorf("expected no findings with ignore patterns, got %d: %v", len(findings), findings)
 	}
@@ -103,7 +105,7 @@ redis:
   replicas: 3
   unknownSubKey: false
 `)
-	findings := detectUnknownKeys(user, defaults, nil, subDefaults, nil, "", nil)
+	findings := detectUnknownKeys(user, defaults, nil, nil, subDefaults, nil, "", nil, nil, false, true)
 	if len(findings) != 1 {
 		t.Fatalf("expected 1 finding for subchart unknown key, got %d: %v", len(findings), findings)
 	}
@@ -133,7 +135,7 @@ annotations:
 image:
   repository: myapp
 `)
-	findings := detectUnknownKeys(user, defaults, nil, nil, nil, "", nil)
+	findings := detectUnknownKeys(user, defaults, nil, nil, nil, nil, "", nil, nil, false, true)
 	if len(findings) != 0 {
 		t.Errorf("expected no findings for empty map defaults, got %d:", len(findings))
 		for _, f := range findings {
@@ -153,7 +155,7 @@ podSecurityContext:
 replicaCount: 2
 completelyUnknown: true
 `)
-	findings := detectUnknownKeys(user, defaults, nil, nil, nil, "", nil)
+	findings := detectUnknownKeys(user, defaults, nil, nil, nil, nil, "", nil, nil, false, true)
 	if len(findings) != 1 {
 		t.Fatalf("expected 1 finding for sibling unknown key, got %d: %v", len(findings), findings)
 	}
@@ -172,7 +174,7 @@ config:
       allowedOrigins: "*"
   userOrgCreationDisabled: true
 `)
-	allPaths := collectAllPaths(defaults, "")
+	allPaths := newPathIndex(collectAllPaths(defaults, ""))
 	user := parseYAML(t, `
 config:
   cors:
@@ -180,7 +182,7 @@ config:
   jwtSecret: "secret123"
   orgCreationDisabled: true
 `)
-	findings := detectUnknownKeys(user, defaults, nil, nil, nil, "", allPaths)
+	findings := detectUnknownKeys(user, defaults, nil, nil, nil, nil, "", allPaths, defaults, false, true)
 	if len(findings) != 3 {
 		t.Fatalf("expected 3 findings, got %d: %v", len(findings), findings)
 	}
@@ -248,3 +250,446 @@ func TestMatchGlob(t *testing.T) {
 		}
 	}
 }
+
+func TestDetectUnknownKeys_SuggestsSubchartPrefix(t *testing.T) {
+	defaults := parseYAML(t, `
+replicaCount: 1
+image:
+  repository: nginx
+`)
+	subDefaults := map[string]*yaml.Node{
+		"redis": parseYAML(t, `
+image:
+  repository: redis
+  tag: latest
+auth:
+  enabled: true
+`),
+	}
+	user := parseYAML(t, `
+image:
+  tag: "7.2"
+auth:
+  enabled: false
+`)
+	findings := detectUnknownKeys(user, defaults, nil, nil, subDefaults, nil, "", nil, nil, false, true)
+	if len(findings) != 2 {
+		t.Fatalf("expected 2 findings, got %d: %v", len(findings), findings)
+	}
+	if findings[0].KeyPath != "image.tag" || findings[0].Suggestion != "redis.image.tag" {
+		t.Errorf("expected image.tag to suggest 'redis.image.tag', got %q -> %q", findings[0].KeyPath, findings[0].Suggestion)
+	}
+	if findings[1].KeyPath != "auth" || findings[1].Suggestion != "redis.auth" {
+		t.Errorf("expected auth to suggest 'redis.auth', got %q -> %q", findings[1].KeyPath, findings[1].Suggestion)
+	}
+}
+
+func TestDetectUnknownKeys_CaseOnlyDifference(t *testing.T) {
+	defaults := parseYAML(t, `
+imagePullSecrets: []
+image:
+  pullPolicy: IfNotPresent
+`)
+	user := parseYAML(t, `
+imagepullsecrets:
+  - name: regcred
+image:
+  pullpolicy: Always
+`)
+	findings := detectUnknownKeys(user, defaults, nil, nil, nil, nil, "", nil, nil, false, true)
+	if len(findings) != 2 {
+		t.Fatalf("expected 2 findings, got %d: %v", len(findings), findings)
+	}
+
+	if findings[0].Message != `Unknown key "imagepullsecrets": key is case-sensitive` {
+		t.Errorf("expected case-sensitive message, got %q", findings[0].Message)
+	}
+	if findings[0].Suggestion != "imagePullSecrets" {
+		t.Errorf("expected suggestion 'imagePullSecrets', got %q", findings[0].Suggestion)
+	}
+	if findings[1].Suggestion != "image.pullPolicy" {
+		t.Errorf("expected suggestion 'image.pullPolicy', got %q", findings[1].Suggestion)
+	}
+}
+
+func TestDetectUnknownKeys_TypoKeepsGenericMessage(t *testing.T) {
+	defaults := parseYAML(t, `
+imagePullSecrets: []
+`)
+	user := parseYAML(t, `
+imagePullSecret: []
+`)
+	findings := detectUnknownKeys(user, defaults, nil, nil, nil, nil, "", nil, nil, false, true)
+	if len(findings) != 1 {
+		t.Fatalf("expected 1 finding, got %d: %v", len(findings), findings)
+	}
+	if findings[0].Message != `Unknown key "imagePullSecret"` {
+		t.Errorf("expected generic message, got %q", findings[0].Message)
+	}
+}
+
+func TestFindClosestKeys_OrderedByDistance(t *testing.T) {
+	candidates := map[string]bool{
+		"ports":   true, // distance 1 from "port"
+		"portals": true, // distance 3
+		"sport":   true, // distance 1
+		"post":    true, // distance 1
+		"service": true,
+	}
+	got := findClosestKeys("port", candidates)
+	want := []string{"ports", "post", "sport"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("findClosestKeys(\"port\") = %v, want %v", got, want)
+	}
+
+	// Equal distances fall back to alphabetical order
+	got = findClosestKeys("portal", map[string]bool{"portals": true, "portly": true, "port": true})
+	want = []string{"portals", "port", "portly"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("findClosestKeys(\"portal\") = %v, want %v", got, want)
+	}
+}
+
+func TestDetectUnknownKeys_DeepSuggestionLine(t *testing.T) {
+	defaults := parseYAML(t, `
+config:
+  basicAuth:
+    jwtSecret: ""
+  security:
+    cors:
+      allowedOrigins: "*"
+image:
+  repository: nginx
+`)
+	user := parseYAML(t, `
+config:
+  cors: {}
+image:
+  repositry: myapp
+`)
+	findings := detectUnknownKeys(user, defaults, nil, nil, nil, nil, "", newPathIndex(collectAllPaths(defaults, "")), defaults, false, true)
+	if len(findings) != 2 {
+		t.Fatalf("expected 2 findings, got %d: %v", len(findings), findings)
+	}
+	if findings[0].Suggestion != "config.security.cors" || findings[0].SuggestionLine != 6 {
+		t.Errorf("expected config.security.cors defined at line 6, got %q at line %d", findings[0].Suggestion, findings[0].SuggestionLine)
+	}
+	if want := `line 3:3: Unknown key "config.cors" (did you mean "config.security.cors" (defined at line 6)?)`; findings[0].String() != want {
+		t.Errorf("String() = %q, want %q", findings[0].String(), want)
+	}
+
+	// Sibling suggestions sit next to the unknown key and carry no line
+	if findings[1].Suggestion != "image.repository" || findings[1].SuggestionLine != 0 {
+		t.Errorf("expected sibling suggestion image.repository without a line, got %q at line %d", findings[1].Suggestion, findings[1].SuggestionLine)
+	}
+}
+
+func TestFindDeepSuggestions_RankedByStrategy(t *testing.T) {
+	allPaths := map[string]string{
+		"config.security.cors":   "cors",
+		"config.deep.nest.cors":  "cors",
+		"config.corsOrigin":      "corsOrigin",
+		"config.core":            "core",
+		"config.unrelatedOption": "unrelatedOption",
+	}
+	got, _ := findDeepSuggestions("cors", newPathIndex(allPaths))
+	want := []string{"config.security.cors", "config.deep.nest.cors", "config.core"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("findDeepSuggestions(\"cors\") = %v, want %v", got, want)
+	}
+}
+
+func TestDetectUnknownKeys_MultipleSuggestions(t *testing.T) {
+	defaults := parseYAML(t, `
+service:
+  port: 80
+  ports: []
+  host: ""
+`)
+	user := parseYAML(t, `
+service:
+  prot: 8080
+`)
+	findings := detectUnknownKeys(user, defaults, nil, nil, nil, nil, "", nil, nil, false, true)
+	if len(findings) != 1 {
+		t.Fatalf("expected 1 finding, got %d: %v", len(findings), findings)
+	}
+	f := findings[0]
+	if f.Suggestion != "service.port" {
+		t.Errorf("expected primary suggestion 'service.port', got %q", f.Suggestion)
+	}
+	want := []string{"service.port", "service.host", "service.ports"}
+	if strings.Join(f.Suggestions, ",") != strings.Join(want, ",") {
+		t.Errorf("expected ranked suggestions %v, got %v", want, f.Suggestions)
+	}
+}
+
+func TestDetectUnknownKeys_SuggestionConfidence(t *testing.T) {
+	defaults := parseYAML(t, `
+config:
+  security:
+    cors: {}
+  replicaCount: 1
+`)
+	user := parseYAML(t, `
+config:
+  cors: {}
+  replcaCont: 3
+`)
+	findings := detectUnknownKeys(user, defaults, nil, nil, nil, nil, "", newPathIndex(collectAllPaths(defaults, "")), defaults, false, true)
+	if len(findings) != 2 {
+		t.Fatalf("expected 2 findings, got %d: %v", len(findings), findings)
+	}
+
+	relocated, fuzzy := findings[0], findings[1]
+	if relocated.Suggestion != "config.security.cors" || fuzzy.Suggestion != "config.replicaCount" {
+		t.Fatalf("unexpected suggestions %q and %q", relocated.Suggestion, fuzzy.Suggestion)
+	}
+	if relocated.Confidence != 1 {
+		t.Errorf("expected confidence 1 for a relocated key, got %v", relocated.Confidence)
+	}
+	if fuzzy.Confidence <= 0 || fuzzy.Confidence >= relocated.Confidence {
+		t.Errorf("expected fuzzy confidence between 0 and %v, got %v", relocated.Confidence, fuzzy.Confidence)
+	}
+}
+
+func TestDetectUnknownKeys_PatternProperties(t *testing.T) {
+	schema := []byte(`{
+		"properties": {
+			"podAnnotations": {
+				"type": "object",
+				"patternProperties": {"^example\\.com/.*$": {"type": "string"}}
+			}
+		}
+	}`)
+	defaults := parseYAML(t, `
+podAnnotations:
+  prometheus.io/scrape: "true"
+`)
+	user := parseYAML(t, `
+podAnnotations:
+  example.com/owner: team-a
+  example.com/tier: backend
+  other.org/owner: team-b
+`)
+	findings := detectUnknownKeys(user, defaults, extractSchemaKeys(schema), extractSchemaPatterns(schema), nil, nil, "", nil, nil, false, true)
+	if len(findings) != 1 {
+		t.Fatalf("expected 1 finding, got %d: %v", len(findings), findings)
+	}
+	if findings[0].KeyPath != "podAnnotations.other.org/owner" {
+		t.Errorf("expected only the non-matching key to be unknown, got %q", findings[0].KeyPath)
+	}
+}
+
+func TestDetectUnknownKeys_NoSuggestions(t *testing.T) {
+	defaults := parseYAML(t, `
+image:
+  repository: nginx
+  tag: latest
+`)
+	user := parseYAML(t, `
+image:
+  regsitry: docker.io
+Image: {}
+`)
+	findings := detectUnknownKeys(user, defaults, nil, nil, nil, nil, "", newPathIndex(collectAllPaths(defaults, "")), defaults, false, false)
+	if len(findings) != 2 {
+		t.Fatalf("expected 2 findings, got %d: %v", len(findings), findings)
+	}
+	for _, f := range findings {
+		if f.Suggestion != "" || len(f.Suggestions) != 0 || f.Confidence != 0 {
+			t.Errorf("expected no suggestion for %s, got %+v", f.KeyPath, f)
+		}
+	}
+}
+
+// largeDefaults builds a defaults tree of width^2 keys two levels deep, and
+// a values file that misspells one key in each section.
+func largeDefaults(b *testing.B, width int) (defaults, user *yaml.Node) {
+	b.Helper()
+	var d, u strings.Builder
+	for i := 0; i < width; i++ {
+		fmt.Fprintf(&d, "section%d:\n", i)
+		for j := 0; j < width; j++ {
+			fmt.Fprintf(&d, "  setting%dValue: %d\n", j, j)
+		}
+		fmt.Fprintf(&u, "section%d:\n  settng%dValue: 1\n", i, i)
+	}
+	for _, pair := range []struct {
+		text string
+		node **yaml.Node
+	}{{d.String(), &defaults}, {u.String(), &user}} {
+		doc := &yaml.Node{}
+		if err := yaml.Unmarshal([]byte(pair.text), doc); err != nil {
+			b.Fatal(err)
+		}
+		*pair.node = doc.Content[0]
+	}
+	return defaults, user
+}
+
+func BenchmarkDetectUnknownKeys(b *testing.B) {
+	defaults, user := largeDefaults(b, 200)
+	allPaths := newPathIndex(collectAllPaths(defaults, ""))
+	for _, bm := range []struct {
+		name    string
+		suggest bool
+	}{{"Suggestions", true}, {"NoSuggestions", false}} {
+		b.Run(bm.name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				detectUnknownKeys(user, defaults, nil, nil, nil, nil, "", allPaths, defaults, false, bm.suggest)
+			}
+		})
+	}
+}
+
+func TestFindDeepSuggestions_LargeDefaultsOnlyRelocated(t *testing.T) {
+	paths := map[string]string{"ingress.annotations": "annotations"}
+	for i := 0; len(paths) <= maxFuzzySearchPaths; i++ {
+		paths[fmt.Sprintf("section%d.value", i)] = "value"
+	}
+	idx := newPathIndex(paths)
+
+	if got, limited := findDeepSuggestions("annotations", idx); len(got) != 1 || got[0] != "ingress.annotations" || !limited {
+		t.Errorf("expected the relocated key to be suggested, got %v", got)
+	}
+	if got, limited := findDeepSuggestions("annotatons", idx); len(got) != 0 || !limited {
+		t.Errorf("expected no misspelling search above %d paths, got %v (limited %t)", maxFuzzySearchPaths, got, limited)
+	}
+	if _, limited := findDeepSuggestions("annotatons", newPathIndex(map[string]string{"ingress.annotations": "annotations"})); limited {
+		t.Error("expected the misspelling search to run on small charts")
+	}
+
+	user := parseYAML(t, "annotatons: {}\n")
+	defaults := parseYAML(t, "ingress:\n  annotations: {}\n")
+	findings := detectUnknownKeys(user, defaults, nil, nil, nil, nil, "", idx, defaults, false, true)
+	if len(findings) != 1 || findings[0].Suggestion != "" || !findings[0].LimitedSearch || !strings.Contains(findings[0].DisplayMessage(), "suggestions limited on large charts") {
+		t.Errorf("expected the finding to note the limited search, got %v", findings)
+	}
+	if findings[0].Message != `Unknown key "annotatons"` {
+		t.Errorf("expected the note to stay out of the message, got %q", findings[0].Message)
+	}
+}
+
+func BenchmarkFindDeepSuggestions(b *testing.B) {
+	defaults, _ := largeDefaults(b, 100)
+	idx := newPathIndex(collectAllPaths(defaults, ""))
+	for _, bm := range []struct {
+		name, path string
+	}{
+		// Indexed lookup: setting7Value exists in every section
+		{"Relocated", "misplaced.setting7Value"},
+		// Full scan: nothing ends in this leaf
+		{"Misspelled", "misplaced.settingSevenValue"},
+	} {
+		b.Run(bm.name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				findDeepSuggestions(bm.path, idx)
+			}
+		})
+	}
+}
+
+func TestDetectUnknownKeys_Column(t *testing.T) {
+	defaults := parseYAML(t, `
+ingress:
+  tls:
+    secretName: ""
+`)
+	user := parseYAML(t, `
+ingress:
+  tls:
+    secretNam: web-tls
+`)
+	findings := detectUnknownKeys(user, defaults, nil, nil, nil, nil, "", nil, nil, false, true)
+	if len(findings) != 1 {
+		t.Fatalf("expected 1 finding, got %d: %v", len(findings), findings)
+	}
+	if findings[0].Line != 4 || findings[0].Column != 5 {
+		t.Errorf("expected the nested key at line 4, column 5, got %d:%d", findings[0].Line, findings[0].Column)
+	}
+	if !strings.HasPrefix(findings[0].String(), "line 4:5: ") {
+		t.Errorf("expected String() to show line:column, got %q", findings[0].String())
+	}
+}
+
+func TestDetectUnknownKeys_NestedTopLevelKey(t *testing.T) {
+	defaults := parseYAML(t, `
+image:
+  repository: nginx
+  tag: latest
+service:
+  type: ClusterIP
+  port: 80
+`)
+	// service is indented under image by mistake
+	user := parseYAML(t, `
+image:
+  tag: "1.25"
+  service:
+    port: 8080
+`)
+	findings := detectUnknownKeys(user, defaults, nil, nil, nil, nil, "", newPathIndex(collectAllPaths(defaults, "")), defaults, false, true)
+	if len(findings) != 1 {
+		t.Fatalf("expected 1 finding, got %d: %v", len(findings), findings)
+	}
+	f := findings[0]
+	if want := `Unknown key "image.service": service appears nested under image; check its indentation`; f.Message != want {
+		t.Errorf("expected message %q, got %q", want, f.Message)
+	}
+	if f.Suggestion != "service" || f.SuggestionLine != 5 {
+		t.Errorf("expected top-level service defined at line 5, got %q at line %d", f.Suggestion, f.SuggestionLine)
+	}
+
+	// A key of the same name that is not top-level is an ordinary relocation
+	user = parseYAML(t, `
+service:
+  repository: myapp
+`)
+	findings = detectUnknownKeys(user, defaults, nil, nil, nil, nil, "", newPathIndex(collectAllPaths(defaults, "")), defaults, false, true)
+	if len(findings) != 1 || strings.Contains(findings[0].Message, "nested") {
+		t.Errorf("expected a plain unknown-key finding, got %v", findings)
+	}
+}
+
+func TestDetectUnknownKeys_NestedTopLevelKeyPrefersSibling(t *testing.T) {
+	defaults := parseYAML(t, `
+labels: {}
+service:
+  label: ""
+`)
+	user := parseYAML(t, `
+service:
+  labels: web
+`)
+	findings := detectUnknownKeys(user, defaults, nil, nil, nil, nil, "", newPathIndex(collectAllPaths(defaults, "")), defaults, false, true)
+	if len(findings) != 1 {
+		t.Fatalf("expected 1 finding, got %d: %v", len(findings), findings)
+	}
+	if f := findings[0]; strings.Contains(f.Message, "nested") || f.Suggestion != "service.label" {
+		t.Errorf("expected the sibling one edit away to be suggested, got %q (suggestion %q)", f.Message, f.Suggestion)
+	}
+}
+
+func TestDetectUnknownKeys_NestedTopLevelKeyInSubchart(t *testing.T) {
+	defaults := parseYAML(t, `
+image:
+  repository: nginx
+`)
+	subDefaults := map[string]*yaml.Node{"redis": parseYAML(t, `
+master:
+  port: 6379
+`)}
+	user := parseYAML(t, `
+redis:
+  master:
+    image: redis:7
+`)
+	findings := detectUnknownKeys(user, defaults, nil, nil, subDefaults, nil, "", newPathIndex(collectAllPaths(defaults, "")), defaults, false, true)
+	if len(findings) != 1 {
+		t.Fatalf("expected 1 finding, got %d: %v", len(findings), findings)
+	}
+	if strings.Contains(findings[0].Message, "nested") {
+		t.Errorf("expected a subchart key not to be taken for a root-chart key, got %q", findings[0].Message)
+	}
+}
@@ -1,8 +1,17 @@
 package validator
 
 import (
+	"bytes"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/chrishham/helm-values-checker/internal/chart"
 	"github.com/chrishham/helm-values-checker/internal/model"
@@ -12,8 +21,170 @@ import (
 // maxValuesFileSize is the maximum allowed size for a values file (10 MB).
 const maxValuesFileSize = 10 * 1024 * 1024
 
+// Check names accepted in Options.Only. CheckUnknown also covers the
+// disabled-subchart and missing-subchart findings, since both are about
+// keys set where the chart cannot use them.
+const (
+	CheckUnknown    = "unknown"
+	CheckType       = "type"
+	CheckSchema     = "schema"
+	CheckDeprecated = "deprecated"
+)
+
+// Phase names recorded in ValidationResult.Timings with Options.Profile.
+const (
+	PhaseParse       = "parse"
+	PhasePrepare     = "prepare"
+	PhaseUnknownKeys = "unknown keys"
+	PhaseTypes       = "type checks"
+	PhaseSchema      = "schema"
+)
+
+// Checks lists every check name, in the order the checks run.
+var Checks = []string{CheckUnknown, CheckType, CheckSchema, CheckDeprecated}
+
+// Options controls how values are validated.
+type Options struct {
+	// IgnoreKeys lists key paths (glob patterns) that are skipped by all checks.
+	IgnoreKeys []string
+
+	// Overrides, if set, is deep-merged over the values before validation,
+	// like Helm's --set flags (see ParseOverrides).
+	Overrides *yaml.Node
+
+	// QuantityPaths lists additional key paths (glob patterns) holding
+	// Kubernetes quantities, where strings like "10Gi" and plain numbers are
+	// interchangeable. resources.{limits,requests} fields and schema
+	// properties with a quantity pattern are always treated this way.
+	QuantityPaths []string
+
+	// Only, if non-empty, restricts validation to the named checks (see
+	// Checks). Alias checks always run, since the others depend on them.
+	Only []string
+
+	// AllowPlaceholders drops the warning for string placeholders such as
+	// "${REPLICAS}" in fields whose expected type is not a string.
+	AllowPlaceholders bool
+
+	// AllowTemplates drops type mismatches and invalid quantities for string
+	// values containing a Go template action such as "{{ .Release.Name }}",
+	// which are rendered before the value is used.
+	AllowTemplates bool
+
+	// StrictUnknown reports every key missing from the chart and subchart
+	// defaults, even when the schema declares it.
+	StrictUnknown bool
+
+	// AllowEmpty treats an empty or comments-only values file as an empty
+	// mapping instead of an error.
+	AllowEmpty bool
+
+	// IgnoreRequired lists key paths (glob patterns) of schema-required keys
+	// that may be missing, e.g. because they are supplied with --set at
+	// deploy time. Unlike IgnoreKeys, it only affects required-key checks.
+	IgnoreRequired []string
+
+	// WarnRedundant reports scalars set to exactly their chart default,
+	// which can be removed from the values file.
+	WarnRedundant bool
+
+	// AllowKeys, if non-empty, lists the only key paths (glob patterns) a
+	// values file may set. Any other key is an error, even if the chart
+	// defines it.
+	AllowKeys []string
+
+	// Coverage records in the result which defaulted keys (from the chart's
+	// values.yaml or schema defaults) the values file sets.
+	Coverage bool
+
+	// Profile records in the result how long each validation phase took.
+	Profile bool
+
+	// NoSuggestions skips the fuzzy search for "did you mean" suggestions
+	// on unknown keys, which is slow on very large charts.
+	NoSuggestions bool
+
+	// CommentDirectives drops the findings on keys annotated with a
+	// "# values-checker:ignore" comment (see IgnoreDirective).
+	CommentDirectives bool
+
+	// ValuesKey, if set, is the dot-separated path of the mapping within a
+	// values file that holds the chart's values (e.g., "helm" for values
+	// embedded in a larger config file). Only that subtree is validated.
+	ValuesKey string
+
+	// Escalations override the severity of findings by key path, so that
+	// e.g. any finding under security.** is an error (see ParseEscalations).
+	Escalations []Escalation
+}
+
+// runs reports whether the named check is enabled by opts.Only.
+func (opts Options) runs(check string) bool {
+	if len(opts.Only) == 0 {
+		return true
+	}
+	for _, c := range opts.Only {
+		if c == check {
+			return true
+		}
+	}
+	return false
+}
+
 // Validate runs all validation checks on a values file against the resolved chart.
 func Validate(valuesFile string, resolved *chart.ResolvedChart, ignoreKeys []string) (*model.ValidationResult, error) {
+	return ValidateWithOptions(valuesFile, resolved, Options{IgnoreKeys: ignoreKeys})
+}
+
+// ValidateWithOptions is like Validate but takes the full set of options.
+func ValidateWithOptions(valuesFile string, resolved *chart.ResolvedChart, opts Options) (*model.ValidationResult, error) {
+	data, err := readValuesFile(valuesFile)
+	if err != nil {
+		return nil, err
+	}
+
+	return ValidateBytesWithOptions(valuesFile, data, resolved, opts)
+}
+
+// ValidateFiles validates each values file like ValidateWithOptions, using
+// up to jobs files at a time (at least one). The results and errors are
+// indexed like files, so their order does not depend on scheduling; for
+// each file exactly one of the two is set. resolved is only read, so it is
+// shared by all workers.
+func ValidateFiles(files []string, resolved *chart.ResolvedChart, opts Options, jobs int) ([]*model.ValidationResult, []error) {
+	results := make([]*model.ValidationResult, len(files))
+	errs := make([]error, len(files))
+	if jobs < 1 {
+		jobs = 1
+	}
+
+	indices := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < jobs && w < len(files); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indices {
+				results[i], errs[i] = ValidateWithOptions(files[i], resolved, opts)
+			}
+		}()
+	}
+	for i := range files {
+		indices <- i
+	}
+	close(indices)
+	wg.Wait()
+
+	return results, errs
+}
+
+// readValuesFile reads a values file from disk, or from an http(s) URL,
+// enforcing maxValuesFileSize.
+func readValuesFile(valuesFile string) ([]byte, error) {
+	if isValuesURL(valuesFile) {
+		return fetchValuesFile(valuesFile)
+	}
+
 	fi, err := os.Stat(valuesFile)
 	if err != nil {
 		return nil, fmt.Errorf("reading values file %s: %w", valuesFile, err)
@@ -26,52 +197,394 @@ func Validate(valuesFile string, resolved *chart.ResolvedChart, ignoreKeys []str
 	if err != nil {
 		return nil, fmt.Errorf("reading values file %s: %w", valuesFile, err)
 	}
+	return data, nil
+}
 
-	userDoc := &yaml.Node{}
-	if err := yaml.Unmarshal(data, userDoc); err != nil {
-		return nil, fmt.Errorf("parsing values file %s: %w", valuesFile, err)
+// ValidateBytes runs all validation checks on values content that did not
+// come from a file on disk (e.g., values of a deployed release). valuesFile
+// labels the source in the result and in error messages.
+func ValidateBytes(valuesFile string, data []byte, resolved *chart.ResolvedChart, ignoreKeys []string) (*model.ValidationResult, error) {
+	return ValidateBytesWithOptions(valuesFile, data, resolved, Options{IgnoreKeys: ignoreKeys})
+}
+
+// ValidateBytesWithOptions is like ValidateBytes but takes the full set of options.
+func ValidateBytesWithOptions(valuesFile string, data []byte, resolved *chart.ResolvedChart, opts Options) (*model.ValidationResult, error) {
+	start := time.Now()
+	userDoc, err := decodeUserValues(valuesFile, data, opts)
+	if err != nil {
+		return nil, err
+	}
+	parsed := time.Now()
+
+	result, err := ValidateNodeWithOptions(userDoc, resolved, opts)
+	if err != nil {
+		return nil, fmt.Errorf("values file %s: %w", valuesFile, err)
+	}
+	result.ValuesFile = valuesFile
+	attachSourceLines(result.Findings, data)
+	if opts.Profile {
+		parse := model.PhaseTiming{Phase: PhaseParse, Duration: parsed.Sub(start)}
+		result.Timings = append([]model.PhaseTiming{parse}, result.Timings...)
 	}
 
-	var userNode *yaml.Node
-	if userDoc.Kind == yaml.DocumentNode && len(userDoc.Content) > 0 {
-		userNode = userDoc.Content[0]
-	} else {
-		userNode = userDoc
+	return result, nil
+}
+
+// decodeUserValues decodes values content into the tree to validate: the
+// document, an empty mapping for an empty document with AllowEmpty, or the
+// ValuesKey subtree.
+func decodeUserValues(valuesFile string, data []byte, opts Options) (*yaml.Node, error) {
+	userDoc, err := decodeValues(valuesFile, data)
+	if err != nil {
+		return nil, err
+	}
+	if isEmptyDocument(userDoc) {
+		if !opts.AllowEmpty {
+			return nil, fmt.Errorf("values file %s is empty", valuesFile)
+		}
+		userDoc = &yaml.Node{Kind: yaml.MappingNode}
+	}
+	if opts.ValuesKey != "" {
+		if userDoc, err = valuesSubtree(userDoc, opts.ValuesKey); err != nil {
+			return nil, fmt.Errorf("values file %s: %w", valuesFile, err)
+		}
 	}
+	return userDoc, nil
+}
+
+// ValidateNode runs all validation checks on an already-parsed values tree,
+// either a document node or its top-level mapping. The result's ValuesFile
+// is left for the caller to set, and findings carry no SourceLine.
+func ValidateNode(userNode *yaml.Node, resolved *chart.ResolvedChart, ignoreKeys []string) (*model.ValidationResult, error) {
+	return ValidateNodeWithOptions(userNode, resolved, Options{IgnoreKeys: ignoreKeys})
+}
+
+// ValidateNodeWithOptions is like ValidateNode but takes the full set of options.
+func ValidateNodeWithOptions(userNode *yaml.Node, resolved *chart.ResolvedChart, opts Options) (*model.ValidationResult, error) {
+	ignoreKeys := opts.IgnoreKeys
 
-	if userNode.Kind != yaml.MappingNode {
-		return nil, fmt.Errorf("values file %s: expected a YAML mapping at top level", valuesFile)
+	userNode, err := topLevelMapping(userNode)
+	if err != nil {
+		return nil, err
+	}
+	var commentIgnored []string
+	if opts.CommentDirectives {
+		commentIgnored = commentIgnoredPaths(userNode, "", nil)
+	}
+	if opts.Overrides != nil {
+		userNode = mergeOverrides(userNode, opts.Overrides)
 	}
 
 	result := &model.ValidationResult{
-		ValuesFile:   valuesFile,
 		ChartName:    resolved.Chart.Metadata.Name,
 		ChartVersion: resolved.Chart.Metadata.Version,
+		AppVersion:   resolved.Chart.Metadata.AppVersion,
+	}
+	phaseStart := time.Now()
+	endPhase := func(phase string) {
+		if opts.Profile {
+			now := time.Now()
+			result.Timings = append(result.Timings, model.PhaseTiming{Phase: phase, Duration: now.Sub(phaseStart)})
+			phaseStart = now
+		}
 	}
 
-	// Extract schema-defined keys if schema is available
-	schemaKeys := extractSchemaKeys(resolved.SchemaBytes)
+	// 0. Broken aliases; a cyclic tree would send the other checks into a loop
+	aliasFindings, cyclic := checkAliases(userNode, ignoreKeys)
+	result.Findings = append(result.Findings, aliasFindings...)
+	if cyclic {
+		result.Findings = dropCommentIgnored(result.Findings, commentIgnored)
+		escalate(result.Findings, opts.Escalations)
+		return result, nil
+	}
+
+	// Resolve merge keys (<<) on both sides, so merged-in keys are known
+	// and checked against the defaults they end up beside
+	userNode = expandMergeKeys(userNode)
+	defaultsNode := expandMergeKeys(resolved.DefaultsNode)
+	subchartDefaults := expandSubchartMergeKeys(resolved.SubchartDefaults)
+
+	// Combine the chart schema with subchart schemas scoped under their keys.
+	// Charts without any schema skip the schema work below altogether; see
+	// BenchmarkValidateNode
+	schemaBytes := mergeSubchartSchemas(resolved.SchemaBytes, resolved.SubchartSchemas)
+	hasSchema := len(schemaBytes) > 0
 
-	// Extract schema type definitions for type checking fallback
-	schemaTypes := extractSchemaTypes(resolved.SchemaBytes)
+	var (
+		schemaKeys     map[string]bool
+		schemaPatterns map[string][]*regexp.Regexp
+		schemaTypes    SchemaTypeMap
+		quantityPaths  = opts.QuantityPaths
+		knownDefaults  = defaultsNode
+	)
+	if hasSchema {
+		// Schema-defined keys, and types as a fallback for type checking
+		schemaKeys = extractSchemaKeys(schemaBytes)
+		schemaPatterns = extractSchemaPatterns(schemaBytes)
+		schemaTypes = extractSchemaTypes(schemaBytes)
 
-	// Pre-compute all paths from defaults tree for deep suggestions
-	allPaths := collectAllPaths(resolved.DefaultsNode, "")
+		// Quantity fields from the command line and from schema patterns
+		quantityPaths = append(append([]string(nil), opts.QuantityPaths...), extractQuantityPaths(schemaBytes)...)
+
+		// Schema defaults fill in keys the chart's values.yaml leaves out, for
+		// the checks that ask what a key is rather than what Helm will render
+		knownDefaults = fillDefaults(defaultsNode, extractSchemaDefaults(schemaBytes))
+	}
+
+	// Pre-compute all paths from the defaults tree and the schema for deep
+	// suggestions; schema-only keys, schema defaults included, are unknown
+	// with StrictUnknown
+	var allPaths *pathIndex
+	if !opts.NoSuggestions {
+		var paths map[string]string
+		if opts.StrictUnknown {
+			paths = collectAllPaths(defaultsNode, "")
+		} else {
+			paths = addSchemaPaths(collectAllPaths(knownDefaults, ""), schemaKeys)
+		}
+		allPaths = newPathIndex(paths)
+	}
+
+	if opts.Coverage {
+		result.Coverage = computeCoverage(userNode, knownDefaults, ignoreKeys)
+	}
+	endPhase(PhasePrepare)
 
 	// 1. Unknown key detection
-	result.Findings = append(result.Findings,
-		detectUnknownKeys(userNode, resolved.DefaultsNode, schemaKeys, resolved.SubchartDefaults, ignoreKeys, "", allPaths)...)
+	if opts.runs(CheckUnknown) {
+		knownKeys, knownPatterns, known := schemaKeys, schemaPatterns, knownDefaults
+		if opts.StrictUnknown {
+			knownKeys, knownPatterns, known = nil, nil, defaultsNode
+		}
+
+		// Keys of unvendored subcharts get one warning instead, since their
+		// defaults are unknown (or, for a release's chart, one info note)
+		unvendored := resolved.UnvendoredDependencies()
+		unknownIgnores := append([]string(nil), ignoreKeys...)
+		for _, key := range unvendored {
+			unknownIgnores = append(unknownIgnores, key, key+".**")
+		}
+		toggles := resolved.SubchartToggles()
+		result.Findings = append(result.Findings,
+			detectUnknownKeys(userNode, known, knownKeys, knownPatterns, subchartDefaults, unknownIgnores, "", allPaths, known, false, !opts.NoSuggestions)...)
+		result.Findings = append(result.Findings,
+			detectDisabledSubcharts(userNode, defaultsNode, toggles, ignoreKeys)...)
+		result.Findings = append(result.Findings,
+			detectUnvendoredSubcharts(userNode, unvendored, toggles, ignoreKeys, resolved.FromRelease)...)
+	}
+
+	// Allowlist: keys outside --allow-keys, known to the chart or not, unless
+	// already reported as unknown
+	if len(opts.AllowKeys) > 0 {
+		disallowed := detectDisallowedKeys(userNode, opts.AllowKeys, ignoreKeys, "")
+		result.Findings = append(result.Findings, withoutUnknownKeys(disallowed, result.Findings)...)
+	}
+	endPhase(PhaseUnknownKeys)
 
 	// 2. Type mismatch detection (uses schema types as fallback for null/absent defaults)
-	result.Findings = append(result.Findings,
-		detectTypeMismatches(userNode, resolved.DefaultsNode, ignoreKeys, "", schemaTypes)...)
+	if opts.runs(CheckType) {
+		typeFindings := detectTypeMismatches(userNode, knownDefaults, ignoreKeys, quantityPaths, "", schemaTypes)
+		if opts.AllowPlaceholders {
+			typeFindings = dropRule(typeFindings, model.RuleUnexpandedPlaceholder)
+		}
+		if opts.AllowTemplates {
+			typeFindings = dropTemplatedMismatches(typeFindings, userNode)
+		}
+		if !opts.StrictUnknown {
+			typeFindings = dropPatternKeys(typeFindings, userNode, schemaPatterns)
+		}
+		if opts.NoSuggestions {
+			dropSuggestions(typeFindings)
+		}
+		result.Findings = append(result.Findings, typeFindings...)
+		if hasSchema {
+			result.Findings = append(result.Findings,
+				checkIntegerFormats(userNode, extractIntegerFormats(schemaBytes), ignoreKeys, "")...)
+		}
+	}
+
+	// Opt-in: values that repeat the chart default
+	if opts.WarnRedundant {
+		result.Findings = append(result.Findings, detectRedundantValues(userNode, defaultsNode, ignoreKeys, "")...)
+	}
+	endPhase(PhaseTypes)
 
 	// 3. Schema validation (required fields + deprecated keys; type errors filtered when custom checker handles them)
-	schemaFindings, err := validateSchema(userNode, resolved.SchemaBytes, ignoreKeys, schemaTypes)
-	if err != nil {
-		return nil, fmt.Errorf("schema validation for %s: %w", valuesFile, err)
+	if hasSchema && (opts.runs(CheckSchema) || opts.runs(CheckDeprecated)) {
+		schemaFindings, err := validateSchema(userNode, schemaBytes, ignoreKeys, opts.IgnoreRequired, schemaTypes)
+		if err != nil {
+			return nil, fmt.Errorf("schema validation: %w", err)
+		}
+		if opts.runs(CheckSchema) {
+			result.Findings = append(result.Findings, checkSchemaDrafts(resolved.SchemaBytes, resolved.SubchartSchemas)...)
+		}
+		for _, f := range schemaFindings {
+			deprecation := f.Rule == model.RuleSchemaDeprecated
+			if (deprecation && opts.runs(CheckDeprecated)) || (!deprecation && opts.runs(CheckSchema)) {
+				result.Findings = append(result.Findings, f)
+			}
+		}
 	}
-	result.Findings = append(result.Findings, schemaFindings...)
 
+	// 4. Informational: required keys left at their chart default
+	if hasSchema && opts.runs(CheckSchema) {
+		result.Findings = append(result.Findings,
+			checkRequiredDefaults(userNode, defaultsNode, schemaBytes, append(append([]string(nil), ignoreKeys...), opts.IgnoreRequired...))...)
+	}
+	endPhase(PhaseSchema)
+
+	result.Findings = dropCommentIgnored(result.Findings, commentIgnored)
+	escalate(result.Findings, opts.Escalations)
+	result.CheckedKeys, result.KnownKeys = countKeys(userNode, ignoreKeys, commentIgnored, result.Findings)
 	return result, nil
 }
+
+// dropRule returns findings without those produced by rule.
+func dropRule(findings []model.Finding, rule string) []model.Finding {
+	kept := findings[:0]
+	for _, f := range findings {
+		if f.Rule != rule {
+			kept = append(kept, f)
+		}
+	}
+	return kept
+}
+
+// dropSuggestions clears the suggestions of findings in place, for unknown
+// keys in list elements, which type checking reports.
+func dropSuggestions(findings []model.Finding) {
+	for i := range findings {
+		f := &findings[i]
+		f.Suggestion, f.Suggestions, f.SuggestionLine, f.Confidence = "", nil, 0, 0
+	}
+}
+
+// decodeValues parses values content into a YAML document node.
+func decodeValues(valuesFile string, data []byte) (*yaml.Node, error) {
+	if len(data) > maxValuesFileSize {
+		return nil, fmt.Errorf("values %s are too large (%d bytes, max %d)", valuesFile, len(data), maxValuesFileSize)
+	}
+
+	// JSON is parsed as YAML too, which keeps line numbers, but is checked
+	// with the JSON decoder first so that syntax errors read as JSON errors
+	if isJSONValuesFile(valuesFile) && len(bytes.TrimSpace(data)) > 0 {
+		var v interface{}
+		if err := json.Unmarshal(data, &v); err != nil {
+			return nil, fmt.Errorf("parsing JSON values file %s: %w", valuesFile, err)
+		}
+		if _, ok := v.(map[string]interface{}); !ok {
+			return nil, fmt.Errorf("values file %s: expected a JSON object at top level", valuesFile)
+		}
+	}
+
+	userDoc := &yaml.Node{}
+	if err := yaml.Unmarshal(data, userDoc); err != nil {
+		if line := tabIndentedLine(err, data); line > 0 {
+			return nil, fmt.Errorf("parsing values file %s: line %d is indented with a tab, but YAML only allows spaces for indentation: %w", valuesFile, line, err)
+		}
+		return nil, fmt.Errorf("parsing values file %s: %w", valuesFile, err)
+	}
+	return userDoc, nil
+}
+
+// yamlErrorLineRe extracts the line number from a yaml.v3 syntax error.
+var yamlErrorLineRe = regexp.MustCompile(`^yaml: line (\d+):`)
+
+// tabIndentedLine returns the line most likely responsible for a yaml.v3
+// tab error, or 0 if err is not one. The parser reports tab errors on the
+// line where the enclosing block started, which is often earlier than the
+// tab, so the first tab-indented line at or after that line is used, or 0
+// if there is none.
+func tabIndentedLine(err error, data []byte) int {
+	msg := err.Error()
+	if !strings.Contains(msg, "tab character") && !strings.Contains(msg, "cannot start any token") {
+		return 0
+	}
+	from := 1
+	if m := yamlErrorLineRe.FindStringSubmatch(msg); m != nil {
+		from, _ = strconv.Atoi(m[1])
+	}
+
+	for i, line := range strings.Split(string(data), "\n") {
+		indent := line[:len(line)-len(strings.TrimLeft(line, " \t"))]
+		if i+1 >= from && strings.Contains(indent, "\t") {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+// isJSONValuesFile reports whether a values file should be read as JSON,
+// judging by its extension.
+func isJSONValuesFile(valuesFile string) bool {
+	return strings.EqualFold(filepath.Ext(valuesFile), ".json")
+}
+
+// parseValues parses values content and returns its top-level mapping node.
+func parseValues(valuesFile string, data []byte) (*yaml.Node, error) {
+	userDoc, err := decodeValues(valuesFile, data)
+	if err != nil {
+		return nil, err
+	}
+
+	if isEmptyDocument(userDoc) {
+		return nil, fmt.Errorf("values file %s is empty", valuesFile)
+	}
+
+	userNode, err := topLevelMapping(userDoc)
+	if err != nil {
+		return nil, fmt.Errorf("values file %s: %w", valuesFile, err)
+	}
+	return userNode, nil
+}
+
+// isEmptyDocument reports whether decoded values content holds no value at
+// all: blank input, or only whitespace and comments. An explicit null such
+// as "~" is not empty.
+func isEmptyDocument(doc *yaml.Node) bool {
+	return doc.Kind == 0 || (doc.Kind == yaml.DocumentNode && len(doc.Content) == 0)
+}
+
+// topLevelMapping unwraps a document node and checks that the values tree
+// is a mapping.
+func topLevelMapping(node *yaml.Node) (*yaml.Node, error) {
+	if node != nil && node.Kind == yaml.DocumentNode && len(node.Content) > 0 {
+		node = node.Content[0]
+	}
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil, errors.New("expected a YAML mapping at top level")
+	}
+	return node, nil
+}
+
+// valuesSubtree returns the mapping at the dot-separated key path within the
+// values document node. Its nodes keep their positions in the file, so
+// findings point at the right lines.
+func valuesSubtree(node *yaml.Node, key string) (*yaml.Node, error) {
+	node, err := topLevelMapping(node)
+	if err != nil {
+		return nil, err
+	}
+	for _, part := range strings.Split(key, ".") {
+		if node = getValueForKey(node, part); node == nil {
+			return nil, fmt.Errorf("values key %s not found", key)
+		}
+		if node.Kind != yaml.MappingNode {
+			return nil, fmt.Errorf("values key %s is not a mapping", key)
+		}
+	}
+	return node, nil
+}
+
+// attachSourceLines fills in each finding's SourceLine with the trimmed text
+// of the line it points at. Findings without a line (Line 0) are left empty.
+func attachSourceLines(findings []model.Finding, data []byte) {
+	lines := strings.Split(string(data), "\n")
+	for i := range findings {
+		line := findings[i].Line
+		if line < 1 || line > len(lines) {
+			continue
+		}
+		findings[i].SourceLine = strings.TrimSpace(lines[line-1])
+	}
+}
@@ -1,6 +1,7 @@
 package validator
 
 import (
+	"errors"
 	"os"
 	"path/filepath"
 	"runtime"
@@ -8,6 +9,8 @@ import (
 	"testing"
 
 	"github.com/chrishham/helm-values-checker/internal/chart"
+	"github.com/chrishham/helm-values-checker/internal/model"
+	"gopkg.in/yaml.v3"
 )
 
 func testdataDir() string {
@@ -31,6 +34,9 @@ func TestValidate_GoodValues(t *testing.T) {
 	if result.HasErrors() {
 		t.Errorf("expected no errors for good values, got: %v", result.Errors())
 	}
+	if result.AppVersion != "2.4.1" {
+		t.Errorf("expected the chart's appVersion, got %q", result.AppVersion)
+	}
 }
 
 func TestValidate_BadValues(t *testing.T) {
@@ -186,3 +192,709 @@ func TestValidate_FileSizeLimit(t *testing.T) {
 		t.Errorf("expected 'too large' error, got: %v", err)
 	}
 }
+
+func TestValidate_SubchartSchemaRequired(t *testing.T) {
+	chartPath := filepath.Join(testdataDir(), "test-chart-with-subchart-schema")
+	resolved, err := chart.Resolve(chartPath, "")
+	if err != nil {
+		t.Fatalf("failed to resolve chart: %v", err)
+	}
+	defer resolved.Cleanup()
+
+	if _, ok := resolved.SubchartSchemas["database"]; !ok {
+		t.Fatalf("expected subchart schema for 'database' to be collected")
+	}
+
+	result, err := Validate(filepath.Join(testdataDir(), "subchart-schema-bad-values.yaml"), resolved, nil)
+	if err != nil {
+		t.Fatalf("validation error: %v", err)
+	}
+
+	found := false
+	for _, f := range result.Errors() {
+		if f.KeyPath == "database.auth" && strings.Contains(f.Message, "password") {
+			found = true
+			if f.Line != 5 {
+				t.Errorf("expected line 5 for database.auth, got %d", f.Line)
+			}
+		}
+	}
+	if !found {
+		t.Errorf("expected required error for 'database.auth.password' from subchart schema, findings: %v", result.Findings)
+	}
+}
+
+func TestValidate_FindingRules(t *testing.T) {
+	chartPath := filepath.Join(testdataDir(), "test-chart-with-schema")
+	resolved, err := chart.Resolve(chartPath, "")
+	if err != nil {
+		t.Fatalf("failed to resolve chart: %v", err)
+	}
+	defer resolved.Cleanup()
+
+	result, err := Validate(filepath.Join(testdataDir(), "schema-bad-values.yaml"), resolved, nil)
+	if err != nil {
+		t.Fatalf("validation error: %v", err)
+	}
+
+	rules := make(map[string]string)
+	for _, f := range result.Findings {
+		rules[f.KeyPath] = f.Rule
+	}
+	if rules["oldSetting"] != model.RuleSchemaDeprecated {
+		t.Errorf("expected rule %q for oldSetting, got %q", model.RuleSchemaDeprecated, rules["oldSetting"])
+	}
+	if rules["auth.username"] != model.RuleSchemaLength {
+		t.Errorf("expected rule %q for auth.username, got %q", model.RuleSchemaLength, rules["auth.username"])
+	}
+
+	result, err = Validate(filepath.Join(testdataDir(), "bad-values.yaml"), resolved, nil)
+	if err != nil {
+		t.Fatalf("validation error: %v", err)
+	}
+	for _, f := range result.Findings {
+		switch f.KeyPath {
+		case "unknownKey":
+			if f.Rule != model.RuleUnknownKey {
+				t.Errorf("expected rule %q for unknownKey, got %q", model.RuleUnknownKey, f.Rule)
+			}
+		case "replicaCount":
+			if f.Rule != model.RuleTypeMismatch {
+				t.Errorf("expected rule %q for replicaCount, got %q", model.RuleTypeMismatch, f.Rule)
+			}
+		}
+	}
+}
+
+func TestValidate_SourceLines(t *testing.T) {
+	chartPath := filepath.Join(testdataDir(), "test-chart")
+	resolved, err := chart.Resolve(chartPath, "")
+	if err != nil {
+		t.Fatalf("failed to resolve chart: %v", err)
+	}
+	defer resolved.Cleanup()
+
+	result, err := Validate(filepath.Join(testdataDir(), "bad-values.yaml"), resolved, nil)
+	if err != nil {
+		t.Fatalf("validation error: %v", err)
+	}
+
+	sources := make(map[string]string)
+	for _, f := range result.Findings {
+		sources[f.KeyPath] = f.SourceLine
+	}
+	if sources["image.regsitry"] != "regsitry: docker.io" {
+		t.Errorf("expected source line 'regsitry: docker.io', got %q", sources["image.regsitry"])
+	}
+	if sources["replicaCount"] != `replicaCount: "three"` {
+		t.Errorf("expected source line 'replicaCount: \"three\"', got %q", sources["replicaCount"])
+	}
+}
+
+func TestAttachSourceLines_NoLine(t *testing.T) {
+	findings := []model.Finding{{Line: 0, KeyPath: "name"}, {Line: 99, KeyPath: "beyond"}}
+	attachSourceLines(findings, []byte("a: 1\n"))
+	for _, f := range findings {
+		if f.SourceLine != "" {
+			t.Errorf("expected no source line for %s, got %q", f.KeyPath, f.SourceLine)
+		}
+	}
+}
+
+func TestValidateNode(t *testing.T) {
+	chartPath := filepath.Join(testdataDir(), "test-chart")
+	resolved, err := chart.Resolve(chartPath, "")
+	if err != nil {
+		t.Fatalf("failed to resolve chart: %v", err)
+	}
+	defer resolved.Cleanup()
+
+	user := parseYAML(t, `
+replicaCount: 2
+image:
+  regsitry: docker.io
+`)
+	result, err := ValidateNode(user, resolved, nil)
+	if err != nil {
+		t.Fatalf("validation error: %v", err)
+	}
+	if len(result.Errors()) != 1 || result.Errors()[0].KeyPath != "image.regsitry" {
+		t.Errorf("expected one error for image.regsitry, got %v", result.Errors())
+	}
+	if result.ChartName != "test-chart" {
+		t.Errorf("expected chart name 'test-chart', got %q", result.ChartName)
+	}
+}
+
+func TestValidateNode_RequiresMapping(t *testing.T) {
+	chartPath := filepath.Join(testdataDir(), "test-chart")
+	resolved, err := chart.Resolve(chartPath, "")
+	if err != nil {
+		t.Fatalf("failed to resolve chart: %v", err)
+	}
+	defer resolved.Cleanup()
+
+	for _, node := range []*yaml.Node{nil, parseYAML(t, `- a`)} {
+		if _, err := ValidateNode(node, resolved, nil); err == nil || !strings.Contains(err.Error(), "expected a YAML mapping") {
+			t.Errorf("expected top-level mapping error, got %v", err)
+		}
+	}
+}
+
+func TestValidateBytesWithOptions_AllowPlaceholders(t *testing.T) {
+	chartPath := filepath.Join(testdataDir(), "test-chart")
+	resolved, err := chart.Resolve(chartPath, "")
+	if err != nil {
+		t.Fatalf("failed to resolve chart: %v", err)
+	}
+	defer resolved.Cleanup()
+
+	data := []byte("replicaCount: \"${REPLICAS}\"\n")
+
+	result, err := ValidateBytes("values.yaml", data, resolved, nil)
+	if err != nil {
+		t.Fatalf("validation error: %v", err)
+	}
+	if result.HasErrors() || len(result.Warnings()) != 1 {
+		t.Errorf("expected one placeholder warning by default, got %v", result.Findings)
+	}
+
+	result, err = ValidateBytesWithOptions("values.yaml", data, resolved, Options{AllowPlaceholders: true})
+	if err != nil {
+		t.Fatalf("validation error: %v", err)
+	}
+	if len(result.Findings) != 0 {
+		t.Errorf("expected no findings with AllowPlaceholders, got %v", result.Findings)
+	}
+}
+
+func TestValidateBytesWithOptions_AllowTemplates(t *testing.T) {
+	resolved, err := chart.Resolve(filepath.Join(testdataDir(), "test-chart"), "")
+	if err != nil {
+		t.Fatalf("failed to resolve chart: %v", err)
+	}
+	defer resolved.Cleanup()
+
+	data := []byte("replicaCount: \"{{ .Values.global.replicas }}\"\nservice:\n  port: eighty\nresources:\n  limits:\n    memory: \"{{ .Values.mem }}\"\n")
+
+	result, err := ValidateBytes("values.yaml", data, resolved, nil)
+	if err != nil {
+		t.Fatalf("validation error: %v", err)
+	}
+	if len(result.Errors()) != 3 {
+		t.Errorf("expected both type mismatches and the invalid quantity by default, got %v", result.Findings)
+	}
+
+	result, err = ValidateBytesWithOptions("values.yaml", data, resolved, Options{AllowTemplates: true})
+	if err != nil {
+		t.Fatalf("validation error: %v", err)
+	}
+	if len(result.Findings) != 1 || result.Findings[0].KeyPath != "service.port" {
+		t.Errorf("expected only the untemplated service.port mismatch with AllowTemplates, got %v", result.Findings)
+	}
+}
+
+func TestValidateWithOptions_SetOverrideTypeMismatch(t *testing.T) {
+	chartPath := filepath.Join(testdataDir(), "test-chart")
+	resolved, err := chart.Resolve(chartPath, "")
+	if err != nil {
+		t.Fatalf("failed to resolve chart: %v", err)
+	}
+	defer resolved.Cleanup()
+
+	overrides, err := ParseOverrides([]string{"replicaCount=foo"}, nil)
+	if err != nil {
+		t.Fatalf("ParseOverrides error: %v", err)
+	}
+
+	result, err := ValidateWithOptions(filepath.Join(testdataDir(), "good-values.yaml"), resolved, Options{Overrides: overrides})
+	if err != nil {
+		t.Fatalf("validation error: %v", err)
+	}
+
+	errors := result.Errors()
+	if len(errors) != 1 || errors[0].KeyPath != "replicaCount" || errors[0].Rule != model.RuleTypeMismatch {
+		t.Fatalf("expected one type mismatch for replicaCount, got %v", errors)
+	}
+	if errors[0].Line != 0 || errors[0].SourceLine != "" {
+		t.Errorf("expected no file position for an override, got line %d (%q)", errors[0].Line, errors[0].SourceLine)
+	}
+}
+
+func TestValidateWithOptions_OnlyUnknown(t *testing.T) {
+	chartPath := filepath.Join(testdataDir(), "test-chart")
+	resolved, err := chart.Resolve(chartPath, "")
+	if err != nil {
+		t.Fatalf("failed to resolve chart: %v", err)
+	}
+	defer resolved.Cleanup()
+	path := filepath.Join(testdataDir(), "bad-values.yaml")
+
+	// Without --only, the same file also has a type mismatch
+	result, err := ValidateWithOptions(path, resolved, Options{})
+	if err != nil {
+		t.Fatalf("validation error: %v", err)
+	}
+	mismatches := 0
+	for _, f := range result.Findings {
+		if f.Rule == model.RuleTypeMismatch {
+			mismatches++
+		}
+	}
+	if mismatches == 0 {
+		t.Fatalf("expected type mismatches without Only, got %v", result.Findings)
+	}
+
+	result, err = ValidateWithOptions(path, resolved, Options{Only: []string{CheckUnknown}})
+	if err != nil {
+		t.Fatalf("validation error: %v", err)
+	}
+
+	if len(result.Findings) == 0 {
+		t.Fatal("expected unknown key findings, got none")
+	}
+	for _, f := range result.Findings {
+		if f.Rule != model.RuleUnknownKey {
+			t.Errorf("expected only %s findings, got %v", model.RuleUnknownKey, f)
+		}
+	}
+}
+
+func TestValidateWithOptions_OnlyDeprecated(t *testing.T) {
+	chartPath := filepath.Join(testdataDir(), "test-chart-with-schema")
+	resolved, err := chart.Resolve(chartPath, "")
+	if err != nil {
+		t.Fatalf("failed to resolve chart: %v", err)
+	}
+	defer resolved.Cleanup()
+
+	result, err := ValidateWithOptions(filepath.Join(testdataDir(), "schema-bad-values.yaml"), resolved, Options{Only: []string{CheckDeprecated}})
+	if err != nil {
+		t.Fatalf("validation error: %v", err)
+	}
+	if len(result.Findings) == 0 {
+		t.Fatal("expected a deprecation finding for oldSetting, got none")
+	}
+	for _, f := range result.Findings {
+		if f.Rule != model.RuleSchemaDeprecated {
+			t.Errorf("expected only %s findings, got %v", model.RuleSchemaDeprecated, f)
+		}
+	}
+}
+
+func TestValidate_JSONValues(t *testing.T) {
+	chartPath := filepath.Join(testdataDir(), "test-chart")
+	resolved, err := chart.Resolve(chartPath, "")
+	if err != nil {
+		t.Fatalf("failed to resolve chart: %v", err)
+	}
+	defer resolved.Cleanup()
+
+	result, err := Validate(filepath.Join(testdataDir(), "json-values.json"), resolved, nil)
+	if err != nil {
+		t.Fatalf("validation error: %v", err)
+	}
+
+	errors := result.Errors()
+	if len(errors) != 2 {
+		t.Fatalf("expected 2 errors, got %d: %v", len(errors), errors)
+	}
+	unknown, mismatch := errors[0], errors[1]
+	if unknown.Rule != model.RuleUnknownKey || unknown.KeyPath != "image.tga" || unknown.Line != 5 || unknown.Suggestion != "image.tag" {
+		t.Errorf("expected unknown key image.tga at line 5 suggesting image.tag, got %+v", unknown)
+	}
+	if mismatch.Rule != model.RuleTypeMismatch || mismatch.KeyPath != "service.port" || mismatch.Line != 8 {
+		t.Errorf("expected type mismatch for service.port at line 8, got %+v", mismatch)
+	}
+}
+
+func TestValidateBytes_JSONErrors(t *testing.T) {
+	chartPath := filepath.Join(testdataDir(), "test-chart")
+	resolved, err := chart.Resolve(chartPath, "")
+	if err != nil {
+		t.Fatalf("failed to resolve chart: %v", err)
+	}
+	defer resolved.Cleanup()
+
+	_, err = ValidateBytes("values.json", []byte(`{"replicaCount": 1,}`), resolved, nil)
+	if err == nil || !strings.Contains(err.Error(), "parsing JSON values file values.json") {
+		t.Errorf("expected a JSON parse error, got %v", err)
+	}
+
+	_, err = ValidateBytes("values.json", []byte(`[1, 2]`), resolved, nil)
+	if err == nil || !strings.Contains(err.Error(), "expected a JSON object at top level") {
+		t.Errorf("expected a top-level JSON object error, got %v", err)
+	}
+}
+
+func TestValidate_TabIndentation(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "values.yaml")
+	if err := os.WriteFile(path, []byte("replicaCount: 2\nimage:\n  repository: nginx\n\ttag: latest\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	resolved, err := chart.Resolve(filepath.Join(testdataDir(), "test-chart"), "")
+	if err != nil {
+		t.Fatalf("failed to resolve chart: %v", err)
+	}
+	defer resolved.Cleanup()
+
+	_, err = Validate(path, resolved, nil)
+	if err == nil || !strings.Contains(err.Error(), "line 4 is indented with a tab") {
+		t.Errorf("expected the tab-indented line to be named, got %v", err)
+	}
+
+	_, err = ValidateBytes("values.yaml", []byte("image: [unclosed\n"), resolved, nil)
+	if err == nil || strings.Contains(err.Error(), "tab") {
+		t.Errorf("expected other syntax errors to be left as is, got %v", err)
+	}
+}
+
+func TestTabIndentedLine_NoneAfterReportedLine(t *testing.T) {
+	err := errors.New("yaml: line 3: found character that cannot start any token")
+	data := []byte("image:\n\ttag: latest\nservice: [\n")
+	if line := tabIndentedLine(err, data); line != 0 {
+		t.Errorf("expected no line when no tab-indented line follows the reported one, got %d", line)
+	}
+}
+
+func TestValidate_EmptyValuesFile(t *testing.T) {
+	chartPath := filepath.Join(testdataDir(), "test-chart")
+	resolved, err := chart.Resolve(chartPath, "")
+	if err != nil {
+		t.Fatalf("failed to resolve chart: %v", err)
+	}
+	defer resolved.Cleanup()
+
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{"empty", ""},
+		{"whitespace", "\n  \n"},
+		{"comments only", "# replicaCount: 3\n\n# image:\n#   tag: v1\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "values.yaml")
+			if err := os.WriteFile(path, []byte(tt.content), 0o644); err != nil {
+				t.Fatal(err)
+			}
+
+			_, err := Validate(path, resolved, nil)
+			if err == nil || !strings.Contains(err.Error(), "is empty") {
+				t.Errorf("expected an empty values file error, got %v", err)
+			}
+
+			result, err := ValidateWithOptions(path, resolved, Options{AllowEmpty: true})
+			if err != nil {
+				t.Fatalf("unexpected error with AllowEmpty: %v", err)
+			}
+			if len(result.Findings) != 0 {
+				t.Errorf("expected no findings with AllowEmpty, got %v", result.Findings)
+			}
+		})
+	}
+}
+
+func TestValidateWithOptions_StrictUnknown(t *testing.T) {
+	chartPath := filepath.Join(testdataDir(), "test-chart-with-schema")
+	resolved, err := chart.Resolve(chartPath, "")
+	if err != nil {
+		t.Fatalf("failed to resolve chart: %v", err)
+	}
+	defer resolved.Cleanup()
+
+	// extraConfig is declared in the schema but absent from values.yaml
+	data := []byte("extraConfig: {}\n")
+
+	result, err := ValidateBytes("values.yaml", data, resolved, nil)
+	if err != nil {
+		t.Fatalf("validation error: %v", err)
+	}
+	for _, f := range result.Findings {
+		if f.Rule == model.RuleUnknownKey {
+			t.Errorf("expected schema-declared key to be accepted, got %v", f)
+		}
+	}
+
+	result, err = ValidateBytesWithOptions("values.yaml", data, resolved, Options{StrictUnknown: true})
+	if err != nil {
+		t.Fatalf("validation error: %v", err)
+	}
+	var unknown []string
+	for _, f := range result.Findings {
+		if f.Rule == model.RuleUnknownKey {
+			unknown = append(unknown, f.KeyPath)
+		}
+	}
+	if len(unknown) != 1 || unknown[0] != "extraConfig" {
+		t.Errorf("expected extraConfig to be unknown with StrictUnknown, got %v", unknown)
+	}
+}
+
+func TestValidateWithOptions_StrictUnknownSuggestions(t *testing.T) {
+	dir := writeValuesDir(t, map[string]string{
+		"Chart.yaml":         "apiVersion: v2\nname: defaults\nversion: 0.1.0\n",
+		"values.yaml":        "replicaCount: 1\n",
+		"values.schema.json": `{"type": "object", "properties": {"timeout": {"type": "integer", "default": 30}}}`,
+	})
+	resolved, err := chart.Resolve(dir, "")
+	if err != nil {
+		t.Fatalf("failed to resolve chart: %v", err)
+	}
+	defer resolved.Cleanup()
+
+	result, err := ValidateBytesWithOptions("values.yaml", []byte("timeoutt: 5\n"), resolved, Options{StrictUnknown: true})
+	if err != nil {
+		t.Fatalf("validation error: %v", err)
+	}
+	for _, f := range result.Findings {
+		if f.Rule == model.RuleUnknownKey && f.Suggestion != "" {
+			t.Errorf("expected no schema-defaulted key to be suggested with StrictUnknown, got %q", f.Suggestion)
+		}
+	}
+}
+
+func TestValidate_PatternPropertiesInArrayItems(t *testing.T) {
+	dir := writeValuesDir(t, map[string]string{
+		"Chart.yaml":  "apiVersion: v2\nname: sidecars\nversion: 0.1.0\n",
+		"values.yaml": "extraContainers:\n  - name: sidecar\n    annotations:\n      team: core\n",
+		"values.schema.json": `{
+			"type": "object",
+			"properties": {
+				"extraContainers": {
+					"type": "array",
+					"items": {
+						"type": "object",
+						"properties": {
+							"annotations": {
+								"type": "object",
+								"patternProperties": {"^example\\.com/.*$": {"type": "string"}}
+							}
+						}
+					}
+				}
+			}
+		}`,
+	})
+	if patterns := extractSchemaPatterns([]byte(`{"items": {"patternProperties": {"^x-": {}}}}`)); len(patterns["[*]"]) != 1 {
+		t.Errorf("expected the patterns of array items under [*], got %v", patterns)
+	}
+
+	resolved, err := chart.Resolve(dir, "")
+	if err != nil {
+		t.Fatalf("failed to resolve chart: %v", err)
+	}
+	defer resolved.Cleanup()
+
+	data := []byte("extraContainers:\n  - name: web\n    annotations:\n      example.com/owner: team-a\n      other.org/owner: team-b\n")
+	result, err := ValidateBytes("values.yaml", data, resolved, nil)
+	if err != nil {
+		t.Fatalf("validation error: %v", err)
+	}
+	var unknown []string
+	for _, f := range result.Findings {
+		if f.Rule == model.RuleUnknownKey {
+			unknown = append(unknown, f.KeyPath)
+		}
+	}
+	if len(unknown) != 1 || unknown[0] != "extraContainers[0].annotations.other.org/owner" {
+		t.Errorf("expected only the key matching no pattern to be unknown, got %v", unknown)
+	}
+}
+
+func TestValidateFiles_PreservesInputOrder(t *testing.T) {
+	chartPath := filepath.Join(testdataDir(), "test-chart")
+	resolved, err := chart.Resolve(chartPath, "")
+	if err != nil {
+		t.Fatalf("failed to resolve chart: %v", err)
+	}
+	defer resolved.Cleanup()
+
+	var files []string
+	for i := 0; i < 20; i++ {
+		name := "good-values.yaml"
+		if i%3 == 0 {
+			name = "bad-values.yaml"
+		}
+		files = append(files, filepath.Join(testdataDir(), name))
+	}
+	files = append(files, filepath.Join(testdataDir(), "missing.yaml"))
+
+	results, errs := ValidateFiles(files, resolved, Options{}, 4)
+	if len(results) != len(files) || len(errs) != len(files) {
+		t.Fatalf("expected %d results and errors, got %d and %d", len(files), len(results), len(errs))
+	}
+	for i, file := range files[:len(files)-1] {
+		if errs[i] != nil {
+			t.Fatalf("unexpected error for %s: %v", file, errs[i])
+		}
+		if results[i].ValuesFile != file {
+			t.Errorf("result %d is for %s, want %s", i, results[i].ValuesFile, file)
+		}
+		if wantErrors := i%3 == 0; results[i].HasErrors() != wantErrors {
+			t.Errorf("result %d: HasErrors() = %v, want %v", i, results[i].HasErrors(), wantErrors)
+		}
+	}
+	if last := len(files) - 1; errs[last] == nil || results[last] != nil {
+		t.Errorf("expected only an error for the missing file, got %v and %v", results[last], errs[last])
+	}
+}
+
+func TestValidate_Profile(t *testing.T) {
+	resolved, err := chart.Resolve(filepath.Join(testdataDir(), "test-chart"), "")
+	if err != nil {
+		t.Fatalf("failed to resolve chart: %v", err)
+	}
+	defer resolved.Cleanup()
+
+	result, err := ValidateWithOptions(filepath.Join(testdataDir(), "good-values.yaml"), resolved, Options{Profile: true})
+	if err != nil {
+		t.Fatalf("validation error: %v", err)
+	}
+	want := []string{PhaseParse, PhasePrepare, PhaseUnknownKeys, PhaseTypes, PhaseSchema}
+	if len(result.Timings) != len(want) {
+		t.Fatalf("expected timings for %v, got %+v", want, result.Timings)
+	}
+	for i, phase := range want {
+		if result.Timings[i].Phase != phase {
+			t.Errorf("expected phase %d to be %q, got %q", i, phase, result.Timings[i].Phase)
+		}
+	}
+
+	result, err = ValidateWithOptions(filepath.Join(testdataDir(), "good-values.yaml"), resolved, Options{})
+	if err != nil {
+		t.Fatalf("validation error: %v", err)
+	}
+	if result.Timings != nil {
+		t.Errorf("expected no timings without Profile, got %+v", result.Timings)
+	}
+}
+
+func TestValidate_ValuesKey(t *testing.T) {
+	resolved, err := chart.Resolve(filepath.Join(testdataDir(), "test-chart"), "")
+	if err != nil {
+		t.Fatalf("failed to resolve chart: %v", err)
+	}
+	defer resolved.Cleanup()
+
+	data := []byte(`team: payments
+deploy:
+  helm:
+    replicaCount: 2
+    imgae:
+      tag: "1.2.3"
+`)
+	result, err := ValidateBytesWithOptions("platform.yaml", data, resolved, Options{ValuesKey: "deploy.helm"})
+	if err != nil {
+		t.Fatalf("validation error: %v", err)
+	}
+	if len(result.Findings) != 1 {
+		t.Fatalf("expected only the misspelled key to be reported, got %v", result.Findings)
+	}
+	f := result.Findings[0]
+	if f.Rule != model.RuleUnknownKey || f.KeyPath != "imgae" || f.Line != 5 {
+		t.Errorf("expected unknown key imgae at line 5, got %+v", f)
+	}
+
+	for key, want := range map[string]string{
+		"deploy.chart": "values key deploy.chart not found",
+		"team":         "values key team is not a mapping",
+	} {
+		_, err := ValidateBytesWithOptions("platform.yaml", data, resolved, Options{ValuesKey: key})
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("ValuesKey %s: expected error %q, got %v", key, want, err)
+		}
+	}
+}
+
+func TestValidate_SuggestsSchemaOnlyKeys(t *testing.T) {
+	resolved, err := chart.Resolve(filepath.Join(testdataDir(), "test-chart-with-schema"), "")
+	if err != nil {
+		t.Fatalf("failed to resolve chart: %v", err)
+	}
+	defer resolved.Cleanup()
+
+	// extraConfig is declared by the schema but has no default
+	data := []byte("auth:\n  username: admin\n  password: s3cret\nextraConfg:\n  debug: true\n")
+	result, err := ValidateBytesWithOptions("values.yaml", data, resolved, Options{})
+	if err != nil {
+		t.Fatalf("validation error: %v", err)
+	}
+	var found bool
+	for _, f := range result.Findings {
+		if f.Rule == model.RuleUnknownKey && f.KeyPath == "extraConfg" {
+			found = true
+			if f.Suggestion != "extraConfig" || f.SuggestionLine != 0 {
+				t.Errorf("expected the schema-only key extraConfig without a defaults line, got %q at line %d", f.Suggestion, f.SuggestionLine)
+			}
+		}
+	}
+	if !found {
+		t.Fatalf("expected extraConfg to be reported as unknown, got %v", result.Findings)
+	}
+}
+
+func TestValidateNode_NoSchemaMatchesEmptySchema(t *testing.T) {
+	resolved, err := chart.Resolve(filepath.Join(testdataDir(), "test-chart"), "")
+	if err != nil {
+		t.Fatalf("failed to resolve chart: %v", err)
+	}
+	defer resolved.Cleanup()
+	data, err := os.ReadFile(filepath.Join(testdataDir(), "bad-values.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A schema that allows anything must find what the no-schema path finds
+	withSchema := *resolved
+	withSchema.SchemaBytes = []byte(`{}`)
+	fast, err := ValidateBytes("bad-values.yaml", data, resolved, nil)
+	if err != nil {
+		t.Fatalf("validation error: %v", err)
+	}
+	full, err := ValidateBytes("bad-values.yaml", data, &withSchema, nil)
+	if err != nil {
+		t.Fatalf("validation error: %v", err)
+	}
+	if len(fast.Findings) == 0 || len(fast.Findings) != len(full.Findings) {
+		t.Fatalf("expected the same findings, got %v and %v", fast.Findings, full.Findings)
+	}
+	for i := range fast.Findings {
+		if fast.Findings[i].String() != full.Findings[i].String() {
+			t.Errorf("finding %d differs: %q vs %q", i, fast.Findings[i], full.Findings[i])
+		}
+	}
+}
+
+// BenchmarkValidateNode compares a chart with a schema against one without,
+// which skips the schema passes entirely.
+func BenchmarkValidateNode(b *testing.B) {
+	data, err := os.ReadFile(filepath.Join(testdataDir(), "bad-values.yaml"))
+	if err != nil {
+		b.Fatal(err)
+	}
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		b.Fatal(err)
+	}
+	for _, bm := range []struct{ name, chart string }{
+		{"Schema", "test-chart-with-schema"},
+		{"NoSchema", "test-chart"},
+	} {
+		resolved, err := chart.Resolve(filepath.Join(testdataDir(), bm.chart), "")
+		if err != nil {
+			b.Fatalf("failed to resolve chart: %v", err)
+		}
+		b.Run(bm.name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, err := ValidateNodeWithOptions(&doc, resolved, Options{}); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+		resolved.Cleanup()
+	}
+}